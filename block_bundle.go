@@ -0,0 +1,251 @@
+package evmc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxBlockBundleRange is the largest number of blocks fetched by one
+// [Evmc.GetBlockBundleRange] call, as the whole range is held in memory.
+const maxBlockBundleRange = 1000
+
+// BlockBundleConfig configures [Evmc.GetBlockBundleRange].
+type BlockBundleConfig struct {
+	// SkipTraces leaves the traces out, e.g. for nodes without the debug
+	// namespace.
+	SkipTraces bool
+	// Timeout is the tracer timeout of each debug_traceBlockByNumber request.
+	Timeout time.Duration
+	// Reexec is passed through to the tracer, see [TraceConfig].
+	Reexec *uint64
+	// Tracer configures the callTracer.
+	Tracer *CallTracerConfig
+}
+
+// BlockBundle is a block with its uncles, receipts and call traces.
+type BlockBundle struct {
+	// Block has its UncleBlocks filled in.
+	Block *evmctypes.BlockIncTx
+	// Transactions holds each transaction of Block, in block order, joined
+	// with its receipt and trace.
+	Transactions []*BundledTransaction
+}
+
+// BundledTransaction is a transaction of a [BlockBundle].
+type BundledTransaction struct {
+	Transaction *evmctypes.Transaction
+	Receipt     *evmctypes.Receipt
+	// Trace is nil when [BlockBundleConfig.SkipTraces] is set. A transaction
+	// the node failed to trace has Trace.Error set.
+	Trace *evmctypes.CallTracer
+}
+
+// GetBlockBundle returns the bundle of block number, see
+// [Evmc.GetBlockBundleRange].
+func (e *Evmc) GetBlockBundle(number uint64, cfg *BlockBundleConfig) (*BlockBundle, error) {
+	return e.GetBlockBundleWithContext(context.Background(), number, cfg)
+}
+
+// GetBlockBundleWithContext is the context-aware variant of
+// [Evmc.GetBlockBundle].
+func (e *Evmc) GetBlockBundleWithContext(ctx context.Context, number uint64, cfg *BlockBundleConfig) (*BlockBundle, error) {
+	bundles, err := e.getBlockBundleRange(ctx, number, number, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return bundles[0], nil
+}
+
+// GetBlockBundleRange returns the blocks from..to (inclusive) with their
+// transactions, uncles, receipts and callTracer traces. Blocks, receipts and
+// traces are requested in the same batches; receipts and traces are then
+// joined onto their transactions by hash and their counts validated against
+// the block. Uncles of all blocks are fetched in one more batch. Receipts
+// fall back like [ethNamespace.GetBlockReceipts]. With
+// [WithBlockIntegrityCheck] every block is verified against its receipts.
+// At most 1000 blocks are fetched per call; larger ranges return
+// [ErrInvalidRange].
+func (e *Evmc) GetBlockBundleRange(from, to uint64, cfg *BlockBundleConfig) ([]*BlockBundle, error) {
+	return e.GetBlockBundleRangeWithContext(context.Background(), from, to, cfg)
+}
+
+// GetBlockBundleRangeWithContext is the context-aware variant of
+// [Evmc.GetBlockBundleRange].
+func (e *Evmc) GetBlockBundleRangeWithContext(
+	ctx context.Context,
+	from, to uint64,
+	cfg *BlockBundleConfig,
+) ([]*BlockBundle, error) {
+	return e.getBlockBundleRange(ctx, from, to, cfg)
+}
+
+func (e *Evmc) getBlockBundleRange(ctx context.Context, from, to uint64, cfg *BlockBundleConfig) ([]*BlockBundle, error) {
+	if from > to || to-from >= maxBlockBundleRange {
+		return nil, ErrInvalidRange
+	}
+	if cfg == nil {
+		cfg = &BlockBundleConfig{}
+	}
+	withTraces := !cfg.SkipTraces
+	if withTraces {
+		if err := requireCapability(e, DebugTraceBlockByNumber.String(), func(c *Capabilities) bool {
+			return c.HasNamespace("debug")
+		}); err != nil {
+			return nil, err
+		}
+	}
+	withReceipts := true
+	if caps := e.cachedCapabilities(); caps != nil && !caps.BlockReceipts {
+		withReceipts = false
+	}
+
+	var (
+		size     = int(to - from + 1)
+		blocks   = make([]*evmctypes.BlockIncTx, size)
+		receipts = make([][]*evmctypes.Receipt, size)
+		traces   = make([][]*evmctypes.CallTracer, size)
+		elements = make([]rpc.BatchElem, 0, size*3)
+		traceCfg = newTracerConfig(CallTracer, cfg.Timeout, cfg.Reexec, cfg.Tracer)
+	)
+	for i := range size {
+		number := hexutil.EncodeUint64(from + uint64(i))
+		elements = append(elements, rpc.BatchElem{
+			Method: EthGetBlockByNumber.String(),
+			Args:   []any{number, true},
+			Result: &blocks[i],
+		})
+		if withReceipts {
+			elements = append(elements, rpc.BatchElem{
+				Method: EthGetBlockReceipts.String(),
+				Args:   []any{number},
+				Result: &receipts[i],
+			})
+		}
+		if withTraces {
+			elements = append(elements, rpc.BatchElem{
+				Method: DebugTraceBlockByNumber.String(),
+				Args:   []any{number, *traceCfg},
+				Result: &traces[i],
+			})
+		}
+	}
+	if err := e.BatchCallWithContext(ctx, elements, -1); err != nil {
+		return nil, err
+	}
+
+	for _, el := range elements {
+		if el.Error == nil {
+			continue
+		}
+		switch el.Method {
+		case EthGetBlockReceipts.String():
+			if !methodExists(el.Error) {
+				withReceipts = false
+				continue
+			}
+			return nil, fmt.Errorf("receipts of block %s: %w", el.Args[0], el.Error)
+		case DebugTraceBlockByNumber.String():
+			return nil, fmt.Errorf("trace block %s: %w", el.Args[0], el.Error)
+		default:
+			return nil, el.Error
+		}
+	}
+	for i, block := range blocks {
+		if block == nil || block.Hash == "" {
+			return nil, fmt.Errorf("block %d not found", from+uint64(i))
+		}
+		if withReceipts && receipts[i] == nil {
+			return nil, fmt.Errorf("receipts of block %d not found", from+uint64(i))
+		}
+	}
+	if !withReceipts {
+		var err error
+		if receipts, err = e.eth.getBlockReceiptsRange(ctx, from, to); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := e.eth.fillUncleBlocks(ctx, blocks); err != nil {
+		return nil, err
+	}
+
+	bundles := make([]*BlockBundle, size)
+	for i, block := range blocks {
+		if withReceipts {
+			txHashes := make([]string, len(block.Transactions))
+			for j, tx := range block.Transactions {
+				txHashes[j] = tx.Hash
+			}
+			sortReceipts(receipts[i])
+			receipts[i] = dropStateSyncReceipt(txHashes, receipts[i])
+		}
+		if e.eth.verifyBlocks {
+			if err := VerifyBlock(block, receipts[i]); err != nil {
+				return nil, err
+			}
+		}
+		bundle, err := newBlockBundle(block, receipts[i], traces[i], withTraces)
+		if err != nil {
+			return nil, err
+		}
+		bundles[i] = bundle
+	}
+	return bundles, nil
+}
+
+// newBlockBundle joins receipts and traces onto the transactions of block.
+// Traces without a txHash are matched by position.
+func newBlockBundle(
+	block *evmctypes.BlockIncTx,
+	receipts []*evmctypes.Receipt,
+	traces []*evmctypes.CallTracer,
+	withTraces bool,
+) (*BlockBundle, error) {
+	txs := block.Transactions
+	if len(receipts) != len(txs) {
+		return nil, fmt.Errorf("%w: block %d has %d transactions, got %d receipts",
+			ErrReceiptMismatch, block.Number, len(txs), len(receipts))
+	}
+	if withTraces && len(traces) != len(txs) {
+		return nil, fmt.Errorf("%w: block %d has %d transactions, got %d traces",
+			ErrTraceMismatch, block.Number, len(txs), len(traces))
+	}
+
+	receiptsByHash := make(map[string]*evmctypes.Receipt, len(receipts))
+	for _, receipt := range receipts {
+		receiptsByHash[receipt.TransactionHash] = receipt
+	}
+	tracesByHash := make(map[string]*evmctypes.CallTracer, len(traces))
+	for _, trace := range traces {
+		if trace.TxHash != "" {
+			tracesByHash[trace.TxHash] = trace
+		}
+	}
+
+	bundle := &BlockBundle{Block: block, Transactions: make([]*BundledTransaction, len(txs))}
+	for i, tx := range txs {
+		receipt, ok := receiptsByHash[tx.Hash]
+		if !ok {
+			return nil, fmt.Errorf("%w: no receipt for transaction %s", ErrReceiptMismatch, tx.Hash)
+		}
+		bundled := &BundledTransaction{Transaction: tx, Receipt: receipt}
+		if withTraces {
+			trace, ok := tracesByHash[tx.Hash]
+			if !ok {
+				if traces[i].TxHash != "" {
+					return nil, fmt.Errorf("%w: no trace for transaction %s", ErrTraceMismatch, tx.Hash)
+				}
+				trace = traces[i]
+			}
+			assignIndexCalls(trace.Result)
+			bundled.Trace = trace
+		}
+		bundle.Transactions[i] = bundled
+	}
+	return bundle, nil
+}
@@ -0,0 +1,149 @@
+package evmc
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBundleMock은 블록마다 트랜잭션 0xtx1 하나를 가진 노드를 흉내낸다.
+// receiptsMethod로 블록 receipt를 제공하는 메서드를 고른다.
+func newBundleMock(t *testing.T, receiptsMethod string, traces func(number string) any) *mockRPCServer {
+	t.Helper()
+	mock := newMockRPCServer(t)
+	mock.on("eth_getBlockByNumber", func(params json.RawMessage) any {
+		var args []any
+		require.NoError(t, json.Unmarshal(params, &args))
+		block := blockJSON(args[0].(string), "0xhash"+args[0].(string), false)
+		if args[1] == false {
+			block["transactions"] = []string{"0xtx1"}
+		}
+		return block
+	})
+	mock.on(receiptsMethod, func(params json.RawMessage) any {
+		var args []string
+		require.NoError(t, json.Unmarshal(params, &args))
+		return []map[string]any{receiptJSON("0xtx1", args[0], "0xhash"+args[0])}
+	})
+	mock.on("debug_traceBlockByNumber", func(params json.RawMessage) any {
+		var args []any
+		require.NoError(t, json.Unmarshal(params, &args))
+		assert.Equal(t, "callTracer", args[1].(map[string]any)["tracer"])
+		return traces(args[0].(string))
+	})
+	return mock
+}
+
+func callTraceJSON(txHash string) map[string]any {
+	return map[string]any{
+		"txHash": txHash,
+		"result": map[string]any{
+			"type":    "CALL",
+			"from":    "0xfrom",
+			"to":      "0xto",
+			"gas":     "0x5208",
+			"gasUsed": "0x5208",
+			"input":   "0x",
+			"calls": []map[string]any{
+				{"type": "STATICCALL", "from": "0xto", "to": "0xother", "gas": "0x100", "gasUsed": "0x10", "input": "0x"},
+			},
+		},
+	}
+}
+
+func Test_Evmc_mock_GetBlockBundleRange(t *testing.T) {
+	mock := newBundleMock(t, "eth_getBlockReceipts", func(string) any {
+		return []any{callTraceJSON("0xtx1")}
+	})
+	client := testEvmc(mock.url())
+
+	bundles, err := client.GetBlockBundleRange(1, 2, nil)
+	require.NoError(t, err)
+	require.Len(t, bundles, 2)
+	for i, bundle := range bundles {
+		assert.Equal(t, uint64(i+1), bundle.Block.Number)
+		require.Len(t, bundle.Transactions, 1)
+		tx := bundle.Transactions[0]
+		assert.Equal(t, "0xtx1", tx.Transaction.Hash)
+		assert.Equal(t, "0xtx1", tx.Receipt.TransactionHash)
+		require.NotNil(t, tx.Trace)
+		require.Len(t, tx.Trace.Result.Calls, 1)
+		assert.Equal(t, "STATICCALL", tx.Trace.Result.Calls[0].Type)
+	}
+
+	bundle, err := client.GetBlockBundle(3, &BlockBundleConfig{SkipTraces: true})
+	require.NoError(t, err)
+	assert.Nil(t, bundle.Transactions[0].Trace)
+
+	_, err = client.GetBlockBundleRange(2, 1, nil)
+	assert.ErrorIs(t, err, ErrInvalidRange)
+}
+
+func Test_Evmc_mock_GetBlockBundleRange_fallbackAndMismatch(t *testing.T) {
+	// eth_getBlockReceipts가 없으면 Bor 메서드로 receipt를 조회한다.
+	mock := newBundleMock(t, "eth_getTransactionReceiptsByBlock", func(number string) any {
+		if number == "0x2" {
+			return []any{}
+		}
+		// txHash가 없으면 위치로 연결한다.
+		trace := callTraceJSON("")
+		delete(trace, "txHash")
+		return []any{trace}
+	})
+	client := testEvmc(mock.url())
+
+	bundle, err := client.GetBlockBundle(1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "0xtx1", bundle.Transactions[0].Receipt.TransactionHash)
+	require.NotNil(t, bundle.Transactions[0].Trace)
+
+	_, err = client.GetBlockBundleRange(1, 2, nil)
+	assert.ErrorIs(t, err, ErrTraceMismatch)
+
+	_, err = testEvmc(newMockRPCServer(t).url()).GetBlockBundle(1, nil)
+	assert.Error(t, err)
+}
+
+func Test_Evmc_mock_GetBlockBundleRange_uncles(t *testing.T) {
+	mock := newBundleMock(t, "eth_getBlockReceipts", func(string) any {
+		return []any{callTraceJSON("0xtx1")}
+	})
+	mock.on("eth_getBlockByNumber", func(params json.RawMessage) any {
+		var args []any
+		require.NoError(t, json.Unmarshal(params, &args))
+		number := args[0].(string)
+		block := blockJSON(number, "0xhash"+number, false)
+		if number != "0x1" {
+			block["uncles"] = []string{"0xuncle" + number + "a", "0xuncle" + number + "b"}
+		}
+		return block
+	})
+	mock.on("eth_getUncleByBlockNumberAndIndex", func(params json.RawMessage) any {
+		var args []string
+		require.NoError(t, json.Unmarshal(params, &args))
+		return blockJSON("0x0", "0xuncle"+args[0]+args[1], true)
+	})
+	client := testEvmc(mock.url())
+
+	// 여러 블록의 uncle을 한 번의 batch로 채운다.
+	bundles, err := client.GetBlockBundleRange(1, 3, &BlockBundleConfig{SkipTraces: true})
+	require.NoError(t, err)
+	require.Len(t, bundles, 3)
+	assert.Empty(t, bundles[0].Block.UncleBlocks)
+	for _, bundle := range bundles[1:] {
+		require.Len(t, bundle.Block.UncleBlocks, 2)
+		number := hexutil.EncodeUint64(bundle.Block.Number)
+		assert.Equal(t, "0xuncle"+number+"0x0", bundle.Block.UncleBlocks[0].Hash)
+		assert.Equal(t, "0xuncle"+number+"0x1", bundle.Block.UncleBlocks[1].Hash)
+	}
+
+	// 한 번에 가져오는 블록 수는 제한된다.
+	_, err = client.GetBlockBundleRange(0, maxBlockBundleRange, nil)
+	assert.ErrorIs(t, err, ErrInvalidRange)
+	_, err = client.GetBlockBundleRange(0, math.MaxUint64, nil)
+	assert.ErrorIs(t, err, ErrInvalidRange)
+}
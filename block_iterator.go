@@ -0,0 +1,289 @@
+package evmc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/bbaktaeho/evmc/evmctypes/kaiatypes"
+)
+
+const (
+	defaultBlockIteratorWindow   = 100
+	defaultBlockIteratorPrefetch = 1
+)
+
+// BlockIteratorConfig configures the Iterate* block range methods.
+type BlockIteratorConfig struct {
+	// WindowSize is the number of blocks fetched per batch. Default: 100.
+	WindowSize uint64
+	// Prefetch is the number of windows fetched ahead of the consumer; the
+	// iterator stops fetching while that many windows are waiting. Default: 1.
+	Prefetch int
+	// Checkpoint, when set, records the last block the consumer has moved
+	// past with Next and lets a later iterator resume after it. It is saved
+	// once per window and on Close rather than for every block.
+	Checkpoint Checkpoint
+}
+
+func (c *BlockIteratorConfig) withDefaults() *BlockIteratorConfig {
+	cfg := BlockIteratorConfig{}
+	if c != nil {
+		cfg = *c
+	}
+	if cfg.WindowSize == 0 {
+		cfg.WindowSize = defaultBlockIteratorWindow
+	}
+	if cfg.Prefetch < 1 {
+		cfg.Prefetch = defaultBlockIteratorPrefetch
+	}
+	return &cfg
+}
+
+// BlockIterator walks a block range in ascending order while fetching it in
+// windows in the background, so only a few windows are held in memory.
+//
+//	it := client.Eth().IterateBlockIncTxRange(from, to, nil)
+//	defer it.Close()
+//	for it.Next() {
+//		block := it.Block()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type BlockIterator[T any] struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	cfg     *BlockIteratorConfig
+	fetch   func(ctx context.Context, from, to uint64) ([]T, error)
+	windows chan blockWindow[T]
+	done    chan struct{}
+
+	window   blockWindow[T]
+	index    int
+	current  bool
+	finished bool
+	err      error
+
+	// last is the last block moved past, not yet saved while unsaved is set.
+	last    uint64
+	unsaved bool
+}
+
+type blockWindow[T any] struct {
+	from   uint64
+	blocks []T
+	err    error
+}
+
+func newBlockIterator[T any](
+	ctx context.Context,
+	from, to uint64,
+	cfg *BlockIteratorConfig,
+	fetch func(ctx context.Context, from, to uint64) ([]T, error),
+) *BlockIterator[T] {
+	cfg = cfg.withDefaults()
+	ctx, cancel := context.WithCancel(ctx)
+	it := &BlockIterator[T]{
+		ctx:     ctx,
+		cancel:  cancel,
+		cfg:     cfg,
+		fetch:   fetch,
+		windows: make(chan blockWindow[T], cfg.Prefetch),
+		done:    make(chan struct{}),
+	}
+	go it.run(from, to)
+	return it
+}
+
+func (it *BlockIterator[T]) run(from, to uint64) {
+	defer close(it.done)
+	defer close(it.windows)
+	send := func(w blockWindow[T]) bool {
+		select {
+		case it.windows <- w:
+			return w.err == nil
+		case <-it.ctx.Done():
+			return false
+		}
+	}
+	if from > to {
+		send(blockWindow[T]{err: ErrInvalidRange})
+		return
+	}
+	if it.cfg.Checkpoint != nil {
+		last, ok, err := it.cfg.Checkpoint.Load(it.ctx)
+		if err != nil {
+			send(blockWindow[T]{err: fmt.Errorf("BlockIterator: load checkpoint: %w", err)})
+			return
+		}
+		if ok && last >= from {
+			if last >= to {
+				return
+			}
+			from = last + 1
+		}
+	}
+	for start := from; ; {
+		end := to
+		if to-start >= it.cfg.WindowSize {
+			end = start + it.cfg.WindowSize - 1
+		}
+		blocks, err := it.fetch(it.ctx, start, end)
+		if !send(blockWindow[T]{from: start, blocks: blocks, err: err}) || end == to {
+			return
+		}
+		start = end + 1
+	}
+}
+
+// Next advances to the next block and reports whether there is one. It
+// returns false at the end of the range or on error, see [BlockIterator.Err].
+func (it *BlockIterator[T]) Next() bool {
+	if it.err != nil || it.finished {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+	if it.current {
+		it.current = false
+		it.last, it.unsaved = it.Number(), it.cfg.Checkpoint != nil
+		it.index++
+		if it.index == len(it.window.blocks) {
+			if err := it.saveCheckpoint(it.ctx); err != nil {
+				it.err = err
+				return false
+			}
+		}
+	}
+	for it.index >= len(it.window.blocks) {
+		var (
+			w  blockWindow[T]
+			ok bool
+		)
+		select {
+		case w, ok = <-it.windows:
+		case <-it.ctx.Done():
+		}
+		if !ok {
+			it.err = it.ctx.Err()
+			it.finished = it.err == nil
+			return false
+		}
+		if w.err != nil {
+			it.err = w.err
+			return false
+		}
+		it.window, it.index = w, 0
+	}
+	it.current = true
+	return true
+}
+
+// Block returns the current block.
+func (it *BlockIterator[T]) Block() T {
+	return it.window.blocks[it.index]
+}
+
+// Number returns the number of the current block.
+func (it *BlockIterator[T]) Number() uint64 {
+	return it.window.from + uint64(it.index)
+}
+
+// Err returns the error that stopped the iterator, if any. Closing the
+// iterator before the end of the range reports [context.Canceled].
+func (it *BlockIterator[T]) Err() error {
+	return it.err
+}
+
+// Close saves the checkpoint, stops fetching and releases the buffered
+// windows. It is safe to call more than once. A failed save is reported by
+// [BlockIterator.Err].
+func (it *BlockIterator[T]) Close() {
+	if err := it.saveCheckpoint(context.WithoutCancel(it.ctx)); err != nil && it.err == nil {
+		it.err = err
+	}
+	it.cancel()
+	<-it.done
+}
+
+func (it *BlockIterator[T]) saveCheckpoint(ctx context.Context) error {
+	if !it.unsaved {
+		return nil
+	}
+	if err := it.cfg.Checkpoint.Save(ctx, it.last); err != nil {
+		return fmt.Errorf("BlockIterator: save checkpoint: %w", err)
+	}
+	it.unsaved = false
+	return nil
+}
+
+// IterateBlockRange streams the blocks from..to (inclusive) with transaction
+// hashes only, fetching them in windows through [ethNamespace.GetBlockRange].
+func (e *ethNamespace) IterateBlockRange(from, to uint64, cfg *BlockIteratorConfig) *BlockIterator[*evmctypes.Block] {
+	return e.IterateBlockRangeWithContext(context.Background(), from, to, cfg)
+}
+
+// IterateBlockRangeWithContext is the context-aware variant of
+// [ethNamespace.IterateBlockRange]; ctx bounds the lifetime of the iterator.
+func (e *ethNamespace) IterateBlockRangeWithContext(
+	ctx context.Context,
+	from, to uint64,
+	cfg *BlockIteratorConfig,
+) *BlockIterator[*evmctypes.Block] {
+	return newBlockIterator(ctx, from, to, cfg, e.getBlockRange)
+}
+
+// IterateBlockIncTxRange streams the blocks from..to (inclusive) with full
+// transactions, fetching them in windows through
+// [ethNamespace.GetBlockIncTxRange].
+func (e *ethNamespace) IterateBlockIncTxRange(from, to uint64, cfg *BlockIteratorConfig) *BlockIterator[*evmctypes.BlockIncTx] {
+	return e.IterateBlockIncTxRangeWithContext(context.Background(), from, to, cfg)
+}
+
+// IterateBlockIncTxRangeWithContext is the context-aware variant of
+// [ethNamespace.IterateBlockIncTxRange]; ctx bounds the lifetime of the
+// iterator.
+func (e *ethNamespace) IterateBlockIncTxRangeWithContext(
+	ctx context.Context,
+	from, to uint64,
+	cfg *BlockIteratorConfig,
+) *BlockIterator[*evmctypes.BlockIncTx] {
+	return newBlockIterator(ctx, from, to, cfg, e.getBlockIncTxRange)
+}
+
+// IterateBlockRange streams the blocks from..to (inclusive) with transaction
+// hashes only, fetching them in windows through [kaiaNamespace.GetBlockRange].
+func (k *kaiaNamespace) IterateBlockRange(from, to uint64, cfg *BlockIteratorConfig) *BlockIterator[*kaiatypes.Block] {
+	return k.IterateBlockRangeWithContext(context.Background(), from, to, cfg)
+}
+
+// IterateBlockRangeWithContext streams the blocks from..to (inclusive) with
+// transaction hashes only; ctx bounds the lifetime of the iterator.
+func (k *kaiaNamespace) IterateBlockRangeWithContext(
+	ctx context.Context,
+	from, to uint64,
+	cfg *BlockIteratorConfig,
+) *BlockIterator[*kaiatypes.Block] {
+	return newBlockIterator(ctx, from, to, cfg, k.GetBlockRangeWithContext)
+}
+
+// IterateBlockIncTxRange streams the blocks from..to (inclusive) with full
+// transactions, fetching them in windows through
+// [kaiaNamespace.GetBlockIncTxRange].
+func (k *kaiaNamespace) IterateBlockIncTxRange(from, to uint64, cfg *BlockIteratorConfig) *BlockIterator[*kaiatypes.BlockIncTx] {
+	return k.IterateBlockIncTxRangeWithContext(context.Background(), from, to, cfg)
+}
+
+// IterateBlockIncTxRangeWithContext streams the blocks from..to (inclusive)
+// with full transactions; ctx bounds the lifetime of the iterator.
+func (k *kaiaNamespace) IterateBlockIncTxRangeWithContext(
+	ctx context.Context,
+	from, to uint64,
+	cfg *BlockIteratorConfig,
+) *BlockIterator[*kaiatypes.BlockIncTx] {
+	return newBlockIterator(ctx, from, to, cfg, k.getBlockIncTxRange)
+}
@@ -0,0 +1,155 @@
+package evmc
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryCheckpoint는 진행 상황을 메모리에 저장하는 Checkpoint.
+type memoryCheckpoint struct {
+	last  uint64
+	saved bool
+	saves int
+}
+
+func (m *memoryCheckpoint) Load(context.Context) (uint64, bool, error) {
+	return m.last, m.saved, nil
+}
+
+func (m *memoryCheckpoint) Save(_ context.Context, number uint64) error {
+	m.last, m.saved = number, true
+	m.saves++
+	return nil
+}
+
+// newBlockIteratorMock은 missing 블록을 제외한 모든 블록을 반환하고 조회한 블록 수를 센다.
+func newBlockIteratorMock(t *testing.T, fetched *atomic.Int64, missing uint64) *mockRPCServer {
+	t.Helper()
+	mock := newMockRPCServer(t)
+	mock.on("eth_getBlockByNumber", func(params json.RawMessage) any {
+		var args []any
+		require.NoError(t, json.Unmarshal(params, &args))
+		fetched.Add(1)
+		number := hexutil.MustDecodeUint64(args[0].(string))
+		if number == missing {
+			return nil
+		}
+		return blockJSON(args[0].(string), "0xhash", args[1] == false)
+	})
+	return mock
+}
+
+func Test_ethNamespace_mock_IterateBlockIncTxRange(t *testing.T) {
+	var fetched atomic.Int64
+	client := testEvmc(newBlockIteratorMock(t, &fetched, 0).url())
+
+	it := client.Eth().IterateBlockIncTxRange(1, 250, &BlockIteratorConfig{WindowSize: 100})
+	defer it.Close()
+	want := uint64(1)
+	for it.Next() {
+		assert.Equal(t, want, it.Number())
+		assert.Equal(t, want, it.Block().Number)
+		require.Len(t, it.Block().Transactions, 1)
+		want++
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, uint64(251), want)
+	assert.Equal(t, int64(250), fetched.Load())
+
+	blocks := client.Eth().IterateBlockRange(5, 5, nil)
+	defer blocks.Close()
+	require.True(t, blocks.Next())
+	assert.Equal(t, []string{"0xtx1", "0xtx2"}, blocks.Block().Transactions)
+	assert.False(t, blocks.Next())
+	assert.NoError(t, blocks.Err())
+
+	invalid := client.Eth().IterateBlockRange(2, 1, nil)
+	defer invalid.Close()
+	assert.False(t, invalid.Next())
+	assert.ErrorIs(t, invalid.Err(), ErrInvalidRange)
+}
+
+func Test_BlockIterator_mock_backpressure(t *testing.T) {
+	var fetched atomic.Int64
+	client := testEvmc(newBlockIteratorMock(t, &fetched, 0).url())
+
+	it := client.Eth().IterateBlockRange(1, 10000, &BlockIteratorConfig{WindowSize: 10, Prefetch: 1})
+	require.True(t, it.Next())
+	time.Sleep(100 * time.Millisecond)
+	// 소비 중인 window, 버퍼의 window, 전송 대기 중인 window까지만 조회한다.
+	assert.LessOrEqual(t, fetched.Load(), int64(30))
+
+	it.Close()
+	assert.False(t, it.Next())
+	assert.ErrorIs(t, it.Err(), context.Canceled)
+}
+
+func Test_BlockIterator_mock_checkpointAndError(t *testing.T) {
+	var fetched atomic.Int64
+	client := testEvmc(newBlockIteratorMock(t, &fetched, 8).url())
+	checkpoint := &memoryCheckpoint{}
+	cfg := &BlockIteratorConfig{WindowSize: 3, Checkpoint: checkpoint}
+
+	it := client.Eth().IterateBlockRange(1, 10, cfg)
+	for it.Next() && it.Number() < 4 {
+	}
+	it.Close()
+	// 4번 블록은 처리가 끝나지 않았으므로 3번까지 기록된다.
+	assert.Equal(t, uint64(3), checkpoint.last)
+	// 블록마다가 아니라 window가 끝날 때 저장한다.
+	assert.Equal(t, 1, checkpoint.saves)
+
+	it = client.Eth().IterateBlockRange(1, 10, cfg)
+	defer it.Close()
+	var numbers []uint64
+	for it.Next() {
+		numbers = append(numbers, it.Number())
+	}
+	assert.Equal(t, []uint64{4, 5, 6}, numbers)
+	assert.ErrorContains(t, it.Err(), "block 8 not found")
+	assert.Equal(t, uint64(6), checkpoint.last)
+	assert.Equal(t, 2, checkpoint.saves)
+}
+
+func Test_BlockIterator_mock_checkpointOnClose(t *testing.T) {
+	var fetched atomic.Int64
+	client := testEvmc(newBlockIteratorMock(t, &fetched, 0).url())
+	checkpoint := &memoryCheckpoint{}
+
+	it := client.Eth().IterateBlockRange(1, 10, &BlockIteratorConfig{WindowSize: 4, Checkpoint: checkpoint})
+	for it.Next() && it.Number() < 3 {
+	}
+	assert.False(t, checkpoint.saved)
+
+	// window 중간에 닫으면 Close에서 마지막으로 지나간 블록을 저장한다.
+	it.Close()
+	it.Close()
+	assert.Equal(t, uint64(2), checkpoint.last)
+	assert.Equal(t, 1, checkpoint.saves)
+}
+
+func Test_kaiaNamespace_mock_IterateBlockRange(t *testing.T) {
+	mock := newMockRPCServer(t)
+	mock.on("kaia_getBlockByNumber", func(params json.RawMessage) any {
+		var args []any
+		require.NoError(t, json.Unmarshal(params, &args))
+		return kaiaConsensusBlockJSON(t, args[0].(string))
+	})
+	client := testEvmc(mock.url())
+
+	it := client.Kaia().IterateBlockRange(10, 14, &BlockIteratorConfig{WindowSize: 2})
+	defer it.Close()
+	var numbers []uint64
+	for it.Next() {
+		numbers = append(numbers, it.Number())
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []uint64{10, 11, 12, 13, 14}, numbers)
+}
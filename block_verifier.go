@@ -0,0 +1,254 @@
+package evmc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// FieldMismatch is a block field whose reported value differs from the value
+// recomputed from the rest of the block.
+type FieldMismatch struct {
+	Field    string
+	Got      string
+	Computed string
+}
+
+// BlockIntegrityError reports every mismatching field of a block. It matches
+// [ErrBlockIntegrity] with errors.Is.
+type BlockIntegrityError struct {
+	Number     uint64
+	Hash       string
+	Mismatches []FieldMismatch
+}
+
+func (e *BlockIntegrityError) Error() string {
+	fields := make([]string, len(e.Mismatches))
+	for i, m := range e.Mismatches {
+		fields[i] = fmt.Sprintf("%s got %s computed %s", m.Field, m.Got, m.Computed)
+	}
+	return fmt.Sprintf("%s: block %d (%s): %s", ErrBlockIntegrity, e.Number, e.Hash, strings.Join(fields, "; "))
+}
+
+func (e *BlockIntegrityError) Unwrap() error {
+	return ErrBlockIntegrity
+}
+
+// VerifyBlock recomputes the block hash, transactions root, withdrawals root
+// and, when receipts are given, the receipts root and logs bloom of block,
+// and returns a [*BlockIntegrityError] listing every field that does not
+// match. receipts is the output of eth_getBlockReceipts; pass nil to check
+// the header and body only.
+//
+// Only Ethereum transaction types (legacy through EIP-7702) can be
+// re-encoded; blocks containing other types (e.g. OP Stack deposits) return
+// a plain error.
+func VerifyBlock(block *evmctypes.BlockIncTx, receipts []*evmctypes.Receipt) error {
+	header, err := rebuildHeader(block.Header())
+	if err != nil {
+		return fmt.Errorf("VerifyBlock: %w", err)
+	}
+	txs := make(types.Transactions, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		if txs[i], err = rebuildTransaction(tx); err != nil {
+			return fmt.Errorf("VerifyBlock: tx %d: %w", i, err)
+		}
+	}
+
+	var mismatches []FieldMismatch
+	check := func(field, got, computed string) {
+		if !strings.EqualFold(got, computed) {
+			mismatches = append(mismatches, FieldMismatch{Field: field, Got: got, Computed: computed})
+		}
+	}
+
+	check("hash", block.Hash, header.Hash().Hex())
+	for i, tx := range txs {
+		check(fmt.Sprintf("transactions[%d].hash", i), block.Transactions[i].Hash, tx.Hash().Hex())
+	}
+	check("transactionsRoot", block.TransactionsRoot, types.DeriveSha(txs, trie.NewStackTrie(nil)).Hex())
+	if block.WithdrawalsRoot != nil {
+		withdrawals := make(types.Withdrawals, len(block.Withdrawals))
+		for i, w := range block.Withdrawals {
+			withdrawals[i] = &types.Withdrawal{
+				Index:     w.Index,
+				Validator: w.ValidatorIndex,
+				Address:   common.HexToAddress(w.Address),
+				Amount:    w.Amount,
+			}
+		}
+		check("withdrawalsRoot", *block.WithdrawalsRoot, types.DeriveSha(withdrawals, trie.NewStackTrie(nil)).Hex())
+	}
+
+	if receipts != nil {
+		rebuilt := make(types.Receipts, len(receipts))
+		for i, r := range receipts {
+			if rebuilt[i], err = rebuildReceipt(r); err != nil {
+				return fmt.Errorf("VerifyBlock: receipt %d: %w", i, err)
+			}
+			rebuilt[i].Bloom = types.CreateBloom(rebuilt[i])
+			check(fmt.Sprintf("receipts[%d].logsBloom", i), r.LogsBloom, hexutil.Encode(rebuilt[i].Bloom.Bytes()))
+			if i < len(block.Transactions) {
+				check(fmt.Sprintf("receipts[%d].transactionHash", i), r.TransactionHash, block.Transactions[i].Hash)
+			}
+		}
+		if len(receipts) != len(txs) {
+			check("receipts", fmt.Sprint(len(receipts)), fmt.Sprint(len(txs)))
+		}
+		check("receiptsRoot", block.ReceiptsRoot, types.DeriveSha(rebuilt, trie.NewStackTrie(nil)).Hex())
+		check("logsBloom", block.LogsBloom, hexutil.Encode(types.MergeBloom(rebuilt).Bytes()))
+	}
+
+	if len(mismatches) > 0 {
+		return &BlockIntegrityError{Number: block.Number, Hash: block.Hash, Mismatches: mismatches}
+	}
+	return nil
+}
+
+func rebuildHeader(block *evmctypes.Header) (*types.Header, error) {
+	header := &types.Header{
+		ParentHash:  common.HexToHash(block.ParentHash),
+		UncleHash:   common.HexToHash(block.Sha3Uncles),
+		Coinbase:    common.HexToAddress(block.Miner),
+		Root:        common.HexToHash(block.StateRoot),
+		TxHash:      common.HexToHash(block.TransactionsRoot),
+		ReceiptHash: common.HexToHash(block.ReceiptsRoot),
+		Number:      new(big.Int).SetUint64(block.Number),
+		Time:        block.Timestamp,
+		MixDigest:   common.HexToHash(block.MixHash),
+	}
+	bloom, err := hexutil.Decode(block.LogsBloom)
+	if err != nil {
+		return nil, fmt.Errorf("logsBloom: %w", err)
+	}
+	header.Bloom = types.BytesToBloom(bloom)
+	if header.Difficulty, err = hexutil.DecodeBig(block.Difficulty); err != nil {
+		return nil, fmt.Errorf("difficulty: %w", err)
+	}
+	if header.GasLimit, err = hexutil.DecodeUint64(block.GasLimit); err != nil {
+		return nil, fmt.Errorf("gasLimit: %w", err)
+	}
+	if header.GasUsed, err = hexutil.DecodeUint64(block.GasUsed); err != nil {
+		return nil, fmt.Errorf("gasUsed: %w", err)
+	}
+	if header.Extra, err = hexutil.Decode(block.ExtraData); err != nil {
+		return nil, fmt.Errorf("extraData: %w", err)
+	}
+	nonce, err := hexutil.Decode(block.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("nonce: %w", err)
+	}
+	copy(header.Nonce[len(header.Nonce)-len(nonce):], nonce)
+	if block.BaseFeePerGas != nil {
+		if header.BaseFee, err = hexutil.DecodeBig(*block.BaseFeePerGas); err != nil {
+			return nil, fmt.Errorf("baseFeePerGas: %w", err)
+		}
+	}
+	if block.WithdrawalsRoot != nil {
+		hash := common.HexToHash(*block.WithdrawalsRoot)
+		header.WithdrawalsHash = &hash
+	}
+	if block.BlobGasUsed != nil {
+		v, err := hexutil.DecodeUint64(*block.BlobGasUsed)
+		if err != nil {
+			return nil, fmt.Errorf("blobGasUsed: %w", err)
+		}
+		header.BlobGasUsed = &v
+	}
+	if block.ExcessBlobGas != nil {
+		v, err := hexutil.DecodeUint64(*block.ExcessBlobGas)
+		if err != nil {
+			return nil, fmt.Errorf("excessBlobGas: %w", err)
+		}
+		header.ExcessBlobGas = &v
+	}
+	if block.ParentBeaconBlockRoot != nil {
+		hash := common.HexToHash(*block.ParentBeaconBlockRoot)
+		header.ParentBeaconRoot = &hash
+	}
+	if block.RequestsHash != nil {
+		hash := common.HexToHash(*block.RequestsHash)
+		header.RequestsHash = &hash
+	}
+	return header, nil
+}
+
+// rebuildTransaction re-creates the signed transaction from its JSON-RPC
+// fields so that it can be re-encoded.
+func rebuildTransaction(tx *evmctypes.Transaction) (*types.Transaction, error) {
+	fields := map[string]any{
+		"type":                 tx.Type,
+		"nonce":                tx.Nonce,
+		"gas":                  tx.Gas,
+		"gasPrice":             tx.GasPrice,
+		"maxFeePerGas":         tx.MaxFeePerGas,
+		"maxPriorityFeePerGas": tx.MaxPriorityFeePerGas,
+		"maxFeePerBlobGas":     tx.MaxFeePerBlobGas,
+		"value":                (*hexutil.Big)(tx.Value.BigInt()),
+		"input":                tx.Input,
+		"v":                    tx.V,
+		"r":                    tx.R,
+		"s":                    tx.S,
+		"yParity":              tx.YParity,
+		"chainId":              tx.ChainID,
+		"accessList":           tx.AccessList,
+		"blobVersionedHashes":  tx.BlobVersionedHashes,
+		"authorizationList":    tx.AuthorizationList,
+	}
+	if tx.To != "" {
+		fields["to"] = tx.To
+	}
+	if tx.AccessList == nil {
+		fields["accessList"] = []any{}
+	}
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	rebuilt := new(types.Transaction)
+	if err := rebuilt.UnmarshalJSON(b); err != nil {
+		return nil, err
+	}
+	return rebuilt, nil
+}
+
+// rebuildReceipt re-creates the consensus part of a receipt.
+func rebuildReceipt(r *evmctypes.Receipt) (*types.Receipt, error) {
+	txType, err := hexutil.DecodeUint64(r.Type)
+	if err != nil {
+		return nil, fmt.Errorf("type: %w", err)
+	}
+	receipt := &types.Receipt{Type: uint8(txType), Logs: make([]*types.Log, len(r.Logs))}
+	if receipt.CumulativeGasUsed, err = hexutil.DecodeUint64(r.CumulativeGasUsed); err != nil {
+		return nil, fmt.Errorf("cumulativeGasUsed: %w", err)
+	}
+	switch {
+	case r.Root != nil:
+		if receipt.PostState, err = hexutil.Decode(*r.Root); err != nil {
+			return nil, fmt.Errorf("root: %w", err)
+		}
+	case r.Status != nil:
+		if receipt.Status, err = hexutil.DecodeUint64(*r.Status); err != nil {
+			return nil, fmt.Errorf("status: %w", err)
+		}
+	}
+	for i, l := range r.Logs {
+		data, err := hexutil.Decode(l.Data)
+		if err != nil {
+			return nil, fmt.Errorf("log %d: data: %w", i, err)
+		}
+		topics := make([]common.Hash, len(l.Topics))
+		for j, topic := range l.Topics {
+			topics[j] = common.HexToHash(topic)
+		}
+		receipt.Logs[i] = &types.Log{Address: common.HexToAddress(l.Address), Topics: topics, Data: data}
+	}
+	return receipt, nil
+}
@@ -0,0 +1,92 @@
+package evmc
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"testing"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// verifierFixture는 rawBlockFixture를 JSON-RPC 형태의 블록/영수증으로 변환한다.
+func verifierFixture(t *testing.T) (*evmctypes.BlockIncTx, []*evmctypes.Receipt) {
+	t.Helper()
+	block, receipts, from := rawBlockFixture(t)
+	decoded := &DecodedBlock{Block: block, Senders: []common.Address{from, from}}
+	rpcBlock, err := decoded.ToBlockIncTx()
+	require.NoError(t, err)
+	rpcReceipts, err := decoded.ToReceipts(receipts)
+	require.NoError(t, err)
+	return rpcBlock, rpcReceipts
+}
+
+func Test_VerifyBlock(t *testing.T) {
+	block, receipts := verifierFixture(t)
+	require.NoError(t, VerifyBlock(block, receipts))
+	require.NoError(t, VerifyBlock(block, nil))
+
+	// 헤더 필드, 트랜잭션, 로그를 각각 변조한다.
+	block.GasUsed = "0x1"
+	block.Transactions[0].Nonce = "0x5"
+	receipts[1].Logs[0].Data = "0x03"
+
+	err := VerifyBlock(block, receipts)
+	require.ErrorIs(t, err, ErrBlockIntegrity)
+	var integrityErr *BlockIntegrityError
+	require.True(t, errors.As(err, &integrityErr))
+	assert.Equal(t, uint64(100), integrityErr.Number)
+
+	fields := make([]string, len(integrityErr.Mismatches))
+	for i, m := range integrityErr.Mismatches {
+		fields[i] = m.Field
+	}
+	assert.ElementsMatch(t, []string{"hash", "transactions[0].hash", "transactionsRoot", "receiptsRoot"}, fields)
+}
+
+func Test_ethNamespace_mock_GetBlockIncTxRange_integrityCheck(t *testing.T) {
+	block, receipts, from := rawBlockFixture(t)
+	decoded := &DecodedBlock{Block: block, Senders: []common.Address{from, from}}
+	header, err := headerFields(block.Header())
+	require.NoError(t, err)
+	txs := make([]map[string]any, len(block.Transactions()))
+	for i, tx := range block.Transactions() {
+		txs[i], err = transactionFields(tx, from, block.Header(), uint64(i))
+		require.NoError(t, err)
+	}
+	header["transactions"] = txs
+	// 노드가 트랜잭션 input을 잘못 내려준 상황
+	txs[1]["input"] = "0x6001"
+
+	mock := newMockRPCServer(t)
+	mock.on("eth_getBlockByNumber", func(params json.RawMessage) any {
+		var args []any
+		require.NoError(t, json.Unmarshal(params, &args))
+		if args[1] == true {
+			return header
+		}
+		hashes := make([]string, len(txs))
+		for i, tx := range txs {
+			hashes[i] = tx["hash"].(string)
+		}
+		withHashes := maps.Clone(header)
+		withHashes["transactions"] = hashes
+		return withHashes
+	})
+	mock.on("eth_getBlockReceipts", func(params json.RawMessage) any { return decoded.receiptFields(receipts) })
+
+	// 기본값은 검증하지 않는다.
+	blocks, err := testEvmc(mock.url()).Eth().GetBlockIncTxRange(100, 100)
+	require.NoError(t, err)
+	assert.Len(t, blocks, 1)
+
+	client, err := New(mock.url(), WithBlockIntegrityCheck())
+	require.NoError(t, err)
+	_, err = client.Eth().GetBlockIncTxRange(100, 100)
+	var integrityErr *BlockIntegrityError
+	require.ErrorAs(t, err, &integrityErr)
+	assert.Equal(t, "transactions[1].hash", integrityErr.Mismatches[0].Field)
+}
@@ -0,0 +1,257 @@
+package evmc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// batchSizeCandidates are the batch sizes tried, largest first, when probing
+// the batch limit of a node. Sizes above the configured maxBatchItems are
+// skipped, as batches are never sent larger than that.
+var batchSizeCandidates = []int{1000, 500, 200, 100, 50, 20, 10, 1}
+
+// Capabilities describes what the connected node supports. Probe it with
+// [Evmc.ProbeCapabilities]; once cached, methods use it to pick the best RPC
+// or to fail early with [ErrMethodNotSupported].
+type Capabilities struct {
+	Client        ClientName
+	ClientVersion string
+
+	// Namespaces holds the optional namespaces that respond: debug, trace,
+	// ots and txpool.
+	Namespaces map[string]bool
+
+	BlockReceipts    bool // eth_getBlockReceipts
+	BorBlockReceipts bool // eth_getTransactionReceiptsByBlock
+	SimulateV1       bool // eth_simulateV1
+	FlatCallTracer   bool // debug tracer "flatCallTracer"
+
+	// LogBlockTimestamp reports whether logs carry blockTimestamp. It is
+	// only detected when the latest block has logs.
+	LogBlockTimestamp bool
+
+	// MaxBatchItems is the largest probed batch the node accepted.
+	MaxBatchItems int
+}
+
+// HasNamespace reports whether the namespace ns responds.
+func (c *Capabilities) HasNamespace(ns string) bool {
+	return c.Namespaces[ns]
+}
+
+// ParseClientName returns the client of a web3_clientVersion string such as
+// "Geth/v1.15.2-stable/linux-amd64/go1.24".
+func ParseClientName(clientVersion string) ClientName {
+	name, _, _ := splitClientVersion(clientVersion)
+	name = strings.ToLower(name)
+	switch {
+	case strings.HasPrefix(name, "geth"):
+		return Geth
+	case strings.HasPrefix(name, "erigon"):
+		return Erigon
+	case strings.HasPrefix(name, "reth"):
+		return Reth
+	case strings.HasPrefix(name, "nethermind"):
+		return Nethermind
+	case strings.HasPrefix(name, "besu"):
+		return Besu
+	case strings.HasPrefix(name, "nitro"):
+		return Nitro
+	case strings.HasPrefix(name, "bor"):
+		return Bor
+	case strings.HasPrefix(name, "kaia"), strings.HasPrefix(name, "klaytn"):
+		return Kaia
+	}
+	return ClientName(name)
+}
+
+// splitClientVersion splits a web3_clientVersion string into the client name
+// and version, its first two "/"-separated fields. ok is false if there is
+// no version field.
+func splitClientVersion(clientVersion string) (name, version string, ok bool) {
+	name, rest, ok := strings.Cut(clientVersion, "/")
+	version, _, _ = strings.Cut(rest, "/")
+	return name, version, ok
+}
+
+// Capabilities returns the cached capabilities of the node, probing them on
+// first use.
+func (e *Evmc) Capabilities() (*Capabilities, error) {
+	return e.CapabilitiesWithContext(context.Background())
+}
+
+// CapabilitiesWithContext is the context-aware variant of
+// [Evmc.Capabilities].
+func (e *Evmc) CapabilitiesWithContext(ctx context.Context) (*Capabilities, error) {
+	if caps := e.cachedCapabilities(); caps != nil {
+		return caps, nil
+	}
+	return e.ProbeCapabilitiesWithContext(ctx)
+}
+
+// ProbeCapabilities probes the node and caches the result, replacing any
+// earlier probe.
+func (e *Evmc) ProbeCapabilities() (*Capabilities, error) {
+	return e.ProbeCapabilitiesWithContext(context.Background())
+}
+
+// ProbeCapabilitiesWithContext is the context-aware variant of
+// [Evmc.ProbeCapabilities]. The batch limit is probed first so that the
+// remaining probes are sent in batches the node accepts.
+func (e *Evmc) ProbeCapabilitiesWithContext(ctx context.Context) (*Capabilities, error) {
+	var (
+		caps            = &Capabilities{Namespaces: make(map[string]bool)}
+		clientVersion   string
+		modules         map[string]string
+		raw             = make([]json.RawMessage, 9)
+		latestLogFilter = map[string]any{"fromBlock": evmctypes.Latest.String(), "toBlock": evmctypes.Latest.String()}
+		flatCallConfig  = map[string]any{"tracer": FlatCallTracer}
+	)
+	elements := []rpc.BatchElem{
+		{Method: Web3ClientVersion.String(), Result: &clientVersion},
+		{Method: RPCModules.String(), Result: &modules},
+		{Method: EthGetBlockReceipts.String(), Args: []any{"0x0"}, Result: &raw[0]},
+		{Method: EthGetTransactionReceiptsByBlock.String(), Args: []any{"0x0"}, Result: &raw[1]},
+		{
+			Method: EthSimulateV1.String(),
+			Args:   []any{map[string]any{"blockStateCalls": []any{}}, evmctypes.Latest.String()},
+			Result: &raw[2],
+		},
+		{
+			Method: DebugTraceCall.String(),
+			Args:   []any{map[string]any{"to": ZeroAddress}, evmctypes.Latest.String(), flatCallConfig},
+			Result: &raw[3],
+		},
+		{Method: DebugGetRawHeader.String(), Args: []any{"0x0"}, Result: &raw[4]},
+		{Method: TraceBlock.String(), Args: []any{"0x0"}, Result: &raw[5]},
+		{Method: OtsGetAPILevel.String(), Result: &raw[6]},
+		{Method: TxpoolStatus.String(), Result: &raw[7]},
+		{Method: EthGetLogs.String(), Args: []any{latestLogFilter}, Result: &raw[8]},
+	}
+	caps.MaxBatchItems = e.probeBatchLimit(ctx)
+	if err := e.probeCall(ctx, elements, caps.MaxBatchItems); err != nil {
+		return nil, fmt.Errorf("ProbeCapabilities: %w", err)
+	}
+	if err := elements[0].Error; err != nil {
+		return nil, fmt.Errorf("ProbeCapabilities: %w", err)
+	}
+	caps.ClientVersion = clientVersion
+	caps.Client = ParseClientName(clientVersion)
+
+	caps.BlockReceipts = methodExists(elements[2].Error)
+	caps.BorBlockReceipts = methodExists(elements[3].Error)
+	caps.SimulateV1 = methodExists(elements[4].Error)
+	// An unknown tracer is reported as a plain error.
+	caps.FlatCallTracer = elements[5].Error == nil
+
+	for i, ns := range []string{"debug", "trace", "ots", "txpool"} {
+		_, listed := modules[ns]
+		caps.Namespaces[ns] = listed || methodExists(elements[6+i].Error)
+	}
+
+	if elements[10].Error == nil {
+		var logs []map[string]json.RawMessage
+		if err := json.Unmarshal(raw[8], &logs); err == nil && len(logs) > 0 {
+			_, caps.LogBlockTimestamp = logs[0]["blockTimestamp"]
+		}
+	}
+
+	e.caps.Store(caps)
+	return caps, nil
+}
+
+// probeCall sends elements in batches of at most limit items, or one request
+// at a time if the node accepts no batches.
+func (e *Evmc) probeCall(ctx context.Context, elements []rpc.BatchElem, limit int) error {
+	if limit < 1 {
+		for i := range elements {
+			el := &elements[i]
+			el.Error = e.c.CallContext(ctx, el.Result, el.Method, el.Args...)
+		}
+		return ctx.Err()
+	}
+	for i := 0; i < len(elements); i += limit {
+		if err := e.batchCall(ctx, elements[i:min(i+limit, len(elements))]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// probeBatchLimit returns the largest candidate batch size, starting from
+// maxBatchItems, that the node answers in full, or 0 if none is accepted.
+func (e *Evmc) probeBatchLimit(ctx context.Context) int {
+	candidates := batchSizeCandidates
+	if e.maxBatchItems > 0 {
+		candidates = []int{e.maxBatchItems}
+		for _, size := range batchSizeCandidates {
+			if size < e.maxBatchItems {
+				candidates = append(candidates, size)
+			}
+		}
+	}
+	for _, size := range candidates {
+		elements := make([]rpc.BatchElem, size)
+		for i := range elements {
+			elements[i] = rpc.BatchElem{Method: EthChainID.String(), Result: new(string)}
+		}
+		if err := e.batchCall(ctx, elements); err != nil {
+			if ctx.Err() != nil {
+				return 0
+			}
+			continue
+		}
+		accepted := true
+		for _, el := range elements {
+			if el.Error != nil {
+				accepted = false
+				break
+			}
+		}
+		if accepted {
+			return size
+		}
+	}
+	return 0
+}
+
+func (e *Evmc) cachedCapabilities() *Capabilities {
+	return e.caps.Load()
+}
+
+// requireCapability returns an [ErrMethodNotSupported] error for method if
+// capabilities were probed and supported reports false for them.
+func requireCapability(cache capabilityCache, method string, supported func(*Capabilities) bool) error {
+	caps := cache.cachedCapabilities()
+	if caps == nil || supported(caps) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s on %s", ErrMethodNotSupported, method, caps.ClientVersion)
+}
+
+// methodExists reports whether err, the result of calling a method with
+// possibly invalid arguments, means the method is available.
+func methodExists(err error) bool {
+	if err == nil {
+		return true
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32601 {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	if strings.Contains(msg, "method") {
+		for _, s := range []string{"not found", "does not exist", "not supported", "not available", "unsupported"} {
+			if strings.Contains(msg, s) {
+				return false
+			}
+		}
+	}
+	return true
+}
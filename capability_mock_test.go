@@ -0,0 +1,135 @@
+package evmc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseClientName(t *testing.T) {
+	for version, want := range map[string]ClientName{
+		"Geth/v1.15.2-stable/linux-amd64/go1.24": Geth,
+		"erigon/3.0.0/linux-amd64/go1.23":        Erigon,
+		"reth/v1.3.0-1234567/x86_64-unknown":     Reth,
+		"Nethermind/v1.31.0+1234/linux-x64":      Nethermind,
+		"besu/v25.1.0/linux-x86_64/openjdk":      Besu,
+		"nitro/v3.5.0-1234567/linux-amd64":       Nitro,
+		"bor/v2.0.1/linux-amd64/go1.23":          Bor,
+		"Klaytn/v1.12.0/linux-amd64/go1.22":      Kaia,
+		"Kaia/v2.0.0/linux-amd64/go1.23":         Kaia,
+		"Unknown/v0.1":                           ClientName("unknown"),
+	} {
+		assert.Equal(t, want, ParseClientName(version), version)
+	}
+}
+
+// newBorMock은 eth_getBlockReceipts 대신 Bor 전용 receipt 메서드를 제공하는 노드를 흉내낸다.
+func newBorMock(t *testing.T) *mockRPCServer {
+	t.Helper()
+	mock := newMockRPCServer(t)
+	mock.batchLimit = 20
+	mock.on("web3_clientVersion", func(json.RawMessage) any { return "bor/v2.0.1/linux-amd64/go1.23" })
+	mock.on("rpc_modules", func(json.RawMessage) any {
+		return map[string]string{"eth": "1.0", "debug": "1.0", "txpool": "1.0"}
+	})
+	mock.on("eth_chainId", func(json.RawMessage) any { return "0x89" })
+	mock.on("eth_getBlockByNumber", func(params json.RawMessage) any {
+		var args []any
+		require.NoError(t, json.Unmarshal(params, &args))
+		block := blockJSON(args[0].(string), "0xblockhash", true)
+		block["transactions"] = []string{"0xtx1"}
+		return block
+	})
+	mock.on("eth_getTransactionReceiptsByBlock", func(params json.RawMessage) any {
+		var args []string
+		require.NoError(t, json.Unmarshal(params, &args))
+		// Bor는 블록에 없는 state-sync receipt를 마지막에 덧붙인다.
+		stateSync := receiptJSON("0xstatesync", args[0], "0xblockhash")
+		stateSync["transactionIndex"] = "0x1"
+		return []map[string]any{stateSync, receiptJSON("0xtx1", args[0], "0xblockhash")}
+	})
+	mock.on("debug_traceCall", func(json.RawMessage) any {
+		return mockRPCError{code: -32000, message: "tracer not found"}
+	})
+	mock.on("debug_getRawHeader", func(json.RawMessage) any { return "0xf9" })
+	mock.on("eth_getLogs", func(json.RawMessage) any {
+		return []map[string]any{{"address": ZeroAddress, "blockTimestamp": "0x1"}}
+	})
+	return mock
+}
+
+func Test_Evmc_mock_ProbeCapabilities(t *testing.T) {
+	client := testEvmc(newBorMock(t).url())
+
+	caps, err := client.ProbeCapabilities()
+	require.NoError(t, err)
+	assert.Equal(t, Bor, caps.Client)
+	assert.False(t, caps.BlockReceipts)
+	assert.True(t, caps.BorBlockReceipts)
+	assert.False(t, caps.SimulateV1)
+	assert.False(t, caps.FlatCallTracer)
+	assert.True(t, caps.LogBlockTimestamp)
+	assert.Equal(t, 20, caps.MaxBatchItems)
+	assert.True(t, caps.HasNamespace("debug"))
+	assert.True(t, caps.HasNamespace("txpool"))
+	assert.False(t, caps.HasNamespace("trace"))
+	assert.False(t, caps.HasNamespace("ots"))
+
+	cached, err := client.Capabilities()
+	require.NoError(t, err)
+	assert.Same(t, caps, cached)
+}
+
+func Test_Evmc_mock_ProbeCapabilities_batchLimit(t *testing.T) {
+	// probe 대상 메서드 수보다 batch 제한이 작아도 나눠서 probe한다.
+	mock := newBorMock(t)
+	mock.batchLimit = 5
+	caps, err := testEvmc(mock.url()).ProbeCapabilities()
+	require.NoError(t, err)
+	assert.Equal(t, Bor, caps.Client)
+	assert.True(t, caps.BorBlockReceipts)
+	assert.True(t, caps.LogBlockTimestamp)
+	assert.Equal(t, 1, caps.MaxBatchItems)
+
+	// 설정된 maxBatchItems보다 큰 크기는 시도하지 않는다.
+	client, err := New(newBorMock(t).url(), WithMaxBatchItems(15))
+	require.NoError(t, err)
+	caps, err = client.ProbeCapabilities()
+	require.NoError(t, err)
+	assert.Equal(t, 15, caps.MaxBatchItems)
+}
+
+func Test_Evmc_mock_capabilityNegotiation(t *testing.T) {
+	client := testEvmc(newBorMock(t).url())
+	chainIDBatch := func() []rpc.BatchElem {
+		elements := make([]rpc.BatchElem, 50)
+		for i := range elements {
+			elements[i] = rpc.BatchElem{Method: EthChainID.String(), Result: new(string)}
+		}
+		return elements
+	}
+
+	// probe 전에는 설정된 batch 크기를 그대로 사용한다.
+	assert.Error(t, client.BatchCall(chainIDBatch(), 1))
+
+	_, err := client.ProbeCapabilities()
+	require.NoError(t, err)
+
+	// eth_getBlockReceipts를 시도하지 않고 Bor 전용 메서드를 사용한다.
+	receipts, err := client.Eth().GetBlockReceipts(1)
+	require.NoError(t, err)
+	require.Len(t, receipts, 1)
+	assert.Equal(t, "0xtx1", receipts[0].TransactionHash)
+
+	_, err = client.Eth().SimulateV1(&evmctypes.SimulatePayload{}, evmctypes.Latest)
+	assert.ErrorIs(t, err, ErrMethodNotSupported)
+	_, err = client.Debug().TraceTransactionFlatCallTracer("0x01", 0, nil, nil)
+	assert.ErrorIs(t, err, ErrMethodNotSupported)
+
+	// batch는 probe한 크기로 나뉘어 전송된다.
+	assert.NoError(t, client.BatchCall(chainIDBatch(), 1))
+}
@@ -76,6 +76,9 @@ func (id ChainID) Name() string {
 	case ChilizSpicy:
 		return "chiliz-spicy"
 	default:
+		if info, ok := LookupChain(id); ok {
+			return info.Name
+		}
 		return "unknown"
 	}
 }
@@ -0,0 +1,247 @@
+package evmc
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Multicall3Address is the address Multicall3 is deployed at on most chains.
+const Multicall3Address = "0xcA11bde05977b3631167028862bE2a4B2F2B7c37"
+
+// FinalityModel describes when a block of a chain can no longer be reorged.
+type FinalityModel string
+
+const (
+	// FinalityProbabilistic chains become safer with every confirmation.
+	FinalityProbabilistic FinalityModel = "probabilistic"
+	// FinalityCheckpoint chains finalize blocks in checkpoints, exposed by
+	// the safe and finalized block tags.
+	FinalityCheckpoint FinalityModel = "checkpoint"
+	// FinalityInstant chains finalize every block on inclusion (BFT).
+	FinalityInstant FinalityModel = "instant"
+	// FinalityL1 rollups are final once their batch is finalized on L1.
+	FinalityL1 FinalityModel = "l1"
+)
+
+// L2Type is the stack a layer 2 or sidechain is built on.
+type L2Type string
+
+const (
+	L2None       L2Type = ""
+	L2OpStack    L2Type = "op-stack"
+	L2Arbitrum   L2Type = "arbitrum"
+	L2PolygonPoS L2Type = "polygon-pos"
+)
+
+// NativeCurrency is the currency gas is paid in.
+type NativeCurrency struct {
+	Symbol   string
+	Decimals uint8
+}
+
+// ChainInfo holds the properties and client defaults of a chain. Register
+// additional chains with [RegisterChain] and look them up with [LookupChain]
+// or [Evmc.Chain].
+type ChainInfo struct {
+	ID             ChainID
+	Name           string
+	NativeCurrency NativeCurrency
+	BlockTime      time.Duration
+	Finality       FinalityModel
+	EIP1559        bool
+	EIP4844        bool
+	Multicall3     string // empty if not deployed
+	Confirmations  uint64 // default confirmations before a block is treated as final
+	ExplorerURL    string // explorer root, e.g. https://etherscan.io
+	L2             L2Type
+}
+
+// TxURL returns the explorer page of a transaction, or "" without explorer.
+func (c *ChainInfo) TxURL(hash string) string {
+	return c.explorerURL("tx", hash)
+}
+
+// AddressURL returns the explorer page of an address, or "" without
+// explorer.
+func (c *ChainInfo) AddressURL(address string) string {
+	return c.explorerURL("address", address)
+}
+
+// BlockURL returns the explorer page of a block, or "" without explorer.
+func (c *ChainInfo) BlockURL(number uint64) string {
+	return c.explorerURL("block", fmt.Sprint(number))
+}
+
+func (c *ChainInfo) explorerURL(kind, value string) string {
+	if c.ExplorerURL == "" {
+		return ""
+	}
+	return strings.TrimSuffix(c.ExplorerURL, "/") + "/" + kind + "/" + value
+}
+
+var chainRegistry = struct {
+	sync.RWMutex
+	chains map[ChainID]ChainInfo
+}{chains: make(map[ChainID]ChainInfo)}
+
+// RegisterChain adds info to the chain registry, replacing any chain with the
+// same ID.
+func RegisterChain(info ChainInfo) error {
+	if info.ID == 0 {
+		return ErrChainIDLessThanZero
+	}
+	if info.Name == "" {
+		return fmt.Errorf("chain %d: name is required", info.ID)
+	}
+	chainRegistry.Lock()
+	defer chainRegistry.Unlock()
+	chainRegistry.chains[info.ID] = info
+	return nil
+}
+
+// LookupChain returns the registered chain with id.
+func LookupChain(id ChainID) (*ChainInfo, bool) {
+	chainRegistry.RLock()
+	defer chainRegistry.RUnlock()
+	info, ok := chainRegistry.chains[id]
+	if !ok {
+		return nil, false
+	}
+	return &info, true
+}
+
+// RegisteredChains returns all registered chains ordered by ID.
+func RegisteredChains() []*ChainInfo {
+	chainRegistry.RLock()
+	defer chainRegistry.RUnlock()
+	chains := make([]*ChainInfo, 0, len(chainRegistry.chains))
+	for _, info := range chainRegistry.chains {
+		chains = append(chains, &info)
+	}
+	slices.SortFunc(chains, func(a, b *ChainInfo) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return chains
+}
+
+// Chain returns the registry entry of the connected chain. The chain ID is
+// fetched once and cached.
+func (e *Evmc) Chain() (*ChainInfo, error) {
+	return e.ChainWithContext(context.Background())
+}
+
+// ChainWithContext is the context-aware variant of [Evmc.Chain].
+func (e *Evmc) ChainWithContext(ctx context.Context) (*ChainInfo, error) {
+	id, err := e.cachedChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	info, ok := LookupChain(ChainID(id))
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownChain, id)
+	}
+	return info, nil
+}
+
+func (e *Evmc) cachedChainID(ctx context.Context) (uint64, error) {
+	if id := e.chainID.Load(); id != 0 {
+		return id, nil
+	}
+	id, err := e.eth.ChainIDWithContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	e.chainID.Store(id)
+	return id, nil
+}
+
+func init() {
+	var (
+		eth = NativeCurrency{Symbol: "ETH", Decimals: 18}
+		l1  = ChainInfo{
+			NativeCurrency: eth,
+			BlockTime:      12 * time.Second,
+			Finality:       FinalityCheckpoint,
+			EIP1559:        true,
+			EIP4844:        true,
+			Multicall3:     Multicall3Address,
+			Confirmations:  12,
+		}
+		opStack = ChainInfo{
+			NativeCurrency: eth,
+			BlockTime:      2 * time.Second,
+			Finality:       FinalityL1,
+			EIP1559:        true,
+			Multicall3:     Multicall3Address,
+			Confirmations:  1,
+			L2:             L2OpStack,
+		}
+		arbitrum = ChainInfo{
+			NativeCurrency: eth,
+			BlockTime:      250 * time.Millisecond,
+			Finality:       FinalityL1,
+			EIP1559:        true,
+			Multicall3:     Multicall3Address,
+			Confirmations:  1,
+			L2:             L2Arbitrum,
+		}
+		polygon = ChainInfo{
+			NativeCurrency: NativeCurrency{Symbol: "POL", Decimals: 18},
+			BlockTime:      2 * time.Second,
+			Finality:       FinalityCheckpoint,
+			EIP1559:        true,
+			Multicall3:     Multicall3Address,
+			Confirmations:  64,
+			L2:             L2PolygonPoS,
+		}
+		kaia = ChainInfo{
+			NativeCurrency: NativeCurrency{Symbol: "KAIA", Decimals: 18},
+			BlockTime:      time.Second,
+			Finality:       FinalityInstant,
+			EIP1559:        true,
+			Multicall3:     Multicall3Address,
+		}
+		chiliz = ChainInfo{
+			NativeCurrency: NativeCurrency{Symbol: "CHZ", Decimals: 18},
+			BlockTime:      3 * time.Second,
+			Finality:       FinalityProbabilistic,
+			EIP1559:        true,
+			Confirmations:  15,
+		}
+	)
+	with := func(base ChainInfo, id ChainID, explorer string) ChainInfo {
+		base.ID = id
+		base.Name = id.Name()
+		base.ExplorerURL = explorer
+		return base
+	}
+	hoodi := with(l1, EthereumHoodi, "https://hoodi.etherscan.io")
+	hoodi.Multicall3 = ""
+	for _, info := range []ChainInfo{
+		with(l1, EthereumMainnet, "https://etherscan.io"),
+		with(l1, EthereumSepolia, "https://sepolia.etherscan.io"),
+		with(l1, EthereumHolesky, "https://holesky.etherscan.io"),
+		hoodi,
+		with(polygon, PolygonMainnet, "https://polygonscan.com"),
+		with(polygon, PolygonAmoy, "https://amoy.polygonscan.com"),
+		with(arbitrum, ArbitrumMainnet, "https://arbiscan.io"),
+		with(arbitrum, ArbitrumSepolia, "https://sepolia.arbiscan.io"),
+		with(opStack, OptimismMainnet, "https://optimistic.etherscan.io"),
+		with(opStack, OptimismSepolia, "https://sepolia-optimism.etherscan.io"),
+		with(opStack, BaseMainnet, "https://basescan.org"),
+		with(opStack, BaseSepolia, "https://sepolia.basescan.org"),
+		with(kaia, KaiaMainnet, "https://kaiascan.io"),
+		with(kaia, KaiaKairos, "https://kairos.kaiascan.io"),
+		with(chiliz, ChilizMainnet, "https://chiliscan.com"),
+		with(chiliz, ChilizSpicy, "https://testnet.chiliscan.com"),
+	} {
+		if err := RegisterChain(info); err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package evmc
+
+import (
+	"encoding/json"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// restoreChainRegistry는 테스트가 끝나면 chain registry를 테스트 이전 상태로 되돌린다.
+func restoreChainRegistry(t *testing.T) {
+	t.Helper()
+	chainRegistry.RLock()
+	saved := maps.Clone(chainRegistry.chains)
+	chainRegistry.RUnlock()
+	t.Cleanup(func() {
+		chainRegistry.Lock()
+		defer chainRegistry.Unlock()
+		chainRegistry.chains = saved
+	})
+}
+
+func Test_ChainRegistry(t *testing.T) {
+	restoreChainRegistry(t)
+
+	// 기본 등록된 체인
+	info, ok := LookupChain(BaseMainnet)
+	require.True(t, ok)
+	assert.Equal(t, "base-mainnet", info.Name)
+	assert.Equal(t, L2OpStack, info.L2)
+	assert.Equal(t, FinalityL1, info.Finality)
+	assert.Equal(t, "ETH", info.NativeCurrency.Symbol)
+	assert.Equal(t, "https://basescan.org/tx/0xabc", info.TxURL("0xabc"))
+
+	info, ok = LookupChain(KaiaMainnet)
+	require.True(t, ok)
+	assert.Equal(t, FinalityInstant, info.Finality)
+	assert.Equal(t, uint64(0), info.Confirmations)
+
+	// 조회 결과를 수정해도 registry에는 영향이 없다.
+	info.Name = "changed"
+	info, _ = LookupChain(KaiaMainnet)
+	assert.Equal(t, "kaia-mainnet", info.Name)
+
+	for _, id := range append(append([]ChainID{}, EthereumIDs...), OpStackIDs...) {
+		_, ok := LookupChain(id)
+		assert.True(t, ok, id.Name())
+	}
+
+	// 런타임 등록
+	custom := ChainID(7777777)
+	assert.Equal(t, "unknown", custom.Name())
+	assert.Error(t, RegisterChain(ChainInfo{ID: custom}))
+	require.NoError(t, RegisterChain(ChainInfo{
+		ID:             custom,
+		Name:           "zora-mainnet",
+		NativeCurrency: NativeCurrency{Symbol: "ETH", Decimals: 18},
+		BlockTime:      2 * time.Second,
+		L2:             L2OpStack,
+	}))
+	assert.Equal(t, "zora-mainnet", custom.Name())
+	info, ok = LookupChain(custom)
+	require.True(t, ok)
+	assert.Empty(t, info.BlockURL(1))
+
+	chains := RegisteredChains()
+	for i := 1; i < len(chains); i++ {
+		assert.Less(t, chains[i-1].ID, chains[i].ID)
+	}
+}
+
+func Test_Evmc_mock_Chain(t *testing.T) {
+	calls := 0
+	mock := newMockRPCServer(t)
+	mock.on("eth_chainId", func(params json.RawMessage) any {
+		calls++
+		return "0x89"
+	})
+	client := testEvmc(mock.url())
+
+	info, err := client.Chain()
+	require.NoError(t, err)
+	assert.Equal(t, PolygonMainnet, info.ID)
+	assert.Equal(t, "POL", info.NativeCurrency.Symbol)
+
+	// chain ID는 캐시된다.
+	_, err = client.Chain()
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	mock.on("eth_chainId", func(params json.RawMessage) any { return "0x1234567" })
+	_, err = testEvmc(mock.url()).Chain()
+	assert.ErrorIs(t, err, ErrUnknownChain)
+}
@@ -0,0 +1,69 @@
+package evmc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// Checkpoint persists the progress of a walk over a block range, such as a
+// range trace or a block iterator.
+type Checkpoint interface {
+	// Load returns the last completed block, or ok false when nothing was saved.
+	Load(ctx context.Context) (last uint64, ok bool, err error)
+	// Save records number as the last completed block.
+	Save(ctx context.Context, number uint64) error
+}
+
+type fileCheckpoint struct {
+	path string
+}
+
+// NewFileCheckpoint returns a [Checkpoint] that stores progress as JSON
+// in the file at path. Writes are atomic, so an interrupted run never leaves
+// a corrupted checkpoint.
+func NewFileCheckpoint(path string) Checkpoint {
+	return &fileCheckpoint{path: path}
+}
+
+type fileCheckpointData struct {
+	LastBlock uint64 `json:"lastBlock"`
+}
+
+func (f *fileCheckpoint) Load(_ context.Context) (uint64, bool, error) {
+	b, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	var data fileCheckpointData
+	if err := json.Unmarshal(b, &data); err != nil {
+		return 0, false, err
+	}
+	return data.LastBlock, true, nil
+}
+
+func (f *fileCheckpoint) Save(_ context.Context, number uint64) error {
+	b, err := json.Marshal(fileCheckpointData{LastBlock: number})
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
@@ -10,11 +10,14 @@ const (
 type ClientName string
 
 const (
-	Geth   ClientName = "geth"
-	Erigon ClientName = "erigon"
-	Bor    ClientName = "bor"
-	Besu   ClientName = "besu"
-	Nitro  ClientName = "nitro"
+	Geth       ClientName = "geth"
+	Erigon     ClientName = "erigon"
+	Reth       ClientName = "reth"
+	Nethermind ClientName = "nethermind"
+	Bor        ClientName = "bor"
+	Besu       ClientName = "besu"
+	Nitro      ClientName = "nitro"
+	Kaia       ClientName = "kaia"
 )
 
 func (c ClientName) String() string {
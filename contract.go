@@ -14,7 +14,7 @@ type contract struct {
 func (c *contract) Query(ctx context.Context, queryParams *evmctypes.QueryParams) (*evmctypes.QueryResp, error) {
 	var (
 		result = new(string)
-		params = []any{queryParams, evmctypes.ParseBlockAndTag(queryParams.NumOrTag)}
+		params = []any{queryParams, evmctypes.ToBlockAndTag(queryParams.NumOrTag)}
 	)
 	if err := c.c.call(ctx, result, EthCall, params...); err != nil {
 		return nil, err
@@ -38,7 +38,7 @@ func (c *contract) BatchQuery(
 	)
 	for i := range elements {
 		results[i] = &evmctypes.QueryResp{To: batchQueryParams[i].To, Data: batchQueryParams[i].Data}
-		numOrTag := evmctypes.ParseBlockAndTag(batchQueryParams[i].NumOrTag)
+		numOrTag := evmctypes.ToBlockAndTag(batchQueryParams[i].NumOrTag)
 		elements[i] = rpc.BatchElem{
 			Method: EthCall.String(),
 			Args: []any{
@@ -72,7 +72,7 @@ func (c *contract) BatchQueriesWithContext(
 	)
 	for i := range elements {
 		results[i] = &evmctypes.QueryResp{To: batchQueryParams[i].To, Data: batchQueryParams[i].Data}
-		numOrTag := batchQueryParams[i].NumOrTag.String()
+		numOrTag := batchQueryParams[i].NumOrTag
 		elements[i] = rpc.BatchElem{
 			Method: EthCall.String(),
 			Args: []any{
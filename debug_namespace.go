@@ -10,7 +10,8 @@ import (
 )
 
 type debugNamespace struct {
-	c caller
+	c    caller
+	caps capabilityCache
 }
 
 // Tracer identifies a named tracer built into go-ethereum.
@@ -63,9 +64,39 @@ type PrestateTracerConfig struct {
 	IncludeEmpty bool `json:"includeEmpty"`
 }
 
+// MuxTracerConfig configures the tracers run by the muxTracer. A nil field
+// runs that tracer with its defaults; the 4byteTracer takes no options.
+type MuxTracerConfig struct {
+	CallTracer     *CallTracerConfig
+	PrestateTracer *PrestateTracerConfig
+}
+
+// tracerConfig returns the muxTracer config, which maps each tracer name to
+// its own config.
+func (m *MuxTracerConfig) tracerConfig() map[Tracer]any {
+	cfg := map[Tracer]any{
+		CallTracer:     struct{}{},
+		PrestateTracer: struct{}{},
+		FourByteTracer: struct{}{},
+	}
+	if m == nil {
+		return cfg
+	}
+	if m.CallTracer != nil {
+		cfg[CallTracer] = m.CallTracer
+	}
+	if m.PrestateTracer != nil {
+		cfg[PrestateTracer] = m.PrestateTracer
+	}
+	return cfg
+}
+
 // TraceConfig is the generic trace configuration accepted by all debug_trace* methods.
 type TraceConfig struct {
 	*DefaultTraceConfig
+	// CallOverrides applies state and block overrides. Only debug_traceCall
+	// honours them.
+	*evmctypes.CallOverrides
 
 	Tracer Tracer `json:"tracer,omitempty"`
 	// Timeout is the maximum duration the tracer is allowed to run.
@@ -118,6 +149,11 @@ func newTracerConfig(tracer Tracer, timeout time.Duration, reexec *uint64, cfg a
 	return tc
 }
 
+// requireFlatCallTracer fails early if the probed node lacks flatCallTracer.
+func (d *debugNamespace) requireFlatCallTracer() error {
+	return requireCapability(d.caps, "flatCallTracer", func(c *Capabilities) bool { return c.FlatCallTracer })
+}
+
 // newCustomTracerConfig builds a [TraceConfig] for a custom JavaScript tracer.
 func newCustomTracerConfig(jsTracer string, timeout time.Duration, reexec *uint64) *TraceConfig {
 	return &TraceConfig{
@@ -127,6 +163,12 @@ func newCustomTracerConfig(jsTracer string, timeout time.Duration, reexec *uint6
 	}
 }
 
+// newStructLoggerConfig builds a [TraceConfig] for the default struct-logger
+// tracer, which is selected by leaving Tracer empty.
+func newStructLoggerConfig(timeout time.Duration, reexec *uint64, cfg *DefaultTraceConfig) *TraceConfig {
+	return &TraceConfig{DefaultTraceConfig: cfg, Timeout: timeout.String(), Reexec: reexec}
+}
+
 // ─── TraceBlockByNumber ──────────────────────────────────────────────────────
 
 // TraceBlockByNumber replays a block identified by number and returns raw trace
@@ -216,6 +258,9 @@ func (d *debugNamespace) traceBlockByNumberFlatCallTracer(
 	reexec *uint64,
 	cfg *FlatCallTracerConfig,
 ) ([]*evmctypes.FlatCallTracer, error) {
+	if err := d.requireFlatCallTracer(); err != nil {
+		return nil, err
+	}
 	flatCallTracers := []*evmctypes.FlatCallTracer{}
 	traceCfg := newTracerConfig(FlatCallTracer, timeout, reexec, cfg)
 	if err := d.traceBlockByNumber(ctx, blockNumber, traceCfg, &flatCallTracers); err != nil {
@@ -261,6 +306,89 @@ func (d *debugNamespace) traceBlockByNumberPrestateTracer(
 	return prestateTracers, nil
 }
 
+// TraceBlockByNumberFourByteTracer replays a block by number using the 4byteTracer.
+func (d *debugNamespace) TraceBlockByNumberFourByteTracer(
+	blockNumber uint64,
+	timeout time.Duration,
+	reexec *uint64,
+) ([]*evmctypes.FourByteTracer, error) {
+	return d.TraceBlockByNumberWithContextFourByteTracer(context.Background(), blockNumber, timeout, reexec)
+}
+
+// TraceBlockByNumberWithContextFourByteTracer is the context-aware variant of [debugNamespace.TraceBlockByNumberFourByteTracer].
+func (d *debugNamespace) TraceBlockByNumberWithContextFourByteTracer(
+	ctx context.Context,
+	blockNumber uint64,
+	timeout time.Duration,
+	reexec *uint64,
+) ([]*evmctypes.FourByteTracer, error) {
+	fourByteTracers := []*evmctypes.FourByteTracer{}
+	traceCfg := newTracerConfig(FourByteTracer, timeout, reexec, nil)
+	if err := d.traceBlockByNumber(ctx, blockNumber, traceCfg, &fourByteTracers); err != nil {
+		return nil, err
+	}
+	return fourByteTracers, nil
+}
+
+// TraceBlockByNumberMuxTracer replays a block by number once, running the
+// callTracer, prestateTracer and 4byteTracer together.
+func (d *debugNamespace) TraceBlockByNumberMuxTracer(
+	blockNumber uint64,
+	timeout time.Duration,
+	reexec *uint64,
+	cfg *MuxTracerConfig,
+) ([]*evmctypes.MuxTracer, error) {
+	return d.TraceBlockByNumberWithContextMuxTracer(context.Background(), blockNumber, timeout, reexec, cfg)
+}
+
+// TraceBlockByNumberWithContextMuxTracer is the context-aware variant of [debugNamespace.TraceBlockByNumberMuxTracer].
+func (d *debugNamespace) TraceBlockByNumberWithContextMuxTracer(
+	ctx context.Context,
+	blockNumber uint64,
+	timeout time.Duration,
+	reexec *uint64,
+	cfg *MuxTracerConfig,
+) ([]*evmctypes.MuxTracer, error) {
+	muxTracers := []*evmctypes.MuxTracer{}
+	traceCfg := newTracerConfig(MuxTracer, timeout, reexec, cfg.tracerConfig())
+	if err := d.traceBlockByNumber(ctx, blockNumber, traceCfg, &muxTracers); err != nil {
+		return nil, err
+	}
+	for _, muxTracer := range muxTracers {
+		if muxTracer.Result != nil {
+			assignIndexCalls(muxTracer.Result.CallTracer)
+		}
+	}
+	return muxTracers, nil
+}
+
+// TraceBlockByNumberStructLogger replays a block by number using the default
+// struct-logger tracer.
+func (d *debugNamespace) TraceBlockByNumberStructLogger(
+	blockNumber uint64,
+	timeout time.Duration,
+	reexec *uint64,
+	cfg *DefaultTraceConfig,
+) ([]*evmctypes.StructLogTracer, error) {
+	return d.TraceBlockByNumberWithContextStructLogger(context.Background(), blockNumber, timeout, reexec, cfg)
+}
+
+// TraceBlockByNumberWithContextStructLogger is the context-aware variant of [debugNamespace.TraceBlockByNumberStructLogger].
+func (d *debugNamespace) TraceBlockByNumberWithContextStructLogger(
+	ctx context.Context,
+	blockNumber uint64,
+	timeout time.Duration,
+	reexec *uint64,
+	cfg *DefaultTraceConfig,
+) ([]*evmctypes.StructLogTracer, error) {
+	structLogTracers := []*evmctypes.StructLogTracer{}
+	traceCfg := newStructLoggerConfig(timeout, reexec, cfg)
+	if err := d.traceBlockByNumber(ctx, blockNumber, traceCfg, &structLogTracers); err != nil {
+		return nil, err
+	}
+	return structLogTracers, nil
+}
+
 // TraceBlockByNumberCustomTracer replays a block by number using a custom JavaScript tracer.
 func (d *debugNamespace) TraceBlockByNumberCustomTracer(
 	blockNumber uint64,
@@ -389,6 +517,9 @@ func (d *debugNamespace) traceBlockByHashFlatCallTracer(
 	reexec *uint64,
 	cfg *FlatCallTracerConfig,
 ) ([]*evmctypes.FlatCallTracer, error) {
+	if err := d.requireFlatCallTracer(); err != nil {
+		return nil, err
+	}
 	flatCallTracers := []*evmctypes.FlatCallTracer{}
 	traceCfg := newTracerConfig(FlatCallTracer, timeout, reexec, cfg)
 	if err := d.traceBlockByHash(ctx, hash, traceCfg, &flatCallTracers); err != nil {
@@ -434,6 +565,89 @@ func (d *debugNamespace) traceBlockByHashPrestateTracer(
 	return prestateTracers, nil
 }
 
+// TraceBlockByHashFourByteTracer replays a block by hash using the 4byteTracer.
+func (d *debugNamespace) TraceBlockByHashFourByteTracer(
+	hash string,
+	timeout time.Duration,
+	reexec *uint64,
+) ([]*evmctypes.FourByteTracer, error) {
+	return d.TraceBlockByHashWithContextFourByteTracer(context.Background(), hash, timeout, reexec)
+}
+
+// TraceBlockByHashWithContextFourByteTracer is the context-aware variant of [debugNamespace.TraceBlockByHashFourByteTracer].
+func (d *debugNamespace) TraceBlockByHashWithContextFourByteTracer(
+	ctx context.Context,
+	hash string,
+	timeout time.Duration,
+	reexec *uint64,
+) ([]*evmctypes.FourByteTracer, error) {
+	fourByteTracers := []*evmctypes.FourByteTracer{}
+	traceCfg := newTracerConfig(FourByteTracer, timeout, reexec, nil)
+	if err := d.traceBlockByHash(ctx, hash, traceCfg, &fourByteTracers); err != nil {
+		return nil, err
+	}
+	return fourByteTracers, nil
+}
+
+// TraceBlockByHashMuxTracer replays a block by hash once, running the
+// callTracer, prestateTracer and 4byteTracer together.
+func (d *debugNamespace) TraceBlockByHashMuxTracer(
+	hash string,
+	timeout time.Duration,
+	reexec *uint64,
+	cfg *MuxTracerConfig,
+) ([]*evmctypes.MuxTracer, error) {
+	return d.TraceBlockByHashWithContextMuxTracer(context.Background(), hash, timeout, reexec, cfg)
+}
+
+// TraceBlockByHashWithContextMuxTracer is the context-aware variant of [debugNamespace.TraceBlockByHashMuxTracer].
+func (d *debugNamespace) TraceBlockByHashWithContextMuxTracer(
+	ctx context.Context,
+	hash string,
+	timeout time.Duration,
+	reexec *uint64,
+	cfg *MuxTracerConfig,
+) ([]*evmctypes.MuxTracer, error) {
+	muxTracers := []*evmctypes.MuxTracer{}
+	traceCfg := newTracerConfig(MuxTracer, timeout, reexec, cfg.tracerConfig())
+	if err := d.traceBlockByHash(ctx, hash, traceCfg, &muxTracers); err != nil {
+		return nil, err
+	}
+	for _, muxTracer := range muxTracers {
+		if muxTracer.Result != nil {
+			assignIndexCalls(muxTracer.Result.CallTracer)
+		}
+	}
+	return muxTracers, nil
+}
+
+// TraceBlockByHashStructLogger replays a block by hash using the default
+// struct-logger tracer.
+func (d *debugNamespace) TraceBlockByHashStructLogger(
+	hash string,
+	timeout time.Duration,
+	reexec *uint64,
+	cfg *DefaultTraceConfig,
+) ([]*evmctypes.StructLogTracer, error) {
+	return d.TraceBlockByHashWithContextStructLogger(context.Background(), hash, timeout, reexec, cfg)
+}
+
+// TraceBlockByHashWithContextStructLogger is the context-aware variant of [debugNamespace.TraceBlockByHashStructLogger].
+func (d *debugNamespace) TraceBlockByHashWithContextStructLogger(
+	ctx context.Context,
+	hash string,
+	timeout time.Duration,
+	reexec *uint64,
+	cfg *DefaultTraceConfig,
+) ([]*evmctypes.StructLogTracer, error) {
+	structLogTracers := []*evmctypes.StructLogTracer{}
+	traceCfg := newStructLoggerConfig(timeout, reexec, cfg)
+	if err := d.traceBlockByHash(ctx, hash, traceCfg, &structLogTracers); err != nil {
+		return nil, err
+	}
+	return structLogTracers, nil
+}
+
 // TraceBlockByHashCustomTracer replays a block by hash using a custom JavaScript tracer.
 func (d *debugNamespace) TraceBlockByHashCustomTracer(
 	hash string,
@@ -539,6 +753,9 @@ func (d *debugNamespace) TraceTransactionWithContextFlatCallTracer(
 	reexec *uint64,
 	cfg *FlatCallTracerConfig,
 ) ([]*evmctypes.FlatCallFrame, error) {
+	if err := d.requireFlatCallTracer(); err != nil {
+		return nil, err
+	}
 	flatCallFrames := []*evmctypes.FlatCallFrame{}
 	traceCfg := newTracerConfig(FlatCallTracer, timeout, reexec, cfg)
 	if err := d.traceTransaction(ctx, hash, traceCfg, &flatCallFrames); err != nil {
@@ -573,6 +790,84 @@ func (d *debugNamespace) TraceTransactionWithContextPrestateTracer(
 	return prestateResult, nil
 }
 
+// TraceTransactionFourByteTracer replays a transaction using the 4byteTracer.
+func (d *debugNamespace) TraceTransactionFourByteTracer(
+	hash string,
+	timeout time.Duration,
+	reexec *uint64,
+) (evmctypes.FourByteFrame, error) {
+	return d.TraceTransactionWithContextFourByteTracer(context.Background(), hash, timeout, reexec)
+}
+
+// TraceTransactionWithContextFourByteTracer is the context-aware variant of [debugNamespace.TraceTransactionFourByteTracer].
+func (d *debugNamespace) TraceTransactionWithContextFourByteTracer(
+	ctx context.Context,
+	hash string,
+	timeout time.Duration,
+	reexec *uint64,
+) (evmctypes.FourByteFrame, error) {
+	fourByteFrame := evmctypes.FourByteFrame{}
+	traceCfg := newTracerConfig(FourByteTracer, timeout, reexec, nil)
+	if err := d.traceTransaction(ctx, hash, traceCfg, &fourByteFrame); err != nil {
+		return nil, err
+	}
+	return fourByteFrame, nil
+}
+
+// TraceTransactionMuxTracer replays a transaction once, running the callTracer, prestateTracer and
+// 4byteTracer together.
+func (d *debugNamespace) TraceTransactionMuxTracer(
+	hash string,
+	timeout time.Duration,
+	reexec *uint64,
+	cfg *MuxTracerConfig,
+) (*evmctypes.MuxFrame, error) {
+	return d.TraceTransactionWithContextMuxTracer(context.Background(), hash, timeout, reexec, cfg)
+}
+
+// TraceTransactionWithContextMuxTracer is the context-aware variant of [debugNamespace.TraceTransactionMuxTracer].
+func (d *debugNamespace) TraceTransactionWithContextMuxTracer(
+	ctx context.Context,
+	hash string,
+	timeout time.Duration,
+	reexec *uint64,
+	cfg *MuxTracerConfig,
+) (*evmctypes.MuxFrame, error) {
+	muxFrame := &evmctypes.MuxFrame{}
+	traceCfg := newTracerConfig(MuxTracer, timeout, reexec, cfg.tracerConfig())
+	if err := d.traceTransaction(ctx, hash, traceCfg, muxFrame); err != nil {
+		return nil, err
+	}
+	assignIndexCalls(muxFrame.CallTracer)
+	return muxFrame, nil
+}
+
+// TraceTransactionStructLogger replays a transaction using the default struct-logger tracer.
+func (d *debugNamespace) TraceTransactionStructLogger(
+	hash string,
+	timeout time.Duration,
+	reexec *uint64,
+	cfg *DefaultTraceConfig,
+) (*evmctypes.StructLogResult, error) {
+	return d.TraceTransactionWithContextStructLogger(context.Background(), hash, timeout, reexec, cfg)
+}
+
+// TraceTransactionWithContextStructLogger is the context-aware variant of [debugNamespace.TraceTransactionStructLogger].
+func (d *debugNamespace) TraceTransactionWithContextStructLogger(
+	ctx context.Context,
+	hash string,
+	timeout time.Duration,
+	reexec *uint64,
+	cfg *DefaultTraceConfig,
+) (*evmctypes.StructLogResult, error) {
+	structLogResult := &evmctypes.StructLogResult{}
+	traceCfg := newStructLoggerConfig(timeout, reexec, cfg)
+	if err := d.traceTransaction(ctx, hash, traceCfg, structLogResult); err != nil {
+		return nil, err
+	}
+	return structLogResult, nil
+}
+
 // TraceTransactionCustomTracer replays a transaction using a custom JavaScript tracer.
 func (d *debugNamespace) TraceTransactionCustomTracer(
 	hash string,
@@ -615,7 +910,8 @@ func (d *debugNamespace) traceTransaction(
 // ─── TraceCall ───────────────────────────────────────────────────────────────
 
 // TraceCall simulates tx against blockAndTag and returns the raw trace result
-// using cfg (nil uses the default struct-logger tracer).
+// using cfg (nil uses the default struct-logger tracer). Set cfg.CallOverrides
+// to run the call with state or block overrides.
 func (d *debugNamespace) TraceCall(tx *Tx, blockAndTag evmctypes.BlockAndTag, cfg *TraceConfig) (any, error) {
 	return d.TraceCallWithContext(context.Background(), tx, blockAndTag, cfg)
 }
@@ -653,9 +949,48 @@ func (d *debugNamespace) TraceCallWithContextCallTracer(
 	timeout time.Duration,
 	reexec *uint64,
 	cfg *CallTracerConfig,
+) (*evmctypes.CallFrame, error) {
+	return d.traceCallCallTracer(ctx, tx, blockAndTag, nil, timeout, reexec, cfg)
+}
+
+// TraceCallCallTracerWithOverrides simulates tx using the callTracer with the
+// given state and block overrides applied.
+func (d *debugNamespace) TraceCallCallTracerWithOverrides(
+	tx *Tx,
+	blockAndTag evmctypes.BlockAndTag,
+	overrides *evmctypes.CallOverrides,
+	timeout time.Duration,
+	reexec *uint64,
+	cfg *CallTracerConfig,
+) (*evmctypes.CallFrame, error) {
+	return d.traceCallCallTracer(context.Background(), tx, blockAndTag, overrides, timeout, reexec, cfg)
+}
+
+// TraceCallWithContextCallTracerWithOverrides is the context-aware variant of [debugNamespace.TraceCallCallTracerWithOverrides].
+func (d *debugNamespace) TraceCallWithContextCallTracerWithOverrides(
+	ctx context.Context,
+	tx *Tx,
+	blockAndTag evmctypes.BlockAndTag,
+	overrides *evmctypes.CallOverrides,
+	timeout time.Duration,
+	reexec *uint64,
+	cfg *CallTracerConfig,
+) (*evmctypes.CallFrame, error) {
+	return d.traceCallCallTracer(ctx, tx, blockAndTag, overrides, timeout, reexec, cfg)
+}
+
+func (d *debugNamespace) traceCallCallTracer(
+	ctx context.Context,
+	tx *Tx,
+	blockAndTag evmctypes.BlockAndTag,
+	overrides *evmctypes.CallOverrides,
+	timeout time.Duration,
+	reexec *uint64,
+	cfg *CallTracerConfig,
 ) (*evmctypes.CallFrame, error) {
 	callFrame := &evmctypes.CallFrame{}
 	traceCfg := newTracerConfig(CallTracer, timeout, reexec, cfg)
+	traceCfg.CallOverrides = overrides
 	if err := d.traceCall(ctx, tx, blockAndTag, traceCfg, callFrame); err != nil {
 		return nil, err
 	}
@@ -683,6 +1018,9 @@ func (d *debugNamespace) TraceCallWithContextFlatCallTracer(
 	reexec *uint64,
 	cfg *FlatCallTracerConfig,
 ) ([]*evmctypes.FlatCallFrame, error) {
+	if err := d.requireFlatCallTracer(); err != nil {
+		return nil, err
+	}
 	flatCallFrames := []*evmctypes.FlatCallFrame{}
 	traceCfg := newTracerConfig(FlatCallTracer, timeout, reexec, cfg)
 	if err := d.traceCall(ctx, tx, blockAndTag, traceCfg, &flatCallFrames); err != nil {
@@ -710,15 +1048,138 @@ func (d *debugNamespace) TraceCallWithContextPrestateTracer(
 	timeout time.Duration,
 	reexec *uint64,
 	cfg *PrestateTracerConfig,
+) (*evmctypes.PrestateResult, error) {
+	return d.traceCallPrestateTracer(ctx, tx, blockAndTag, nil, timeout, reexec, cfg)
+}
+
+// TraceCallPrestateTracerWithOverrides simulates tx using the prestateTracer
+// with the given state and block overrides applied.
+func (d *debugNamespace) TraceCallPrestateTracerWithOverrides(
+	tx *Tx,
+	blockAndTag evmctypes.BlockAndTag,
+	overrides *evmctypes.CallOverrides,
+	timeout time.Duration,
+	reexec *uint64,
+	cfg *PrestateTracerConfig,
+) (*evmctypes.PrestateResult, error) {
+	return d.traceCallPrestateTracer(context.Background(), tx, blockAndTag, overrides, timeout, reexec, cfg)
+}
+
+// TraceCallWithContextPrestateTracerWithOverrides is the context-aware variant of [debugNamespace.TraceCallPrestateTracerWithOverrides].
+func (d *debugNamespace) TraceCallWithContextPrestateTracerWithOverrides(
+	ctx context.Context,
+	tx *Tx,
+	blockAndTag evmctypes.BlockAndTag,
+	overrides *evmctypes.CallOverrides,
+	timeout time.Duration,
+	reexec *uint64,
+	cfg *PrestateTracerConfig,
+) (*evmctypes.PrestateResult, error) {
+	return d.traceCallPrestateTracer(ctx, tx, blockAndTag, overrides, timeout, reexec, cfg)
+}
+
+func (d *debugNamespace) traceCallPrestateTracer(
+	ctx context.Context,
+	tx *Tx,
+	blockAndTag evmctypes.BlockAndTag,
+	overrides *evmctypes.CallOverrides,
+	timeout time.Duration,
+	reexec *uint64,
+	cfg *PrestateTracerConfig,
 ) (*evmctypes.PrestateResult, error) {
 	prestateResult := &evmctypes.PrestateResult{}
 	traceCfg := newTracerConfig(PrestateTracer, timeout, reexec, cfg)
+	traceCfg.CallOverrides = overrides
 	if err := d.traceCall(ctx, tx, blockAndTag, traceCfg, prestateResult); err != nil {
 		return nil, err
 	}
 	return prestateResult, nil
 }
 
+// TraceCallFourByteTracer simulates tx using the 4byteTracer.
+func (d *debugNamespace) TraceCallFourByteTracer(
+	tx *Tx,
+	blockAndTag evmctypes.BlockAndTag,
+	timeout time.Duration,
+	reexec *uint64,
+) (evmctypes.FourByteFrame, error) {
+	return d.TraceCallWithContextFourByteTracer(context.Background(), tx, blockAndTag, timeout, reexec)
+}
+
+// TraceCallWithContextFourByteTracer is the context-aware variant of [debugNamespace.TraceCallFourByteTracer].
+func (d *debugNamespace) TraceCallWithContextFourByteTracer(
+	ctx context.Context,
+	tx *Tx,
+	blockAndTag evmctypes.BlockAndTag,
+	timeout time.Duration,
+	reexec *uint64,
+) (evmctypes.FourByteFrame, error) {
+	fourByteFrame := evmctypes.FourByteFrame{}
+	traceCfg := newTracerConfig(FourByteTracer, timeout, reexec, nil)
+	if err := d.traceCall(ctx, tx, blockAndTag, traceCfg, &fourByteFrame); err != nil {
+		return nil, err
+	}
+	return fourByteFrame, nil
+}
+
+// TraceCallMuxTracer simulates tx once, running the callTracer, prestateTracer and
+// 4byteTracer together.
+func (d *debugNamespace) TraceCallMuxTracer(
+	tx *Tx,
+	blockAndTag evmctypes.BlockAndTag,
+	timeout time.Duration,
+	reexec *uint64,
+	cfg *MuxTracerConfig,
+) (*evmctypes.MuxFrame, error) {
+	return d.TraceCallWithContextMuxTracer(context.Background(), tx, blockAndTag, timeout, reexec, cfg)
+}
+
+// TraceCallWithContextMuxTracer is the context-aware variant of [debugNamespace.TraceCallMuxTracer].
+func (d *debugNamespace) TraceCallWithContextMuxTracer(
+	ctx context.Context,
+	tx *Tx,
+	blockAndTag evmctypes.BlockAndTag,
+	timeout time.Duration,
+	reexec *uint64,
+	cfg *MuxTracerConfig,
+) (*evmctypes.MuxFrame, error) {
+	muxFrame := &evmctypes.MuxFrame{}
+	traceCfg := newTracerConfig(MuxTracer, timeout, reexec, cfg.tracerConfig())
+	if err := d.traceCall(ctx, tx, blockAndTag, traceCfg, muxFrame); err != nil {
+		return nil, err
+	}
+	assignIndexCalls(muxFrame.CallTracer)
+	return muxFrame, nil
+}
+
+// TraceCallStructLogger simulates tx using the default struct-logger tracer.
+func (d *debugNamespace) TraceCallStructLogger(
+	tx *Tx,
+	blockAndTag evmctypes.BlockAndTag,
+	timeout time.Duration,
+	reexec *uint64,
+	cfg *DefaultTraceConfig,
+) (*evmctypes.StructLogResult, error) {
+	return d.TraceCallWithContextStructLogger(context.Background(), tx, blockAndTag, timeout, reexec, cfg)
+}
+
+// TraceCallWithContextStructLogger is the context-aware variant of [debugNamespace.TraceCallStructLogger].
+func (d *debugNamespace) TraceCallWithContextStructLogger(
+	ctx context.Context,
+	tx *Tx,
+	blockAndTag evmctypes.BlockAndTag,
+	timeout time.Duration,
+	reexec *uint64,
+	cfg *DefaultTraceConfig,
+) (*evmctypes.StructLogResult, error) {
+	structLogResult := &evmctypes.StructLogResult{}
+	traceCfg := newStructLoggerConfig(timeout, reexec, cfg)
+	if err := d.traceCall(ctx, tx, blockAndTag, traceCfg, structLogResult); err != nil {
+		return nil, err
+	}
+	return structLogResult, nil
+}
+
 // TraceCallCustomTracer simulates tx using a custom JavaScript tracer.
 func (d *debugNamespace) TraceCallCustomTracer(
 	tx *Tx,
@@ -758,7 +1219,7 @@ func (d *debugNamespace) traceCall(
 	if err != nil {
 		return err
 	}
-	params := []any{msg, blockAndTag.String()}
+	params := []any{msg, blockAndTag}
 	if traceCfg != nil {
 		params = append(params, *traceCfg)
 	}
@@ -779,7 +1240,7 @@ func (d *debugNamespace) GetRawHeaderWithContext(ctx context.Context, blockAndTa
 
 func (d *debugNamespace) getRawHeader(ctx context.Context, blockAndTag evmctypes.BlockAndTag) (string, error) {
 	result := new(string)
-	if err := d.c.call(ctx, result, DebugGetRawHeader, blockAndTag.String()); err != nil {
+	if err := d.c.call(ctx, result, DebugGetRawHeader, blockAndTag); err != nil {
 		return "", err
 	}
 	return *result, nil
@@ -797,7 +1258,7 @@ func (d *debugNamespace) GetRawBlockWithContext(ctx context.Context, blockAndTag
 
 func (d *debugNamespace) getRawBlock(ctx context.Context, blockAndTag evmctypes.BlockAndTag) (string, error) {
 	result := new(string)
-	if err := d.c.call(ctx, result, DebugGetRawBlock, blockAndTag.String()); err != nil {
+	if err := d.c.call(ctx, result, DebugGetRawBlock, blockAndTag); err != nil {
 		return "", err
 	}
 	return *result, nil
@@ -833,7 +1294,7 @@ func (d *debugNamespace) GetRawReceiptsWithContext(ctx context.Context, blockAnd
 
 func (d *debugNamespace) getRawReceipts(ctx context.Context, blockAndTag evmctypes.BlockAndTag) ([]string, error) {
 	result := new([]string)
-	if err := d.c.call(ctx, result, DebugGetRawReceipts, blockAndTag.String()); err != nil {
+	if err := d.c.call(ctx, result, DebugGetRawReceipts, blockAndTag); err != nil {
 		return nil, err
 	}
 	return *result, nil
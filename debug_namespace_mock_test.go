@@ -3,6 +3,7 @@ package evmc
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/bbaktaeho/evmc/evmctypes"
 	"github.com/stretchr/testify/assert"
@@ -237,6 +238,30 @@ func Test_debugNamespace_mock_TraceCallPrestateTracer(t *testing.T) {
 	require.NotNil(t, result)
 }
 
+func Test_debugNamespace_mock_TraceCallCallTracerWithOverrides(t *testing.T) {
+	client := testWithMock(t, "debug_traceCall", func(params json.RawMessage) any {
+		var p []json.RawMessage
+		require.NoError(t, json.Unmarshal(params, &p))
+		require.Len(t, p, 3)
+		cfg := map[string]json.RawMessage{}
+		require.NoError(t, json.Unmarshal(p[2], &cfg))
+		assert.JSONEq(t, `"callTracer"`, string(cfg["tracer"]))
+		assert.JSONEq(t, `{"0xholder":{"state":{"0x00":"0x01"}}}`, string(cfg["stateOverrides"]))
+		assert.JSONEq(t, `{"baseFeePerGas":"0x0"}`, string(cfg["blockOverrides"]))
+		return callTracerResultJSON("")["result"]
+	})
+	baseFee := "0x0"
+	tx := &Tx{To: "0x000000000000000000000000000000000000dead", Data: "0x"}
+	callFrame, err := client.Debug().TraceCallCallTracerWithOverrides(tx, evmctypes.Latest, &evmctypes.CallOverrides{
+		StateOverrides: evmctypes.SimulateStateOverride{
+			"0xholder": {State: map[string]string{"0x00": "0x01"}},
+		},
+		BlockOverrides: &evmctypes.SimulateBlockOverride{BaseFeePerGas: &baseFee},
+	}, time.Second, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "CALL", callFrame.Type)
+}
+
 // ─── Custom Tracer ───────────────────────────────────────────────────────────
 
 // customTracerResultJSON은 custom JS tracer 결과 mock 데이터.
@@ -388,3 +413,143 @@ func Test_debugNamespace_mock_GetBadBlocks(t *testing.T) {
 	require.NotNil(t, badBlocks[0].Block)
 	assert.Equal(t, uint64(1), badBlocks[0].Block.Number)
 }
+
+// ─── 4byte / Mux / StructLogger ─────────────────────────────────────────────
+
+func Test_debugNamespace_mock_TraceTransactionFourByteTracer(t *testing.T) {
+	client := testWithMock(t, "debug_traceTransaction", func(params json.RawMessage) any {
+		var p []json.RawMessage
+		require.NoError(t, json.Unmarshal(params, &p))
+		require.Len(t, p, 2)
+		assert.Contains(t, string(p[1]), `"tracer":"4byteTracer"`)
+		return map[string]any{
+			// transfer(address,uint256) 호출 1회, calldata 64 bytes
+			"0xa9059cbb-64": 1,
+		}
+	})
+	frame, err := client.Debug().TraceTransactionFourByteTracer("0xtxhash", time.Second, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), frame["0xa9059cbb-64"])
+}
+
+func Test_debugNamespace_mock_TraceTransactionMuxTracer(t *testing.T) {
+	client := testWithMock(t, "debug_traceTransaction", func(params json.RawMessage) any {
+		var p []json.RawMessage
+		require.NoError(t, json.Unmarshal(params, &p))
+		cfg := struct {
+			Tracer       string                     `json:"tracer"`
+			TracerConfig map[string]json.RawMessage `json:"tracerConfig"`
+		}{}
+		require.NoError(t, json.Unmarshal(p[1], &cfg))
+		assert.Equal(t, "muxTracer", cfg.Tracer)
+		assert.JSONEq(t, `{"onlyTopCall":false,"withLog":true}`, string(cfg.TracerConfig["callTracer"]))
+		assert.JSONEq(t, `{}`, string(cfg.TracerConfig["prestateTracer"]))
+		assert.Contains(t, cfg.TracerConfig, "4byteTracer")
+		return map[string]any{
+			"callTracer": callTracerResultJSON("")["result"],
+			"prestateTracer": map[string]any{
+				"0xfrom": map[string]any{"balance": "0xde0b6b3a7640000", "nonce": 1},
+			},
+			"4byteTracer": map[string]any{"0xa9059cbb-64": 2},
+		}
+	})
+	frame, err := client.Debug().TraceTransactionMuxTracer("0xtxhash", time.Second, nil, &MuxTracerConfig{
+		CallTracer: &CallTracerConfig{WithLog: true},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, frame.CallTracer)
+	assert.Equal(t, "CALL", frame.CallTracer.Type)
+	require.NotNil(t, frame.PrestateTracer)
+	prestate, err := frame.PrestateTracer.ParseFrame()
+	require.NoError(t, err)
+	assert.Contains(t, prestate, "0xfrom")
+	assert.Equal(t, uint64(2), frame.FourByteTracer["0xa9059cbb-64"])
+}
+
+func Test_debugNamespace_mock_TraceBlockByNumberMuxTracer(t *testing.T) {
+	client := testWithMock(t, "debug_traceBlockByNumber", func(params json.RawMessage) any {
+		return []map[string]any{
+			{
+				"txHash": "0xtx1",
+				"result": map[string]any{
+					"callTracer":  callTracerResultJSON("")["result"],
+					"4byteTracer": map[string]any{},
+				},
+			},
+		}
+	})
+	results, err := client.Debug().TraceBlockByNumberMuxTracer(100, time.Second, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "0xtx1", results[0].TxHash)
+	require.NotNil(t, results[0].Result)
+	assert.Equal(t, "0xfrom", results[0].Result.CallTracer.From)
+	assert.Nil(t, results[0].Result.PrestateTracer)
+}
+
+func Test_debugNamespace_mock_TraceTransactionStructLogger(t *testing.T) {
+	client := testWithMock(t, "debug_traceTransaction", func(params json.RawMessage) any {
+		var p []json.RawMessage
+		require.NoError(t, json.Unmarshal(params, &p))
+		assert.NotContains(t, string(p[1]), `"tracer"`)
+		assert.Contains(t, string(p[1]), `"enableMemory":true`)
+		return map[string]any{
+			"gas":         21100,
+			"failed":      true,
+			"returnValue": "0x08c379a0",
+			"structLogs": []any{
+				map[string]any{
+					"pc": 0, "op": "PUSH1", "gas": 79000, "gasCost": 3, "depth": 1,
+					"stack": []string{},
+				},
+				map[string]any{
+					"pc": 2, "op": "SSTORE", "gas": 78997, "gasCost": 20000, "depth": 1,
+					"stack":   []string{"0x1", "0x0"},
+					"storage": map[string]string{"0x00": "0x01"},
+					"refund":  4800,
+				},
+				map[string]any{
+					"pc": 3, "op": "REVERT", "gas": 58997, "gasCost": 0, "depth": 1,
+					"error": "execution reverted",
+				},
+			},
+		}
+	})
+	result, err := client.Debug().TraceTransactionStructLogger("0xtxhash", time.Second, nil, &DefaultTraceConfig{EnableMemory: true})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(21100), result.Gas)
+	assert.True(t, result.Failed)
+	assert.Equal(t, "0x08c379a0", result.ReturnValue)
+	require.Len(t, result.StructLogs, 3)
+	assert.Equal(t, "SSTORE", result.StructLogs[1].OP)
+	assert.Equal(t, uint64(20000), result.StructLogs[1].GasCost)
+	assert.Equal(t, "0x01", result.StructLogs[1].Storage["0x00"])
+	assert.Equal(t, uint64(4800), result.StructLogs[1].Refund)
+	assert.Equal(t, "execution reverted", result.StructLogs[2].Error)
+}
+
+func Test_debugNamespace_mock_TraceBlockByHashStructLogger(t *testing.T) {
+	client := testWithMock(t, "debug_traceBlockByHash", func(params json.RawMessage) any {
+		return []map[string]any{
+			traceResultJSON("0xtx1"),
+			traceResultJSON("0xtx2"),
+		}
+	})
+	results, err := client.Debug().TraceBlockByHashStructLogger("0xblockhash", time.Second, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "0xtx2", results[1].TxHash)
+	require.NotNil(t, results[1].Result)
+	assert.Equal(t, uint64(21000), results[1].Result.Gas)
+	assert.Empty(t, results[1].Result.StructLogs)
+}
+
+func Test_debugNamespace_mock_TraceCallFourByteTracer(t *testing.T) {
+	client := testWithMock(t, "debug_traceCall", func(params json.RawMessage) any {
+		return map[string]any{"0x18160ddd-0": 1}
+	})
+	tx := &Tx{To: "0x000000000000000000000000000000000000dead", Data: "0x18160ddd"}
+	frame, err := client.Debug().TraceCallFourByteTracer(tx, evmctypes.Latest, time.Second, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), frame["0x18160ddd-0"])
+}
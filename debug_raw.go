@@ -0,0 +1,473 @@
+package evmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// DecodedTransaction is a transaction decoded from its consensus encoding
+// together with its recovered sender.
+type DecodedTransaction struct {
+	*types.Transaction
+	From common.Address
+}
+
+// ToTransaction converts t to an [evmctypes.Transaction] without block
+// context (block hash, number and index are left empty).
+func (t *DecodedTransaction) ToTransaction() (*evmctypes.Transaction, error) {
+	return convertTransaction(t.Transaction, t.From, nil, 0)
+}
+
+// DecodedBlock is a block decoded from its RLP encoding together with the
+// recovered sender of each transaction.
+type DecodedBlock struct {
+	*types.Block
+	Senders []common.Address
+}
+
+// Transaction returns the i-th transaction with its sender.
+func (b *DecodedBlock) Transaction(i int) *DecodedTransaction {
+	return &DecodedTransaction{Transaction: b.Transactions()[i], From: b.Senders[i]}
+}
+
+// ToBlock converts b to an [evmctypes.Block] holding transaction hashes.
+func (b *DecodedBlock) ToBlock() (*evmctypes.Block, error) {
+	hashes := make([]string, len(b.Transactions()))
+	for i, tx := range b.Transactions() {
+		hashes[i] = tx.Hash().Hex()
+	}
+	block := new(evmctypes.Block)
+	if err := b.convert(hashes, block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// ToBlockIncTx converts b to an [evmctypes.BlockIncTx] holding full
+// transactions.
+func (b *DecodedBlock) ToBlockIncTx() (*evmctypes.BlockIncTx, error) {
+	txs := make([]map[string]any, len(b.Transactions()))
+	for i, tx := range b.Transactions() {
+		fields, err := transactionFields(tx, b.Senders[i], b.Header(), uint64(i))
+		if err != nil {
+			return nil, err
+		}
+		txs[i] = fields
+	}
+	block := new(evmctypes.BlockIncTx)
+	if err := b.convert(txs, block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func (b *DecodedBlock) convert(transactions any, dst any) error {
+	fields, err := headerFields(b.Header())
+	if err != nil {
+		return err
+	}
+	uncles := make([]string, len(b.Uncles()))
+	for i, uncle := range b.Uncles() {
+		uncles[i] = uncle.Hash().Hex()
+	}
+	fields["size"] = hexutil.Uint64(b.Size())
+	fields["uncles"] = uncles
+	fields["transactions"] = transactions
+	if b.Withdrawals() != nil {
+		fields["withdrawals"] = b.Withdrawals()
+	}
+	return remarshal(fields, dst)
+}
+
+// VerifyTransactionsRoot recomputes the transactions trie and compares its
+// root with the header. It also checks the uncles hash and, after Shanghai,
+// the withdrawals root.
+func (b *DecodedBlock) VerifyTransactionsRoot() error {
+	header := b.Header()
+	if root := types.DeriveSha(b.Transactions(), trie.NewStackTrie(nil)); root != header.TxHash {
+		return fmt.Errorf("%w: transactionsRoot %s, computed %s", ErrRootMismatch, header.TxHash, root)
+	}
+	if hash := types.CalcUncleHash(b.Uncles()); hash != header.UncleHash {
+		return fmt.Errorf("%w: sha3Uncles %s, computed %s", ErrRootMismatch, header.UncleHash, hash)
+	}
+	if header.WithdrawalsHash != nil {
+		root := types.DeriveSha(b.Withdrawals(), trie.NewStackTrie(nil))
+		if root != *header.WithdrawalsHash {
+			return fmt.Errorf("%w: withdrawalsRoot %s, computed %s", ErrRootMismatch, *header.WithdrawalsHash, root)
+		}
+	}
+	return nil
+}
+
+// VerifyReceiptsRoot recomputes the receipts trie of receipts and compares its
+// root with the header.
+func (b *DecodedBlock) VerifyReceiptsRoot(receipts types.Receipts) error {
+	header := b.Header()
+	if len(receipts) != len(b.Transactions()) {
+		return fmt.Errorf("%w: %d receipts for %d transactions", ErrRootMismatch, len(receipts), len(b.Transactions()))
+	}
+	if root := types.DeriveSha(receipts, trie.NewStackTrie(nil)); root != header.ReceiptHash {
+		return fmt.Errorf("%w: receiptsRoot %s, computed %s", ErrRootMismatch, header.ReceiptHash, root)
+	}
+	return nil
+}
+
+// ToReceipts converts the consensus receipts of b to [evmctypes.Receipt]s,
+// deriving the fields that are not part of the consensus encoding (hashes,
+// indices, gas used, contract address, effective gas price) from the block.
+// blobGasPrice is left empty since it depends on the chain configuration.
+func (b *DecodedBlock) ToReceipts(receipts types.Receipts) ([]*evmctypes.Receipt, error) {
+	if len(receipts) != len(b.Transactions()) {
+		return nil, fmt.Errorf("ToReceipts: %d receipts for %d transactions", len(receipts), len(b.Transactions()))
+	}
+	result := make([]*evmctypes.Receipt, len(receipts))
+	for i, fields := range b.receiptFields(receipts) {
+		result[i] = new(evmctypes.Receipt)
+		if err := remarshal(fields, result[i]); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// receiptFields returns the JSON-RPC representation of receipts.
+func (b *DecodedBlock) receiptFields(receipts types.Receipts) []map[string]any {
+	var (
+		txs      = b.Transactions()
+		header   = b.Header()
+		result   = make([]map[string]any, len(receipts))
+		logIndex uint
+		prevGas  uint64
+	)
+	for i, receipt := range receipts {
+		tx := txs[i]
+		fields := map[string]any{
+			"blockHash":         header.Hash(),
+			"blockNumber":       (*hexutil.Big)(header.Number),
+			"transactionHash":   tx.Hash(),
+			"transactionIndex":  hexutil.Uint64(i),
+			"from":              b.Senders[i],
+			"to":                tx.To(),
+			"gasUsed":           hexutil.Uint64(receipt.CumulativeGasUsed - prevGas),
+			"cumulativeGasUsed": hexutil.Uint64(receipt.CumulativeGasUsed),
+			"type":              hexutil.Uint(tx.Type()),
+			"effectiveGasPrice": (*hexutil.Big)(effectiveGasPrice(tx, header.BaseFee)),
+			"logsBloom":         receipt.Bloom,
+		}
+		prevGas = receipt.CumulativeGasUsed
+		if len(receipt.PostState) > 0 {
+			fields["root"] = hexutil.Bytes(receipt.PostState)
+		} else {
+			fields["status"] = hexutil.Uint(receipt.Status)
+		}
+		if tx.To() == nil {
+			fields["contractAddress"] = crypto.CreateAddress(b.Senders[i], tx.Nonce())
+		}
+		if tx.Type() == types.BlobTxType {
+			fields["blobGasUsed"] = hexutil.Uint64(uint64(len(tx.BlobHashes())) * params.BlobTxBlobGasPerBlob)
+		}
+		logs := make([]map[string]any, len(receipt.Logs))
+		for j, log := range receipt.Logs {
+			logs[j] = map[string]any{
+				"address":          log.Address,
+				"topics":           log.Topics,
+				"data":             hexutil.Bytes(log.Data),
+				"blockNumber":      (*hexutil.Big)(header.Number),
+				"blockHash":        header.Hash(),
+				"blockTimestamp":   hexutil.Uint64(header.Time),
+				"transactionHash":  tx.Hash(),
+				"transactionIndex": hexutil.Uint64(i),
+				"logIndex":         hexutil.Uint(logIndex),
+				"removed":          false,
+			}
+			logIndex++
+		}
+		fields["logs"] = logs
+		result[i] = fields
+	}
+	return result
+}
+
+// DecodeRawHeader decodes the output of debug_getRawHeader.
+func DecodeRawHeader(raw string) (*types.Header, error) {
+	b, err := hexutil.Decode(raw)
+	if err != nil {
+		return nil, fmt.Errorf("DecodeRawHeader: %w", err)
+	}
+	header := new(types.Header)
+	if err := rlp.DecodeBytes(b, header); err != nil {
+		return nil, fmt.Errorf("DecodeRawHeader: %w", err)
+	}
+	return header, nil
+}
+
+// DecodeRawBlock decodes the output of debug_getRawBlock and recovers the
+// sender of every transaction.
+func DecodeRawBlock(raw string) (*DecodedBlock, error) {
+	b, err := hexutil.Decode(raw)
+	if err != nil {
+		return nil, fmt.Errorf("DecodeRawBlock: %w", err)
+	}
+	block := new(types.Block)
+	if err := rlp.DecodeBytes(b, block); err != nil {
+		return nil, fmt.Errorf("DecodeRawBlock: %w", err)
+	}
+	senders := make([]common.Address, len(block.Transactions()))
+	for i, tx := range block.Transactions() {
+		if senders[i], err = recoverSender(tx); err != nil {
+			return nil, fmt.Errorf("DecodeRawBlock: tx %d: %w", i, err)
+		}
+	}
+	return &DecodedBlock{Block: block, Senders: senders}, nil
+}
+
+// DecodeRawTransaction decodes the output of debug_getRawTransaction (or any
+// signed transaction in consensus encoding) and recovers its sender.
+func DecodeRawTransaction(raw string) (*DecodedTransaction, error) {
+	b, err := hexutil.Decode(raw)
+	if err != nil {
+		return nil, fmt.Errorf("DecodeRawTransaction: %w", err)
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(b); err != nil {
+		return nil, fmt.Errorf("DecodeRawTransaction: %w", err)
+	}
+	from, err := recoverSender(tx)
+	if err != nil {
+		return nil, fmt.Errorf("DecodeRawTransaction: %w", err)
+	}
+	return &DecodedTransaction{Transaction: tx, From: from}, nil
+}
+
+// DecodeRawReceipts decodes the output of debug_getRawReceipts.
+func DecodeRawReceipts(raw []string) (types.Receipts, error) {
+	receipts := make(types.Receipts, len(raw))
+	for i, r := range raw {
+		b, err := hexutil.Decode(r)
+		if err != nil {
+			return nil, fmt.Errorf("DecodeRawReceipts: receipt %d: %w", i, err)
+		}
+		receipt := new(types.Receipt)
+		if err := receipt.UnmarshalBinary(b); err != nil {
+			return nil, fmt.Errorf("DecodeRawReceipts: receipt %d: %w", i, err)
+		}
+		receipts[i] = receipt
+	}
+	return receipts, nil
+}
+
+// ConvertHeader converts a decoded header to an [evmctypes.Header].
+func ConvertHeader(header *types.Header) (*evmctypes.Header, error) {
+	fields, err := headerFields(header)
+	if err != nil {
+		return nil, err
+	}
+	result := new(evmctypes.Header)
+	if err := remarshal(fields, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// recoverSender recovers the sender with a signer accepting every transaction
+// type. Legacy transactions without replay protection are recovered with the
+// Homestead rules.
+func recoverSender(tx *types.Transaction) (common.Address, error) {
+	return types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+}
+
+// effectiveGasPrice is the price paid per gas unit: the gas price of legacy
+// and access-list transactions, min(feeCap, baseFee+tipCap) otherwise.
+func effectiveGasPrice(tx *types.Transaction, baseFee *big.Int) *big.Int {
+	if baseFee == nil {
+		return tx.GasPrice()
+	}
+	price := new(big.Int).Add(baseFee, tx.GasTipCap())
+	if price.Cmp(tx.GasFeeCap()) > 0 {
+		return new(big.Int).Set(tx.GasFeeCap())
+	}
+	return price
+}
+
+// headerFields returns the JSON-RPC representation of header, which is what
+// the evmctypes unmarshalers expect.
+func headerFields(header *types.Header) (map[string]any, error) {
+	b, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]any)
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func convertTransaction(
+	tx *types.Transaction,
+	from common.Address,
+	header *types.Header,
+	index uint64,
+) (*evmctypes.Transaction, error) {
+	fields, err := transactionFields(tx, from, header, index)
+	if err != nil {
+		return nil, err
+	}
+	result := new(evmctypes.Transaction)
+	if err := remarshal(fields, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// transactionFields returns the JSON-RPC representation of tx as included in
+// the block of header (nil when pending).
+func transactionFields(
+	tx *types.Transaction,
+	from common.Address,
+	header *types.Header,
+	index uint64,
+) (map[string]any, error) {
+	b, err := json.Marshal(tx)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]any)
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	fields["from"] = from
+	fields["gasPrice"] = (*hexutil.Big)(tx.GasPrice())
+	if header != nil {
+		fields["blockHash"] = header.Hash()
+		fields["blockNumber"] = (*hexutil.Big)(header.Number)
+		fields["transactionIndex"] = hexutil.Uint64(index)
+		fields["gasPrice"] = (*hexutil.Big)(effectiveGasPrice(tx, header.BaseFee))
+	}
+	return fields, nil
+}
+
+func remarshal(src, dst any) error {
+	b, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, dst)
+}
+
+// ─── Decoded Raw Methods ────────────────────────────────────────────────────
+
+// GetDecodedHeader fetches debug_getRawHeader and decodes it.
+func (d *debugNamespace) GetDecodedHeader(blockAndTag evmctypes.BlockAndTag) (*types.Header, error) {
+	return d.GetDecodedHeaderWithContext(context.Background(), blockAndTag)
+}
+
+// GetDecodedHeaderWithContext is the context-aware variant of [debugNamespace.GetDecodedHeader].
+func (d *debugNamespace) GetDecodedHeaderWithContext(ctx context.Context, blockAndTag evmctypes.BlockAndTag) (*types.Header, error) {
+	raw, err := d.getRawHeader(ctx, blockAndTag)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeRawHeader(raw)
+}
+
+// GetDecodedBlock fetches debug_getRawBlock, decodes it and recovers the
+// transaction senders.
+func (d *debugNamespace) GetDecodedBlock(blockAndTag evmctypes.BlockAndTag) (*DecodedBlock, error) {
+	return d.GetDecodedBlockWithContext(context.Background(), blockAndTag)
+}
+
+// GetDecodedBlockWithContext is the context-aware variant of [debugNamespace.GetDecodedBlock].
+func (d *debugNamespace) GetDecodedBlockWithContext(ctx context.Context, blockAndTag evmctypes.BlockAndTag) (*DecodedBlock, error) {
+	raw, err := d.getRawBlock(ctx, blockAndTag)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeRawBlock(raw)
+}
+
+// GetDecodedTransaction fetches debug_getRawTransaction, decodes it and
+// recovers the sender.
+func (d *debugNamespace) GetDecodedTransaction(hash string) (*DecodedTransaction, error) {
+	return d.GetDecodedTransactionWithContext(context.Background(), hash)
+}
+
+// GetDecodedTransactionWithContext is the context-aware variant of [debugNamespace.GetDecodedTransaction].
+func (d *debugNamespace) GetDecodedTransactionWithContext(ctx context.Context, hash string) (*DecodedTransaction, error) {
+	raw, err := d.getRawTransaction(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeRawTransaction(raw)
+}
+
+// GetDecodedReceipts fetches debug_getRawReceipts and decodes them.
+func (d *debugNamespace) GetDecodedReceipts(blockAndTag evmctypes.BlockAndTag) (types.Receipts, error) {
+	return d.GetDecodedReceiptsWithContext(context.Background(), blockAndTag)
+}
+
+// GetDecodedReceiptsWithContext is the context-aware variant of [debugNamespace.GetDecodedReceipts].
+func (d *debugNamespace) GetDecodedReceiptsWithContext(ctx context.Context, blockAndTag evmctypes.BlockAndTag) (types.Receipts, error) {
+	raw, err := d.getRawReceipts(ctx, blockAndTag)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeRawReceipts(raw)
+}
+
+// GetVerifiedRawBlock fetches the raw block and receipts in one batch, decodes
+// them and verifies the transactions, uncles, withdrawals and receipts roots
+// against the header. A mismatch is reported as [ErrRootMismatch].
+func (d *debugNamespace) GetVerifiedRawBlock(blockAndTag evmctypes.BlockAndTag) (*DecodedBlock, types.Receipts, error) {
+	return d.GetVerifiedRawBlockWithContext(context.Background(), blockAndTag)
+}
+
+// GetVerifiedRawBlockWithContext is the context-aware variant of [debugNamespace.GetVerifiedRawBlock].
+func (d *debugNamespace) GetVerifiedRawBlockWithContext(
+	ctx context.Context,
+	blockAndTag evmctypes.BlockAndTag,
+) (*DecodedBlock, types.Receipts, error) {
+	var (
+		rawBlock    string
+		rawReceipts []string
+		elements    = []rpc.BatchElem{
+			{Method: DebugGetRawBlock.String(), Args: []any{blockAndTag}, Result: &rawBlock},
+			{Method: DebugGetRawReceipts.String(), Args: []any{blockAndTag}, Result: &rawReceipts},
+		}
+	)
+	if err := d.c.BatchCallWithContext(ctx, elements, -1); err != nil {
+		return nil, nil, fmt.Errorf("GetVerifiedRawBlock: %w", err)
+	}
+	for _, el := range elements {
+		if el.Error != nil {
+			return nil, nil, fmt.Errorf("GetVerifiedRawBlock: %w", el.Error)
+		}
+	}
+	block, err := DecodeRawBlock(rawBlock)
+	if err != nil {
+		return nil, nil, err
+	}
+	receipts, err := DecodeRawReceipts(rawReceipts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := block.VerifyTransactionsRoot(); err != nil {
+		return nil, nil, fmt.Errorf("GetVerifiedRawBlock: %w", err)
+	}
+	if err := block.VerifyReceiptsRoot(receipts); err != nil {
+		return nil, nil, fmt.Errorf("GetVerifiedRawBlock: %w", err)
+	}
+	return block, receipts, nil
+}
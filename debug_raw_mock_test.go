@@ -0,0 +1,164 @@
+package evmc
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rawBlockFixture는 서명된 트랜잭션 2개(legacy, dynamic fee)와 영수증으로 블록을 만든다.
+func rawBlockFixture(t *testing.T) (*types.Block, types.Receipts, common.Address) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	legacy := types.MustSignNewTx(key, signer, &types.LegacyTx{
+		Nonce: 0, GasPrice: big.NewInt(20), Gas: 21000, To: &to, Value: big.NewInt(1),
+	})
+	create := types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+		ChainID: big.NewInt(1), Nonce: 1, GasTipCap: big.NewInt(2), GasFeeCap: big.NewInt(30),
+		Gas: 100000, Data: []byte{0x60, 0x00},
+	})
+	receipts := types.Receipts{
+		{Type: legacy.Type(), Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 21000, Logs: []*types.Log{}},
+		{Type: create.Type(), Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 71000, Logs: []*types.Log{{
+			Address: to, Topics: []common.Hash{common.HexToHash("0x01")}, Data: []byte{0x02},
+		}}},
+	}
+	for _, r := range receipts {
+		r.Bloom = types.CreateBloom(r)
+	}
+	header := &types.Header{
+		Number: big.NewInt(100), GasLimit: 30_000_000, GasUsed: 71000, Time: 1700000000,
+		BaseFee: big.NewInt(10), Difficulty: big.NewInt(0),
+	}
+	block := types.NewBlock(header, &types.Body{Transactions: types.Transactions{legacy, create}}, receipts, trie.NewStackTrie(nil))
+	return block, receipts, from
+}
+
+func encodeRawReceipts(t *testing.T, receipts types.Receipts) []string {
+	t.Helper()
+	raw := make([]string, len(receipts))
+	for i, r := range receipts {
+		b, err := r.MarshalBinary()
+		require.NoError(t, err)
+		raw[i] = hexutil.Encode(b)
+	}
+	return raw
+}
+
+func Test_debugNamespace_mock_GetDecodedBlock(t *testing.T) {
+	block, receipts, from := rawBlockFixture(t)
+	rawBlock, err := rlp.EncodeToBytes(block)
+	require.NoError(t, err)
+
+	mock := newMockRPCServer(t)
+	mock.on("debug_getRawBlock", func(params json.RawMessage) any { return hexutil.Encode(rawBlock) })
+	mock.on("debug_getRawReceipts", func(params json.RawMessage) any { return encodeRawReceipts(t, receipts) })
+	client := testEvmc(mock.url())
+
+	decoded, err := client.Debug().GetDecodedBlock(evmctypes.FormatNumber(100))
+	require.NoError(t, err)
+	assert.Equal(t, block.Hash(), decoded.Hash())
+	assert.Equal(t, []common.Address{from, from}, decoded.Senders)
+	require.NoError(t, decoded.VerifyTransactionsRoot())
+
+	converted, err := decoded.ToBlockIncTx()
+	require.NoError(t, err)
+	assert.Equal(t, block.Hash().Hex(), converted.Hash)
+	assert.Equal(t, uint64(100), converted.Number)
+	require.Len(t, converted.Transactions, 2)
+	assert.Equal(t, from.Hex(), common.HexToAddress(converted.Transactions[1].From).Hex())
+	// dynamic fee: min(30, 10+2)
+	assert.Equal(t, "0xc", converted.Transactions[1].GasPrice)
+
+	hashes, err := decoded.ToBlock()
+	require.NoError(t, err)
+	assert.Equal(t, []string{block.Transactions()[0].Hash().Hex(), block.Transactions()[1].Hash().Hex()}, hashes.Transactions)
+
+	gotReceipts, err := client.Debug().GetDecodedReceipts(evmctypes.FormatNumber(100))
+	require.NoError(t, err)
+	require.NoError(t, decoded.VerifyReceiptsRoot(gotReceipts))
+
+	converted2, err := decoded.ToReceipts(gotReceipts)
+	require.NoError(t, err)
+	require.Len(t, converted2, 2)
+	assert.Equal(t, "0x5208", converted2[0].GasUsed)
+	assert.Equal(t, "0xc350", converted2[1].GasUsed)
+	require.NotNil(t, converted2[1].ContractAddress)
+	assert.Equal(t, crypto.CreateAddress(from, 1), common.HexToAddress(*converted2[1].ContractAddress))
+	require.Len(t, converted2[1].Logs, 1)
+	assert.Equal(t, uint64(1), converted2[1].Logs[0].TransactionIndex)
+
+	// 영수증 하나를 변조하면 receiptsRoot 검증이 실패한다.
+	gotReceipts[0].CumulativeGasUsed++
+	assert.ErrorIs(t, decoded.VerifyReceiptsRoot(gotReceipts), ErrRootMismatch)
+}
+
+func Test_debugNamespace_mock_GetVerifiedRawBlock(t *testing.T) {
+	block, receipts, _ := rawBlockFixture(t)
+	rawBlock, err := rlp.EncodeToBytes(block)
+	require.NoError(t, err)
+
+	mock := newMockRPCServer(t)
+	mock.on("debug_getRawBlock", func(params json.RawMessage) any { return hexutil.Encode(rawBlock) })
+	mock.on("debug_getRawReceipts", func(params json.RawMessage) any { return encodeRawReceipts(t, receipts) })
+	client := testEvmc(mock.url())
+
+	decoded, gotReceipts, err := client.Debug().GetVerifiedRawBlock(evmctypes.FormatNumber(100))
+	require.NoError(t, err)
+	assert.Equal(t, block.Hash(), decoded.Hash())
+	assert.Len(t, gotReceipts, 2)
+
+	// 트랜잭션 목록이 헤더와 맞지 않는 블록
+	tampered := types.NewBlockWithHeader(block.Header()).WithBody(types.Body{Transactions: block.Transactions()[:1]})
+	rawTampered, err := rlp.EncodeToBytes(tampered)
+	require.NoError(t, err)
+	mock.on("debug_getRawBlock", func(params json.RawMessage) any { return hexutil.Encode(rawTampered) })
+	_, _, err = client.Debug().GetVerifiedRawBlock(evmctypes.FormatNumber(100))
+	assert.ErrorIs(t, err, ErrRootMismatch)
+}
+
+func Test_debugNamespace_mock_GetDecodedTransactionAndHeader(t *testing.T) {
+	block, _, from := rawBlockFixture(t)
+	rawTx, err := block.Transactions()[1].MarshalBinary()
+	require.NoError(t, err)
+	rawHeader, err := rlp.EncodeToBytes(block.Header())
+	require.NoError(t, err)
+
+	mock := newMockRPCServer(t)
+	mock.on("debug_getRawTransaction", func(params json.RawMessage) any { return hexutil.Encode(rawTx) })
+	mock.on("debug_getRawHeader", func(params json.RawMessage) any { return hexutil.Encode(rawHeader) })
+	client := testEvmc(mock.url())
+
+	tx, err := client.Debug().GetDecodedTransaction(block.Transactions()[1].Hash().Hex())
+	require.NoError(t, err)
+	assert.Equal(t, from, tx.From)
+	converted, err := tx.ToTransaction()
+	require.NoError(t, err)
+	assert.Equal(t, tx.Hash().Hex(), converted.Hash)
+	assert.Equal(t, "0x1", converted.Nonce)
+
+	header, err := client.Debug().GetDecodedHeader(evmctypes.FormatNumber(100))
+	require.NoError(t, err)
+	assert.Equal(t, block.Hash(), header.Hash())
+	convertedHeader, err := ConvertHeader(header)
+	require.NoError(t, err)
+	assert.Equal(t, block.Hash().Hex(), convertedHeader.Hash)
+
+	_, err = DecodeRawTransaction("0xzz")
+	assert.Error(t, err)
+}
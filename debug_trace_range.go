@@ -0,0 +1,496 @@
+package evmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	defaultTraceRangeWorkers      = 4
+	defaultTraceRangeRetries      = 3
+	defaultTraceRangeRetryBackoff = 500 * time.Millisecond
+)
+
+// TraceRangeConfig configures the TraceRange* methods of [debugNamespace].
+type TraceRangeConfig struct {
+	// Workers is the maximum number of blocks traced concurrently. The actual
+	// concurrency is halved whenever a block times out and grows back by one
+	// after a run of successes. Default: 4.
+	Workers int
+	// Timeout is the tracer timeout of each debug_trace* request. A block that
+	// hits it is traced again transaction by transaction.
+	Timeout time.Duration
+	// Reexec is passed through to the tracer, see [TraceConfig].
+	Reexec *uint64
+	// Retries is the number of extra attempts for a failed block or
+	// transaction trace. Default: 3; a negative value disables retries.
+	Retries int
+	// RetryBackoff is the delay before the first retry; it doubles on each
+	// following attempt. Default: 500ms.
+	RetryBackoff time.Duration
+	// Checkpoint, when set, records the last block handed to the callback and
+	// lets a later run resume after it.
+	Checkpoint TraceCheckpoint
+}
+
+func (c *TraceRangeConfig) withDefaults() *TraceRangeConfig {
+	cfg := TraceRangeConfig{}
+	if c != nil {
+		cfg = *c
+	}
+	if cfg.Workers < 1 {
+		cfg.Workers = defaultTraceRangeWorkers
+	}
+	if cfg.Retries < 0 {
+		cfg.Retries = 0
+	} else if c == nil || c.Retries == 0 {
+		cfg.Retries = defaultTraceRangeRetries
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = defaultTraceRangeRetryBackoff
+	}
+	return &cfg
+}
+
+// BlockTraces is the trace of a single block delivered by the TraceRange*
+// methods.
+type BlockTraces[T any] struct {
+	BlockNumber uint64
+	Traces      []T
+	// PerTransaction reports that the block-level trace timed out or failed and
+	// the block was traced transaction by transaction instead.
+	PerTransaction bool
+}
+
+// TraceCheckpoint persists the progress of a range trace.
+type TraceCheckpoint = Checkpoint
+
+// ─── TraceRange ──────────────────────────────────────────────────────────────
+
+// TraceRangeCallTracer traces every block in [from, to] with the callTracer and
+// calls fn once per block in ascending block order. Returning an error from fn
+// stops the range.
+func (d *debugNamespace) TraceRangeCallTracer(
+	from, to uint64,
+	rangeCfg *TraceRangeConfig,
+	cfg *CallTracerConfig,
+	fn func(*BlockTraces[*evmctypes.CallTracer]) error,
+) error {
+	return d.TraceRangeWithContextCallTracer(context.Background(), from, to, rangeCfg, cfg, fn)
+}
+
+// TraceRangeWithContextCallTracer is the context-aware variant of [debugNamespace.TraceRangeCallTracer].
+func (d *debugNamespace) TraceRangeWithContextCallTracer(
+	ctx context.Context,
+	from, to uint64,
+	rangeCfg *TraceRangeConfig,
+	cfg *CallTracerConfig,
+	fn func(*BlockTraces[*evmctypes.CallTracer]) error,
+) error {
+	rangeCfg = rangeCfg.withDefaults()
+	traceCfg := newTracerConfig(CallTracer, rangeCfg.Timeout, rangeCfg.Reexec, cfg)
+	return traceRange(ctx, d, from, to, rangeCfg, &rangeTracer[*evmctypes.CallTracer]{
+		traceBlock: func(ctx context.Context, number uint64) ([]*evmctypes.CallTracer, error) {
+			callTracers := []*evmctypes.CallTracer{}
+			if err := d.traceBlockByNumber(ctx, number, traceCfg, &callTracers); err != nil {
+				return nil, err
+			}
+			for _, callTracer := range callTracers {
+				assignIndexCalls(callTracer.Result)
+			}
+			return callTracers, nil
+		},
+		traceTx: func(ctx context.Context, hash string) (*evmctypes.CallTracer, error) {
+			callFrame := &evmctypes.CallFrame{}
+			if err := d.traceTransaction(ctx, hash, traceCfg, callFrame); err != nil {
+				return nil, err
+			}
+			assignIndexCalls(callFrame)
+			callTracer := &evmctypes.CallTracer{Result: callFrame}
+			callTracer.TxHash = hash
+			return callTracer, nil
+		},
+		traceErr: func(t *evmctypes.CallTracer) any { return t.Error },
+	}, fn)
+}
+
+// TraceRangeFlatCallTracer traces every block in [from, to] with the
+// flatCallTracer (go-ethereum only). See [debugNamespace.TraceRangeCallTracer].
+func (d *debugNamespace) TraceRangeFlatCallTracer(
+	from, to uint64,
+	rangeCfg *TraceRangeConfig,
+	cfg *FlatCallTracerConfig,
+	fn func(*BlockTraces[*evmctypes.FlatCallTracer]) error,
+) error {
+	return d.TraceRangeWithContextFlatCallTracer(context.Background(), from, to, rangeCfg, cfg, fn)
+}
+
+// TraceRangeWithContextFlatCallTracer is the context-aware variant of [debugNamespace.TraceRangeFlatCallTracer].
+func (d *debugNamespace) TraceRangeWithContextFlatCallTracer(
+	ctx context.Context,
+	from, to uint64,
+	rangeCfg *TraceRangeConfig,
+	cfg *FlatCallTracerConfig,
+	fn func(*BlockTraces[*evmctypes.FlatCallTracer]) error,
+) error {
+	rangeCfg = rangeCfg.withDefaults()
+	traceCfg := newTracerConfig(FlatCallTracer, rangeCfg.Timeout, rangeCfg.Reexec, cfg)
+	return traceRange(ctx, d, from, to, rangeCfg, &rangeTracer[*evmctypes.FlatCallTracer]{
+		traceBlock: func(ctx context.Context, number uint64) ([]*evmctypes.FlatCallTracer, error) {
+			flatCallTracers := []*evmctypes.FlatCallTracer{}
+			if err := d.traceBlockByNumber(ctx, number, traceCfg, &flatCallTracers); err != nil {
+				return nil, err
+			}
+			assignIndexFlatCalls(flatCallTracers)
+			return flatCallTracers, nil
+		},
+		traceTx: func(ctx context.Context, hash string) (*evmctypes.FlatCallTracer, error) {
+			flatCallFrames := []*evmctypes.FlatCallFrame{}
+			if err := d.traceTransaction(ctx, hash, traceCfg, &flatCallFrames); err != nil {
+				return nil, err
+			}
+			flatCallTracer := &evmctypes.FlatCallTracer{Result: flatCallFrames}
+			flatCallTracer.TxHash = hash
+			assignIndexFlatCalls([]*evmctypes.FlatCallTracer{flatCallTracer})
+			return flatCallTracer, nil
+		},
+		traceErr: func(t *evmctypes.FlatCallTracer) any { return t.Error },
+	}, fn)
+}
+
+// TraceRangePrestateTracer traces every block in [from, to] with the
+// prestateTracer. See [debugNamespace.TraceRangeCallTracer].
+func (d *debugNamespace) TraceRangePrestateTracer(
+	from, to uint64,
+	rangeCfg *TraceRangeConfig,
+	cfg *PrestateTracerConfig,
+	fn func(*BlockTraces[*evmctypes.PrestateTracer]) error,
+) error {
+	return d.TraceRangeWithContextPrestateTracer(context.Background(), from, to, rangeCfg, cfg, fn)
+}
+
+// TraceRangeWithContextPrestateTracer is the context-aware variant of [debugNamespace.TraceRangePrestateTracer].
+func (d *debugNamespace) TraceRangeWithContextPrestateTracer(
+	ctx context.Context,
+	from, to uint64,
+	rangeCfg *TraceRangeConfig,
+	cfg *PrestateTracerConfig,
+	fn func(*BlockTraces[*evmctypes.PrestateTracer]) error,
+) error {
+	rangeCfg = rangeCfg.withDefaults()
+	traceCfg := newTracerConfig(PrestateTracer, rangeCfg.Timeout, rangeCfg.Reexec, cfg)
+	return traceRange(ctx, d, from, to, rangeCfg, &rangeTracer[*evmctypes.PrestateTracer]{
+		traceBlock: func(ctx context.Context, number uint64) ([]*evmctypes.PrestateTracer, error) {
+			prestateTracers := []*evmctypes.PrestateTracer{}
+			if err := d.traceBlockByNumber(ctx, number, traceCfg, &prestateTracers); err != nil {
+				return nil, err
+			}
+			return prestateTracers, nil
+		},
+		traceTx: func(ctx context.Context, hash string) (*evmctypes.PrestateTracer, error) {
+			var result json.RawMessage
+			if err := d.traceTransaction(ctx, hash, traceCfg, &result); err != nil {
+				return nil, err
+			}
+			prestateTracer := &evmctypes.PrestateTracer{Result: result}
+			prestateTracer.TxHash = hash
+			return prestateTracer, nil
+		},
+		traceErr: func(t *evmctypes.PrestateTracer) any { return t.Error },
+	}, fn)
+}
+
+// rangeTracer adapts a typed tracer to traceRange.
+type rangeTracer[T any] struct {
+	traceBlock func(ctx context.Context, number uint64) ([]T, error)
+	traceTx    func(ctx context.Context, hash string) (T, error)
+	// traceErr returns the per-transaction error of a block-level result.
+	traceErr func(T) any
+}
+
+func traceRange[T any](
+	ctx context.Context,
+	d *debugNamespace,
+	from, to uint64,
+	cfg *TraceRangeConfig,
+	tracer *rangeTracer[T],
+	fn func(*BlockTraces[T]) error,
+) error {
+	if from > to {
+		return ErrInvalidRange
+	}
+	if cfg.Checkpoint != nil {
+		last, ok, err := cfg.Checkpoint.Load(ctx)
+		if err != nil {
+			return fmt.Errorf("TraceRange: load checkpoint: %w", err)
+		}
+		if ok && last >= from {
+			if last >= to {
+				return nil
+			}
+			from = last + 1
+		}
+	}
+
+	var (
+		g, gctx = errgroup.WithContext(ctx)
+		limiter = newAdaptiveLimiter(cfg.Workers)
+		// window bounds how far workers may run ahead of the callback, so a
+		// ring of that many result slots is enough: a slot is reused only
+		// after the callback has taken its previous result.
+		window  = make(chan struct{}, cfg.Workers*2)
+		results = make([]chan *BlockTraces[T], cap(window))
+	)
+	for i := range results {
+		results[i] = make(chan *BlockTraces[T], 1)
+	}
+	slot := func(number uint64) chan *BlockTraces[T] {
+		return results[(number-from)%uint64(len(results))]
+	}
+
+	g.Go(func() error {
+		for number := from; ; number++ {
+			select {
+			case window <- struct{}{}:
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+			out := slot(number)
+			g.Go(func() error {
+				traces, err := traceRangeBlock(gctx, d, number, cfg, limiter, tracer)
+				if err != nil {
+					return fmt.Errorf("TraceRange: block %d: %w", number, err)
+				}
+				out <- traces
+				return nil
+			})
+			if number == to {
+				return nil
+			}
+		}
+	})
+
+	g.Go(func() error {
+		for number := from; ; number++ {
+			var traces *BlockTraces[T]
+			select {
+			case traces = <-slot(number):
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+			<-window
+			if err := fn(traces); err != nil {
+				return err
+			}
+			if cfg.Checkpoint != nil {
+				if err := cfg.Checkpoint.Save(gctx, traces.BlockNumber); err != nil {
+					return fmt.Errorf("TraceRange: save checkpoint: %w", err)
+				}
+			}
+			if number == to {
+				return nil
+			}
+		}
+	})
+
+	return g.Wait()
+}
+
+// traceRangeBlock traces a block, falling back to per-transaction tracing when
+// the block-level request times out and re-tracing single transactions whose
+// trace timed out inside an otherwise successful block.
+func traceRangeBlock[T any](
+	ctx context.Context,
+	d *debugNamespace,
+	number uint64,
+	cfg *TraceRangeConfig,
+	limiter *adaptiveLimiter,
+	tracer *rangeTracer[T],
+) (*BlockTraces[T], error) {
+	var (
+		result = &BlockTraces[T]{BlockNumber: number}
+		traces []T
+	)
+	blockErr := withTraceRetry(ctx, cfg, limiter, false, func(ctx context.Context) (err error) {
+		traces, err = tracer.traceBlock(ctx, number)
+		return err
+	})
+	if blockErr != nil && !isTraceTimeout(blockErr) {
+		return nil, blockErr
+	}
+
+	var retrace []int
+	if blockErr != nil {
+		result.PerTransaction = true
+	} else {
+		for i, trace := range traces {
+			if isTraceTimeout(tracer.traceErr(trace)) {
+				retrace = append(retrace, i)
+			}
+		}
+		if len(retrace) == 0 {
+			result.Traces = traces
+			return result, nil
+		}
+	}
+
+	var hashes []string
+	err := withTraceRetry(ctx, cfg, limiter, true, func(ctx context.Context) (err error) {
+		hashes, err = d.blockTransactionHashes(ctx, number)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.PerTransaction {
+		traces = make([]T, len(hashes))
+		for i := range hashes {
+			retrace = append(retrace, i)
+		}
+	} else if len(traces) != len(hashes) {
+		return nil, fmt.Errorf("got %d traces for %d transactions", len(traces), len(hashes))
+	}
+	for _, i := range retrace {
+		err := withTraceRetry(ctx, cfg, limiter, true, func(ctx context.Context) (err error) {
+			traces[i], err = tracer.traceTx(ctx, hashes[i])
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("tx %s: %w", hashes[i], err)
+		}
+	}
+	result.Traces = traces
+	return result, nil
+}
+
+func (d *debugNamespace) blockTransactionHashes(ctx context.Context, number uint64) ([]string, error) {
+	block := new(evmctypes.Block)
+	if err := d.c.call(ctx, block, EthGetBlockByNumber, evmctypes.FormatNumber(number), false); err != nil {
+		return nil, err
+	}
+	if block.Hash == "" {
+		return nil, fmt.Errorf("block %d not found", number)
+	}
+	return block.Transactions, nil
+}
+
+// withTraceRetry runs call under limiter and retries failures with
+// exponential backoff. Timeouts are only retried when retryTimeout is set;
+// a block that timed out is better traced per transaction than again.
+func withTraceRetry(
+	ctx context.Context,
+	cfg *TraceRangeConfig,
+	limiter *adaptiveLimiter,
+	retryTimeout bool,
+	call func(ctx context.Context) error,
+) error {
+	backoff := cfg.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		if err := limiter.acquire(ctx); err != nil {
+			return err
+		}
+		err := call(ctx)
+		timedOut := isTraceTimeout(err)
+		limiter.release(timedOut)
+		if err == nil || ctx.Err() != nil || attempt >= cfg.Retries || timedOut && !retryTimeout {
+			return err
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+// isTraceTimeout reports whether v, a request error or the error field of a
+// trace result, is a tracer or transport timeout.
+func isTraceTimeout(v any) bool {
+	var msg string
+	switch v := v.(type) {
+	case nil:
+		return false
+	case error:
+		msg = v.Error()
+	case string:
+		msg = v
+	default:
+		msg = fmt.Sprint(v)
+	}
+	msg = strings.ToLower(msg)
+	return strings.Contains(msg, "timeout") ||
+		strings.Contains(msg, "timed out") ||
+		strings.Contains(msg, "deadline exceeded")
+}
+
+// adaptiveLimiter bounds concurrent trace requests. The limit is halved on a
+// timeout and raised by one after limit consecutive successes, up to max.
+type adaptiveLimiter struct {
+	mu        sync.Mutex
+	cond      *sync.Cond
+	max       int
+	limit     int
+	inFlight  int
+	successes int
+}
+
+func newAdaptiveLimiter(max int) *adaptiveLimiter {
+	l := &adaptiveLimiter{max: max, limit: max}
+	l.cond = sync.NewCond(&l.mu)
+	return l
+}
+
+func (l *adaptiveLimiter) acquire(ctx context.Context) error {
+	stop := context.AfterFunc(ctx, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.cond.Broadcast()
+	})
+	defer stop()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for l.inFlight >= l.limit {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		l.cond.Wait()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	l.inFlight++
+	return nil
+}
+
+func (l *adaptiveLimiter) release(timedOut bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inFlight--
+	if timedOut {
+		l.limit = max(1, l.limit/2)
+		l.successes = 0
+	} else if l.limit < l.max {
+		l.successes++
+		if l.successes >= l.limit {
+			l.limit++
+			l.successes = 0
+		}
+	}
+	l.cond.Broadcast()
+}
+
+// current returns the current concurrency limit.
+func (l *adaptiveLimiter) current() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limit
+}
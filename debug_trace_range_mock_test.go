@@ -0,0 +1,234 @@
+package evmc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockNumberParam은 debug_traceBlockByNumber/eth_getBlockByNumber 요청의 블록 번호를 꺼낸다.
+func blockNumberParam(t *testing.T, params json.RawMessage) uint64 {
+	t.Helper()
+	var args []json.RawMessage
+	require.NoError(t, json.Unmarshal(params, &args))
+	var hexNum string
+	require.NoError(t, json.Unmarshal(args[0], &hexNum))
+	return hexutil.MustDecodeUint64(hexNum)
+}
+
+func Test_debugNamespace_mock_TraceRangeCallTracer_ordered(t *testing.T) {
+	mock := newMockRPCServer(t)
+	mock.on("debug_traceBlockByNumber", func(params json.RawMessage) any {
+		n := blockNumberParam(t, params)
+		// 앞 블록일수록 늦게 응답해 완료 순서를 뒤섞는다.
+		time.Sleep(time.Duration(10-n) * 2 * time.Millisecond)
+		return []map[string]any{callTracerResultJSON(hexutil.EncodeUint64(n))}
+	})
+	client := testEvmc(mock.url())
+
+	checkpoint := NewFileCheckpoint(filepath.Join(t.TempDir(), "trace.json"))
+	rangeCfg := &TraceRangeConfig{Workers: 3, Timeout: time.Second, Checkpoint: checkpoint}
+
+	var got []uint64
+	err := client.Debug().TraceRangeCallTracer(1, 8, rangeCfg, nil, func(b *BlockTraces[*evmctypes.CallTracer]) error {
+		require.Len(t, b.Traces, 1)
+		assert.Equal(t, hexutil.EncodeUint64(b.BlockNumber), b.Traces[0].TxHash)
+		assert.False(t, b.PerTransaction)
+		got = append(got, b.BlockNumber)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5, 6, 7, 8}, got)
+
+	last, ok, err := checkpoint.Load(context.Background())
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(8), last)
+
+	// checkpoint 이후 블록부터 재개한다.
+	got = nil
+	err = client.Debug().TraceRangeCallTracer(1, 10, rangeCfg, nil, func(b *BlockTraces[*evmctypes.CallTracer]) error {
+		got = append(got, b.BlockNumber)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{9, 10}, got)
+}
+
+func Test_debugNamespace_mock_TraceRangeCallTracer_maxUint64(t *testing.T) {
+	mock := newMockRPCServer(t)
+	mock.on("debug_traceBlockByNumber", func(params json.RawMessage) any {
+		n := blockNumberParam(t, params)
+		return []map[string]any{callTracerResultJSON(hexutil.EncodeUint64(n))}
+	})
+	client := testEvmc(mock.url())
+
+	// 결과 슬롯(Workers*2)보다 긴 범위가 uint64 끝에서 멈추는지 확인한다.
+	var (
+		from = uint64(math.MaxUint64 - 4)
+		got  []uint64
+	)
+	err := client.Debug().TraceRangeCallTracer(from, math.MaxUint64, &TraceRangeConfig{Workers: 1}, nil,
+		func(b *BlockTraces[*evmctypes.CallTracer]) error {
+			got = append(got, b.BlockNumber)
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{from, from + 1, from + 2, from + 3, from + 4}, got)
+}
+
+func Test_debugNamespace_mock_TraceRangeCallTracer_blockTimeoutFallback(t *testing.T) {
+	mock := newMockRPCServer(t)
+	mock.on("debug_traceBlockByNumber", func(params json.RawMessage) any {
+		if blockNumberParam(t, params) == 2 {
+			return mockRPCError{code: -32000, message: "execution timeout"}
+		}
+		return []map[string]any{callTracerResultJSON("0xother")}
+	})
+	mock.on("eth_getBlockByNumber", func(params json.RawMessage) any {
+		return blockJSON(hexutil.EncodeUint64(blockNumberParam(t, params)), "0xhash", true)
+	})
+	var txCalls atomic.Int32
+	mock.on("debug_traceTransaction", func(params json.RawMessage) any {
+		txCalls.Add(1)
+		return callTracerResultJSON("")["result"]
+	})
+	client := testEvmc(mock.url())
+
+	blocks := map[uint64]*BlockTraces[*evmctypes.CallTracer]{}
+	err := client.Debug().TraceRangeCallTracer(1, 3, &TraceRangeConfig{Timeout: time.Second}, nil,
+		func(b *BlockTraces[*evmctypes.CallTracer]) error {
+			blocks[b.BlockNumber] = b
+			return nil
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, blocks, 3)
+	assert.False(t, blocks[1].PerTransaction)
+	require.True(t, blocks[2].PerTransaction)
+	require.Len(t, blocks[2].Traces, 2)
+	assert.Equal(t, "0xtx1", blocks[2].Traces[0].TxHash)
+	assert.Equal(t, "0xtx2", blocks[2].Traces[1].TxHash)
+	assert.Equal(t, "CALL", blocks[2].Traces[1].Result.Type)
+	assert.Equal(t, int32(2), txCalls.Load())
+}
+
+func Test_debugNamespace_mock_TraceRangeCallTracer_txTimeoutRetrace(t *testing.T) {
+	mock := newMockRPCServer(t)
+	mock.on("debug_traceBlockByNumber", func(params json.RawMessage) any {
+		return []map[string]any{
+			callTracerResultJSON("0xtx1"),
+			{"txHash": "0xtx2", "error": "execution timeout"},
+		}
+	})
+	mock.on("eth_getBlockByNumber", func(params json.RawMessage) any {
+		return blockJSON("0x1", "0xhash", true)
+	})
+	var traced []string
+	mock.on("debug_traceTransaction", func(params json.RawMessage) any {
+		var args []string
+		json.Unmarshal(params, &args)
+		traced = append(traced, args[0])
+		return callTracerResultJSON("")["result"]
+	})
+	client := testEvmc(mock.url())
+
+	var block *BlockTraces[*evmctypes.CallTracer]
+	err := client.Debug().TraceRangeCallTracer(1, 1, nil, nil, func(b *BlockTraces[*evmctypes.CallTracer]) error {
+		block = b
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0xtx2"}, traced)
+	assert.False(t, block.PerTransaction)
+	require.Len(t, block.Traces, 2)
+	assert.Nil(t, block.Traces[1].Error)
+	require.NotNil(t, block.Traces[1].Result)
+}
+
+func Test_debugNamespace_mock_TraceRangePrestateTracer_retry(t *testing.T) {
+	var attempts atomic.Int32
+	mock := newMockRPCServer(t)
+	mock.on("debug_traceBlockByNumber", func(params json.RawMessage) any {
+		if attempts.Add(1) <= 2 {
+			return mockRPCError{code: -32000, message: "missing trie node"}
+		}
+		return []map[string]any{{"txHash": "0xtx1", "result": map[string]any{}}}
+	})
+	client := testEvmc(mock.url())
+
+	var count int
+	err := client.Debug().TraceRangePrestateTracer(1, 1, &TraceRangeConfig{RetryBackoff: time.Millisecond}, nil,
+		func(b *BlockTraces[*evmctypes.PrestateTracer]) error {
+			count += len(b.Traces)
+			return nil
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, int32(3), attempts.Load())
+
+	attempts.Store(0)
+	err = client.Debug().TraceRangePrestateTracer(1, 1, &TraceRangeConfig{Retries: -1}, nil,
+		func(b *BlockTraces[*evmctypes.PrestateTracer]) error { return nil },
+	)
+	require.ErrorContains(t, err, "missing trie node")
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func Test_debugNamespace_mock_TraceRangeFlatCallTracer_callbackError(t *testing.T) {
+	mock := newMockRPCServer(t)
+	mock.on("debug_traceBlockByNumber", func(params json.RawMessage) any {
+		return []map[string]any{}
+	})
+	client := testEvmc(mock.url())
+
+	stop := errors.New("stop")
+	var count int
+	err := client.Debug().TraceRangeFlatCallTracer(1, 100, &TraceRangeConfig{Workers: 2}, nil,
+		func(b *BlockTraces[*evmctypes.FlatCallTracer]) error {
+			count++
+			if b.BlockNumber == 3 {
+				return stop
+			}
+			return nil
+		},
+	)
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 3, count)
+	assert.ErrorIs(t, client.Debug().TraceRangeFlatCallTracer(2, 1, nil, nil, nil), ErrInvalidRange)
+}
+
+func Test_adaptiveLimiter(t *testing.T) {
+	l := newAdaptiveLimiter(8)
+	require.NoError(t, l.acquire(context.Background()))
+	l.release(true)
+	assert.Equal(t, 4, l.current())
+	require.NoError(t, l.acquire(context.Background()))
+	l.release(true)
+	assert.Equal(t, 2, l.current())
+	for range 2 {
+		require.NoError(t, l.acquire(context.Background()))
+		l.release(false)
+	}
+	assert.Equal(t, 3, l.current())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	for range 3 {
+		require.NoError(t, l.acquire(ctx))
+	}
+	done := make(chan error)
+	go func() { done <- l.acquire(ctx) }()
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
@@ -28,6 +28,7 @@
 //   - [Evmc.Web3] – web3_* utility methods (client version)
 //   - [Evmc.Debug] – debug_* trace methods (traceTransaction, traceBlockByNumber)
 //   - [Evmc.Kaia] – kaia_* methods for the Kaia blockchain
+//   - [Evmc.Storage] – raw storage reads (mappings, arrays, strings, proxy slots)
 //   - [Evmc.Contract] – raw smart contract calls
 //   - [Evmc.ERC20] – ERC-20 token standard methods
 //
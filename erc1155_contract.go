@@ -110,7 +110,7 @@ func (e *erc1155Contract) balanceOf(
 		result = new(string)
 		params = []any{
 			&evmctypes.QueryParams{To: tokenAddress, Data: GenerateERC1155BalanceOf(owner, id)},
-			evmctypes.ParseBlockAndTag(blockAndTag),
+			evmctypes.ToBlockAndTag(blockAndTag),
 		}
 	)
 	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
@@ -154,7 +154,7 @@ func (e *erc1155Contract) balanceOfBatch(
 		result = new(string)
 		params = []any{
 			&evmctypes.QueryParams{To: tokenAddress, Data: data},
-			evmctypes.ParseBlockAndTag(blockAndTag),
+			evmctypes.ToBlockAndTag(blockAndTag),
 		}
 	)
 	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
@@ -193,7 +193,7 @@ func (e *erc1155Contract) isApprovedForAll(
 		result = new(string)
 		params = []any{
 			&evmctypes.QueryParams{To: tokenAddress, Data: GenerateERC1155IsApprovedForAll(owner, operator)},
-			evmctypes.ParseBlockAndTag(blockAndTag),
+			evmctypes.ToBlockAndTag(blockAndTag),
 		}
 	)
 	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
@@ -229,7 +229,7 @@ func (e *erc1155Contract) uri(
 		result = new(string)
 		params = []any{
 			&evmctypes.QueryParams{To: tokenAddress, Data: GenerateERC1155URI(id)},
-			evmctypes.ParseBlockAndTag(blockAndTag),
+			evmctypes.ToBlockAndTag(blockAndTag),
 		}
 	)
 	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
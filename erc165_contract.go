@@ -0,0 +1,148 @@
+package evmc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bbaktaeho/evmc/evmcsoltypes"
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/bbaktaeho/evmc/evmcutils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ERC-165 interface identifiers of the token standards and extensions that
+// [erc165Contract.DetectTokenStandard] knows about.
+const (
+	InterfaceIDERC165             = "0x01ffc9a7"
+	InterfaceIDERC721             = "0x80ac58cd"
+	InterfaceIDERC721Metadata     = "0x5b5e139f"
+	InterfaceIDERC721Enumerable   = "0x780e9d63"
+	InterfaceIDERC1155            = "0xd9b67a26"
+	InterfaceIDERC1155MetadataURI = "0x0e89341c"
+	InterfaceIDERC2981            = "0x2a55205a"
+
+	// interfaceIDInvalid must never be supported by an ERC-165 compliant contract.
+	interfaceIDInvalid = "0xffffffff"
+)
+
+const (
+	erc165SupportsInterfaceSig = "0x01ffc9a7"
+
+	erc165FuncSigSupportsInterface = "supportsInterface(bytes4)"
+)
+
+type erc165Contract struct {
+	c caller
+}
+
+func GenerateERC165SupportsInterface(interfaceID string) string {
+	id, err := hexutil.Decode(interfaceID)
+	if err != nil || len(id) != 4 {
+		return ""
+	}
+	input, _ := evmcutils.GenerateTxInput(
+		erc165FuncSigSupportsInterface,
+		evmcsoltypes.FixedBytes(id),
+	)
+	return input
+}
+
+func (e *erc165Contract) SupportsInterface(
+	contractAddress string,
+	interfaceID string,
+	blockAndTag evmctypes.BlockAndTag,
+) (bool, error) {
+	return e.supportsInterface(context.Background(), contractAddress, interfaceID, blockAndTag)
+}
+
+func (e *erc165Contract) SupportsInterfaceWithContext(
+	ctx context.Context,
+	contractAddress string,
+	interfaceID string,
+	blockAndTag evmctypes.BlockAndTag,
+) (bool, error) {
+	return e.supportsInterface(ctx, contractAddress, interfaceID, blockAndTag)
+}
+
+func (e *erc165Contract) supportsInterface(
+	ctx context.Context,
+	contractAddress string,
+	interfaceID string,
+	blockAndTag evmctypes.BlockAndTag,
+) (bool, error) {
+	data := GenerateERC165SupportsInterface(interfaceID)
+	if data == "" {
+		return false, fmt.Errorf("SupportsInterface: invalid interface id %q", interfaceID)
+	}
+	var (
+		result = new(string)
+		params = []any{
+			&evmctypes.QueryParams{To: contractAddress, Data: data},
+			evmctypes.ToBlockAndTag(blockAndTag),
+		}
+	)
+	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
+		return false, fmt.Errorf("SupportsInterface: %w", err)
+	}
+	return evmcsoltypes.ParseBool(*result)
+}
+
+// SupportsInterfaces queries several interface IDs in a single batch.
+// A probe that reverts or returns malformed data is reported as unsupported.
+func (e *erc165Contract) SupportsInterfaces(
+	contractAddress string,
+	interfaceIDs []string,
+	blockAndTag evmctypes.BlockAndTag,
+) (map[string]bool, error) {
+	return e.supportsInterfaces(context.Background(), contractAddress, interfaceIDs, blockAndTag)
+}
+
+func (e *erc165Contract) SupportsInterfacesWithContext(
+	ctx context.Context,
+	contractAddress string,
+	interfaceIDs []string,
+	blockAndTag evmctypes.BlockAndTag,
+) (map[string]bool, error) {
+	return e.supportsInterfaces(ctx, contractAddress, interfaceIDs, blockAndTag)
+}
+
+func (e *erc165Contract) supportsInterfaces(
+	ctx context.Context,
+	contractAddress string,
+	interfaceIDs []string,
+	blockAndTag evmctypes.BlockAndTag,
+) (map[string]bool, error) {
+	var (
+		size     = len(interfaceIDs)
+		elements = make([]rpc.BatchElem, size)
+		results  = make([]string, size)
+	)
+	for i, id := range interfaceIDs {
+		data := GenerateERC165SupportsInterface(id)
+		if data == "" {
+			return nil, fmt.Errorf("SupportsInterfaces: invalid interface id %q", id)
+		}
+		elements[i] = rpc.BatchElem{
+			Method: EthCall.String(),
+			Args: []any{
+				&evmctypes.QueryParams{To: contractAddress, Data: data},
+				evmctypes.ToBlockAndTag(blockAndTag),
+			},
+			Result: &results[i],
+		}
+	}
+	if err := e.c.BatchCallWithContext(ctx, elements, -1); err != nil {
+		return nil, fmt.Errorf("SupportsInterfaces: %w", err)
+	}
+	supported := make(map[string]bool, size)
+	for i, el := range elements {
+		if el.Error != nil {
+			supported[interfaceIDs[i]] = false
+			continue
+		}
+		ok, err := evmcsoltypes.ParseBool(results[i])
+		supported[interfaceIDs[i]] = err == nil && ok
+	}
+	return supported, nil
+}
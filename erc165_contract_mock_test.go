@@ -0,0 +1,123 @@
+package evmc
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	solTrue  = "0x0000000000000000000000000000000000000000000000000000000000000001"
+	solFalse = "0x0000000000000000000000000000000000000000000000000000000000000000"
+)
+
+// ethCallData는 eth_call 파라미터에서 calldata를 꺼낸다.
+func ethCallData(t *testing.T, params json.RawMessage) string {
+	t.Helper()
+	var args []json.RawMessage
+	require.NoError(t, json.Unmarshal(params, &args))
+	var q struct {
+		Data string `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(args[0], &q))
+	return q.Data
+}
+
+func Test_erc165_mock_SupportsInterface(t *testing.T) {
+	mock := newMockRPCServer(t)
+	mock.on("eth_call", func(params json.RawMessage) any {
+		data := ethCallData(t, params)
+		assert.Equal(t, "0x01ffc9a780ac58cd00000000000000000000000000000000000000000000000000000000", data)
+		return solTrue
+	})
+
+	client := testEvmc(mock.url())
+	ok, err := client.ERC165().SupportsInterface("0xnft", InterfaceIDERC721, evmctypes.Latest)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = client.ERC165().SupportsInterface("0xnft", "0x1234", evmctypes.Latest)
+	assert.Error(t, err)
+}
+
+func Test_erc165_mock_DetectTokenStandard_ERC721(t *testing.T) {
+	mock := newMockRPCServer(t)
+	mock.on("eth_getCode", func(params json.RawMessage) any {
+		return "0x6080604052"
+	})
+	supported := map[string]bool{
+		InterfaceIDERC165:           true,
+		InterfaceIDERC721:           true,
+		InterfaceIDERC721Metadata:   true,
+		InterfaceIDERC721Enumerable: false,
+		InterfaceIDERC2981:          true,
+	}
+	mock.on("eth_call", func(params json.RawMessage) any {
+		data := ethCallData(t, params)
+		if !strings.HasPrefix(data, erc165SupportsInterfaceSig) {
+			return "0x"
+		}
+		if supported[data[:2]+data[10:18]] {
+			return solTrue
+		}
+		return solFalse
+	})
+
+	client := testEvmc(mock.url())
+	tc, err := client.ERC165().DetectTokenStandard("0xnft", evmctypes.Latest)
+	require.NoError(t, err)
+	assert.True(t, tc.IsContract)
+	assert.True(t, tc.SupportsERC165)
+	assert.Equal(t, []TokenStandard{TokenStandardERC721}, tc.Standards)
+	assert.True(t, tc.Has(TokenExtensionMetadata))
+	assert.True(t, tc.Has(TokenExtensionRoyalty))
+	assert.False(t, tc.Has(TokenExtensionEnumerable))
+}
+
+func Test_erc165_mock_DetectTokenStandard_ERC4626Proxy(t *testing.T) {
+	mock := newMockRPCServer(t)
+	// selector가 없는 프록시 바이트코드
+	mock.on("eth_getCode", func(params json.RawMessage) any {
+		return "0x363d3d373d3d3d363d73bebebebebebebebebebebebebebebebebebebebe5af43d82803e903d91602b57fd5bf3"
+	})
+	mock.on("eth_call", func(params json.RawMessage) any {
+		switch ethCallData(t, params) {
+		case selDecimals:
+			return "0x0000000000000000000000000000000000000000000000000000000000000012"
+		case selTotalSupply, selTotalAssets:
+			return "0x00000000000000000000000000000000000000000000000000000000000003e8"
+		case selAsset:
+			return "0x000000000000000000000000a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+		case selName, selSymbol:
+			return "0x0000000000000000000000000000000000000000000000000000000000000020" +
+				"0000000000000000000000000000000000000000000000000000000000000004" +
+				"7661756c74000000000000000000000000000000000000000000000000000000"
+		}
+		return "0x"
+	})
+
+	client := testEvmc(mock.url())
+	tc, err := client.ERC165().DetectTokenStandard("0xvault", evmctypes.Latest)
+	require.NoError(t, err)
+	assert.False(t, tc.SupportsERC165)
+	assert.True(t, tc.Is(TokenStandardERC20))
+	assert.True(t, tc.Is(TokenStandardERC4626))
+	assert.True(t, tc.Has(TokenExtensionMetadata))
+}
+
+func Test_erc165_mock_DetectTokenStandard_EOA(t *testing.T) {
+	mock := newMockRPCServer(t)
+	mock.on("eth_getCode", func(params json.RawMessage) any {
+		return "0x"
+	})
+
+	client := testEvmc(mock.url())
+	tc, err := client.ERC165().DetectTokenStandard("0xeoa", evmctypes.Latest)
+	require.NoError(t, err)
+	assert.False(t, tc.IsContract)
+	assert.Empty(t, tc.Standards)
+}
@@ -79,7 +79,7 @@ func (e *erc20Contract) name(
 		result = new(string)
 		params = []any{
 			&evmctypes.QueryParams{To: tokenAddress, Data: erc20NameSig},
-			evmctypes.ParseBlockAndTag(blockAndTag),
+			evmctypes.ToBlockAndTag(blockAndTag),
 		}
 	)
 	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
@@ -113,7 +113,7 @@ func (e *erc20Contract) symbol(
 		result = new(string)
 		params = []any{
 			evmctypes.QueryParams{To: tokenAddress, Data: erc20SymbolSig},
-			evmctypes.ParseBlockAndTag(blockAndTag),
+			evmctypes.ToBlockAndTag(blockAndTag),
 		}
 	)
 	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
@@ -147,7 +147,7 @@ func (e *erc20Contract) totalSupply(
 		result = new(string)
 		params = []any{
 			evmctypes.QueryParams{To: tokenAddress, Data: erc20TotalSupplySig},
-			evmctypes.ParseBlockAndTag(blockAndTag),
+			evmctypes.ToBlockAndTag(blockAndTag),
 		}
 	)
 	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
@@ -177,7 +177,7 @@ func (e *erc20Contract) decimals(
 		result = new(string)
 		params = []any{
 			evmctypes.QueryParams{To: tokenAddress, Data: erc20DecimalsSig},
-			evmctypes.ParseBlockAndTag(blockAndTag),
+			evmctypes.ToBlockAndTag(blockAndTag),
 		}
 	)
 	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
@@ -375,7 +375,7 @@ func (e *erc20Contract) balanceOf(
 		result = new(string)
 		params = []any{
 			evmctypes.QueryParams{To: tokenAddress, Data: GenerateERC20BalanceOf(owner)},
-			evmctypes.ParseBlockAndTag(blockAndTag),
+			evmctypes.ToBlockAndTag(blockAndTag),
 		}
 	)
 	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
@@ -414,7 +414,7 @@ func (e *erc20Contract) allowance(
 		result = new(string)
 		params = []any{
 			evmctypes.QueryParams{To: tokenAddress, Data: GenerateERC20Allowance(owner, spender)},
-			evmctypes.ParseBlockAndTag(blockAndTag),
+			evmctypes.ToBlockAndTag(blockAndTag),
 		}
 	)
 	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
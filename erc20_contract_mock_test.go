@@ -0,0 +1,44 @@
+package evmc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_erc20_mock_BalanceOf_FormatHash(t *testing.T) {
+	const blockHash = "0x1111111111111111111111111111111111111111111111111111111111111111"
+
+	mock := newMockRPCServer(t)
+	mock.on("eth_call", func(params json.RawMessage) any {
+		var args []json.RawMessage
+		require.NoError(t, json.Unmarshal(params, &args))
+		require.Len(t, args, 2)
+		// 블록 해시는 문자열이 아니라 EIP-1898 객체로 전송되어야 한다.
+		assert.JSONEq(t, `{"blockHash":"`+blockHash+`","requireCanonical":true}`, string(args[1]))
+		return uintWord(1000)
+	})
+
+	client := testEvmc(mock.url())
+	balance, err := client.ERC20().BalanceOf("0xtoken", addrAlice, evmctypes.FormatHash(blockHash, true))
+	require.NoError(t, err)
+	assert.Equal(t, "1000", balance.String())
+}
+
+func Test_erc20_mock_BalanceOf_Number(t *testing.T) {
+	mock := newMockRPCServer(t)
+	mock.on("eth_call", func(params json.RawMessage) any {
+		var args []json.RawMessage
+		require.NoError(t, json.Unmarshal(params, &args))
+		require.Len(t, args, 2)
+		assert.JSONEq(t, `"0x64"`, string(args[1]))
+		return uintWord(1)
+	})
+
+	client := testEvmc(mock.url())
+	_, err := client.ERC20().BalanceOf("0xtoken", addrAlice, evmctypes.FormatNumber(100))
+	require.NoError(t, err)
+}
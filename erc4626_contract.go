@@ -0,0 +1,787 @@
+package evmc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bbaktaeho/evmc/evmcsoltypes"
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/bbaktaeho/evmc/evmcutils"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	erc4626AssetSig       = "0x38d52e0f"
+	erc4626TotalAssetsSig = "0x01e1d114"
+
+	erc4626FuncSigConvertToShares = "convertToShares(uint256)"
+	erc4626FuncSigConvertToAssets = "convertToAssets(uint256)"
+	erc4626FuncSigMaxDeposit      = "maxDeposit(address)"
+	erc4626FuncSigMaxMint         = "maxMint(address)"
+	erc4626FuncSigMaxWithdraw     = "maxWithdraw(address)"
+	erc4626FuncSigMaxRedeem       = "maxRedeem(address)"
+	erc4626FuncSigPreviewDeposit  = "previewDeposit(uint256)"
+	erc4626FuncSigPreviewMint     = "previewMint(uint256)"
+	erc4626FuncSigPreviewWithdraw = "previewWithdraw(uint256)"
+	erc4626FuncSigPreviewRedeem   = "previewRedeem(uint256)"
+	erc4626FuncSigDeposit         = "deposit(uint256,address)"
+	erc4626FuncSigMint            = "mint(uint256,address)"
+	erc4626FuncSigWithdraw        = "withdraw(uint256,address,address)"
+	erc4626FuncSigRedeem          = "redeem(uint256,address,address)"
+)
+
+// erc4626Contract covers the ERC-4626 tokenized vault interface. A vault is also
+// an ERC-20 share token, so balances and transfers of shares go through ERC20().
+type erc4626Contract struct {
+	info clientInfo
+	c    caller
+	ts   transactionSender
+}
+
+// --- Generate helpers ---
+
+func GenerateERC4626ConvertToShares(assets decimal.Decimal) string {
+	input, _ := evmcutils.GenerateTxInput(erc4626FuncSigConvertToShares, evmcsoltypes.Uint256(assets))
+	return input
+}
+
+func GenerateERC4626ConvertToAssets(shares decimal.Decimal) string {
+	input, _ := evmcutils.GenerateTxInput(erc4626FuncSigConvertToAssets, evmcsoltypes.Uint256(shares))
+	return input
+}
+
+func GenerateERC4626MaxDeposit(receiver string) string {
+	input, _ := evmcutils.GenerateTxInput(erc4626FuncSigMaxDeposit, evmcsoltypes.Address(receiver))
+	return input
+}
+
+func GenerateERC4626MaxMint(receiver string) string {
+	input, _ := evmcutils.GenerateTxInput(erc4626FuncSigMaxMint, evmcsoltypes.Address(receiver))
+	return input
+}
+
+func GenerateERC4626MaxWithdraw(owner string) string {
+	input, _ := evmcutils.GenerateTxInput(erc4626FuncSigMaxWithdraw, evmcsoltypes.Address(owner))
+	return input
+}
+
+func GenerateERC4626MaxRedeem(owner string) string {
+	input, _ := evmcutils.GenerateTxInput(erc4626FuncSigMaxRedeem, evmcsoltypes.Address(owner))
+	return input
+}
+
+func GenerateERC4626PreviewDeposit(assets decimal.Decimal) string {
+	input, _ := evmcutils.GenerateTxInput(erc4626FuncSigPreviewDeposit, evmcsoltypes.Uint256(assets))
+	return input
+}
+
+func GenerateERC4626PreviewMint(shares decimal.Decimal) string {
+	input, _ := evmcutils.GenerateTxInput(erc4626FuncSigPreviewMint, evmcsoltypes.Uint256(shares))
+	return input
+}
+
+func GenerateERC4626PreviewWithdraw(assets decimal.Decimal) string {
+	input, _ := evmcutils.GenerateTxInput(erc4626FuncSigPreviewWithdraw, evmcsoltypes.Uint256(assets))
+	return input
+}
+
+func GenerateERC4626PreviewRedeem(shares decimal.Decimal) string {
+	input, _ := evmcutils.GenerateTxInput(erc4626FuncSigPreviewRedeem, evmcsoltypes.Uint256(shares))
+	return input
+}
+
+func GenerateERC4626Deposit(assets decimal.Decimal, receiver string) string {
+	input, _ := evmcutils.GenerateTxInput(
+		erc4626FuncSigDeposit,
+		evmcsoltypes.Uint256(assets),
+		evmcsoltypes.Address(receiver),
+	)
+	return input
+}
+
+func GenerateERC4626Mint(shares decimal.Decimal, receiver string) string {
+	input, _ := evmcutils.GenerateTxInput(
+		erc4626FuncSigMint,
+		evmcsoltypes.Uint256(shares),
+		evmcsoltypes.Address(receiver),
+	)
+	return input
+}
+
+func GenerateERC4626Withdraw(assets decimal.Decimal, receiver string, owner string) string {
+	input, _ := evmcutils.GenerateTxInput(
+		erc4626FuncSigWithdraw,
+		evmcsoltypes.Uint256(assets),
+		evmcsoltypes.Address(receiver),
+		evmcsoltypes.Address(owner),
+	)
+	return input
+}
+
+func GenerateERC4626Redeem(shares decimal.Decimal, receiver string, owner string) string {
+	input, _ := evmcutils.GenerateTxInput(
+		erc4626FuncSigRedeem,
+		evmcsoltypes.Uint256(shares),
+		evmcsoltypes.Address(receiver),
+		evmcsoltypes.Address(owner),
+	)
+	return input
+}
+
+// --- View methods ---
+
+func (e *erc4626Contract) Asset(vaultAddress string, blockAndTag evmctypes.BlockAndTag) (string, error) {
+	return e.asset(context.Background(), vaultAddress, blockAndTag)
+}
+
+func (e *erc4626Contract) AssetWithContext(
+	ctx context.Context,
+	vaultAddress string,
+	blockAndTag evmctypes.BlockAndTag,
+) (string, error) {
+	return e.asset(ctx, vaultAddress, blockAndTag)
+}
+
+func (e *erc4626Contract) asset(
+	ctx context.Context,
+	vaultAddress string,
+	blockAndTag evmctypes.BlockAndTag,
+) (string, error) {
+	var (
+		result = new(string)
+		params = []any{
+			&evmctypes.QueryParams{To: vaultAddress, Data: erc4626AssetSig},
+			evmctypes.ToBlockAndTag(blockAndTag),
+		}
+	)
+	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
+		return "", fmt.Errorf("Asset: %w", err)
+	}
+	return evmcsoltypes.ParseSolAddress(*result)
+}
+
+func (e *erc4626Contract) TotalAssets(vaultAddress string, blockAndTag evmctypes.BlockAndTag) (decimal.Decimal, error) {
+	return e.totalAssets(context.Background(), vaultAddress, blockAndTag)
+}
+
+func (e *erc4626Contract) TotalAssetsWithContext(
+	ctx context.Context,
+	vaultAddress string,
+	blockAndTag evmctypes.BlockAndTag,
+) (decimal.Decimal, error) {
+	return e.totalAssets(ctx, vaultAddress, blockAndTag)
+}
+
+func (e *erc4626Contract) totalAssets(
+	ctx context.Context,
+	vaultAddress string,
+	blockAndTag evmctypes.BlockAndTag,
+) (decimal.Decimal, error) {
+	var (
+		result = new(string)
+		params = []any{
+			&evmctypes.QueryParams{To: vaultAddress, Data: erc4626TotalAssetsSig},
+			evmctypes.ToBlockAndTag(blockAndTag),
+		}
+	)
+	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
+		return decimal.Zero, fmt.Errorf("TotalAssets: %w", err)
+	}
+	return evmcsoltypes.ParseSolUintToDecimal(*result)
+}
+
+func (e *erc4626Contract) ConvertToShares(vaultAddress string, assets decimal.Decimal, blockAndTag evmctypes.BlockAndTag) (decimal.Decimal, error) {
+	return e.convertToShares(context.Background(), vaultAddress, assets, blockAndTag)
+}
+
+func (e *erc4626Contract) ConvertToSharesWithContext(
+	ctx context.Context,
+	vaultAddress string,
+	assets decimal.Decimal,
+	blockAndTag evmctypes.BlockAndTag,
+) (decimal.Decimal, error) {
+	return e.convertToShares(ctx, vaultAddress, assets, blockAndTag)
+}
+
+func (e *erc4626Contract) convertToShares(
+	ctx context.Context,
+	vaultAddress string,
+	assets decimal.Decimal,
+	blockAndTag evmctypes.BlockAndTag,
+) (decimal.Decimal, error) {
+	var (
+		result = new(string)
+		params = []any{
+			&evmctypes.QueryParams{To: vaultAddress, Data: GenerateERC4626ConvertToShares(assets)},
+			evmctypes.ToBlockAndTag(blockAndTag),
+		}
+	)
+	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
+		return decimal.Zero, fmt.Errorf("ConvertToShares: %w", err)
+	}
+	return evmcsoltypes.ParseSolUintToDecimal(*result)
+}
+
+func (e *erc4626Contract) ConvertToAssets(vaultAddress string, shares decimal.Decimal, blockAndTag evmctypes.BlockAndTag) (decimal.Decimal, error) {
+	return e.convertToAssets(context.Background(), vaultAddress, shares, blockAndTag)
+}
+
+func (e *erc4626Contract) ConvertToAssetsWithContext(
+	ctx context.Context,
+	vaultAddress string,
+	shares decimal.Decimal,
+	blockAndTag evmctypes.BlockAndTag,
+) (decimal.Decimal, error) {
+	return e.convertToAssets(ctx, vaultAddress, shares, blockAndTag)
+}
+
+func (e *erc4626Contract) convertToAssets(
+	ctx context.Context,
+	vaultAddress string,
+	shares decimal.Decimal,
+	blockAndTag evmctypes.BlockAndTag,
+) (decimal.Decimal, error) {
+	var (
+		result = new(string)
+		params = []any{
+			&evmctypes.QueryParams{To: vaultAddress, Data: GenerateERC4626ConvertToAssets(shares)},
+			evmctypes.ToBlockAndTag(blockAndTag),
+		}
+	)
+	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
+		return decimal.Zero, fmt.Errorf("ConvertToAssets: %w", err)
+	}
+	return evmcsoltypes.ParseSolUintToDecimal(*result)
+}
+
+func (e *erc4626Contract) MaxDeposit(vaultAddress string, receiver string, blockAndTag evmctypes.BlockAndTag) (decimal.Decimal, error) {
+	return e.maxDeposit(context.Background(), vaultAddress, receiver, blockAndTag)
+}
+
+func (e *erc4626Contract) MaxDepositWithContext(
+	ctx context.Context,
+	vaultAddress string,
+	receiver string,
+	blockAndTag evmctypes.BlockAndTag,
+) (decimal.Decimal, error) {
+	return e.maxDeposit(ctx, vaultAddress, receiver, blockAndTag)
+}
+
+func (e *erc4626Contract) maxDeposit(
+	ctx context.Context,
+	vaultAddress string,
+	receiver string,
+	blockAndTag evmctypes.BlockAndTag,
+) (decimal.Decimal, error) {
+	var (
+		result = new(string)
+		params = []any{
+			&evmctypes.QueryParams{To: vaultAddress, Data: GenerateERC4626MaxDeposit(receiver)},
+			evmctypes.ToBlockAndTag(blockAndTag),
+		}
+	)
+	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
+		return decimal.Zero, fmt.Errorf("MaxDeposit: %w", err)
+	}
+	return evmcsoltypes.ParseSolUintToDecimal(*result)
+}
+
+func (e *erc4626Contract) MaxMint(vaultAddress string, receiver string, blockAndTag evmctypes.BlockAndTag) (decimal.Decimal, error) {
+	return e.maxMint(context.Background(), vaultAddress, receiver, blockAndTag)
+}
+
+func (e *erc4626Contract) MaxMintWithContext(
+	ctx context.Context,
+	vaultAddress string,
+	receiver string,
+	blockAndTag evmctypes.BlockAndTag,
+) (decimal.Decimal, error) {
+	return e.maxMint(ctx, vaultAddress, receiver, blockAndTag)
+}
+
+func (e *erc4626Contract) maxMint(
+	ctx context.Context,
+	vaultAddress string,
+	receiver string,
+	blockAndTag evmctypes.BlockAndTag,
+) (decimal.Decimal, error) {
+	var (
+		result = new(string)
+		params = []any{
+			&evmctypes.QueryParams{To: vaultAddress, Data: GenerateERC4626MaxMint(receiver)},
+			evmctypes.ToBlockAndTag(blockAndTag),
+		}
+	)
+	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
+		return decimal.Zero, fmt.Errorf("MaxMint: %w", err)
+	}
+	return evmcsoltypes.ParseSolUintToDecimal(*result)
+}
+
+func (e *erc4626Contract) MaxWithdraw(vaultAddress string, owner string, blockAndTag evmctypes.BlockAndTag) (decimal.Decimal, error) {
+	return e.maxWithdraw(context.Background(), vaultAddress, owner, blockAndTag)
+}
+
+func (e *erc4626Contract) MaxWithdrawWithContext(
+	ctx context.Context,
+	vaultAddress string,
+	owner string,
+	blockAndTag evmctypes.BlockAndTag,
+) (decimal.Decimal, error) {
+	return e.maxWithdraw(ctx, vaultAddress, owner, blockAndTag)
+}
+
+func (e *erc4626Contract) maxWithdraw(
+	ctx context.Context,
+	vaultAddress string,
+	owner string,
+	blockAndTag evmctypes.BlockAndTag,
+) (decimal.Decimal, error) {
+	var (
+		result = new(string)
+		params = []any{
+			&evmctypes.QueryParams{To: vaultAddress, Data: GenerateERC4626MaxWithdraw(owner)},
+			evmctypes.ToBlockAndTag(blockAndTag),
+		}
+	)
+	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
+		return decimal.Zero, fmt.Errorf("MaxWithdraw: %w", err)
+	}
+	return evmcsoltypes.ParseSolUintToDecimal(*result)
+}
+
+func (e *erc4626Contract) MaxRedeem(vaultAddress string, owner string, blockAndTag evmctypes.BlockAndTag) (decimal.Decimal, error) {
+	return e.maxRedeem(context.Background(), vaultAddress, owner, blockAndTag)
+}
+
+func (e *erc4626Contract) MaxRedeemWithContext(
+	ctx context.Context,
+	vaultAddress string,
+	owner string,
+	blockAndTag evmctypes.BlockAndTag,
+) (decimal.Decimal, error) {
+	return e.maxRedeem(ctx, vaultAddress, owner, blockAndTag)
+}
+
+func (e *erc4626Contract) maxRedeem(
+	ctx context.Context,
+	vaultAddress string,
+	owner string,
+	blockAndTag evmctypes.BlockAndTag,
+) (decimal.Decimal, error) {
+	var (
+		result = new(string)
+		params = []any{
+			&evmctypes.QueryParams{To: vaultAddress, Data: GenerateERC4626MaxRedeem(owner)},
+			evmctypes.ToBlockAndTag(blockAndTag),
+		}
+	)
+	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
+		return decimal.Zero, fmt.Errorf("MaxRedeem: %w", err)
+	}
+	return evmcsoltypes.ParseSolUintToDecimal(*result)
+}
+
+func (e *erc4626Contract) PreviewDeposit(vaultAddress string, assets decimal.Decimal, blockAndTag evmctypes.BlockAndTag) (decimal.Decimal, error) {
+	return e.previewDeposit(context.Background(), vaultAddress, assets, blockAndTag)
+}
+
+func (e *erc4626Contract) PreviewDepositWithContext(
+	ctx context.Context,
+	vaultAddress string,
+	assets decimal.Decimal,
+	blockAndTag evmctypes.BlockAndTag,
+) (decimal.Decimal, error) {
+	return e.previewDeposit(ctx, vaultAddress, assets, blockAndTag)
+}
+
+func (e *erc4626Contract) previewDeposit(
+	ctx context.Context,
+	vaultAddress string,
+	assets decimal.Decimal,
+	blockAndTag evmctypes.BlockAndTag,
+) (decimal.Decimal, error) {
+	var (
+		result = new(string)
+		params = []any{
+			&evmctypes.QueryParams{To: vaultAddress, Data: GenerateERC4626PreviewDeposit(assets)},
+			evmctypes.ToBlockAndTag(blockAndTag),
+		}
+	)
+	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
+		return decimal.Zero, fmt.Errorf("PreviewDeposit: %w", err)
+	}
+	return evmcsoltypes.ParseSolUintToDecimal(*result)
+}
+
+func (e *erc4626Contract) PreviewMint(vaultAddress string, shares decimal.Decimal, blockAndTag evmctypes.BlockAndTag) (decimal.Decimal, error) {
+	return e.previewMint(context.Background(), vaultAddress, shares, blockAndTag)
+}
+
+func (e *erc4626Contract) PreviewMintWithContext(
+	ctx context.Context,
+	vaultAddress string,
+	shares decimal.Decimal,
+	blockAndTag evmctypes.BlockAndTag,
+) (decimal.Decimal, error) {
+	return e.previewMint(ctx, vaultAddress, shares, blockAndTag)
+}
+
+func (e *erc4626Contract) previewMint(
+	ctx context.Context,
+	vaultAddress string,
+	shares decimal.Decimal,
+	blockAndTag evmctypes.BlockAndTag,
+) (decimal.Decimal, error) {
+	var (
+		result = new(string)
+		params = []any{
+			&evmctypes.QueryParams{To: vaultAddress, Data: GenerateERC4626PreviewMint(shares)},
+			evmctypes.ToBlockAndTag(blockAndTag),
+		}
+	)
+	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
+		return decimal.Zero, fmt.Errorf("PreviewMint: %w", err)
+	}
+	return evmcsoltypes.ParseSolUintToDecimal(*result)
+}
+
+func (e *erc4626Contract) PreviewWithdraw(vaultAddress string, assets decimal.Decimal, blockAndTag evmctypes.BlockAndTag) (decimal.Decimal, error) {
+	return e.previewWithdraw(context.Background(), vaultAddress, assets, blockAndTag)
+}
+
+func (e *erc4626Contract) PreviewWithdrawWithContext(
+	ctx context.Context,
+	vaultAddress string,
+	assets decimal.Decimal,
+	blockAndTag evmctypes.BlockAndTag,
+) (decimal.Decimal, error) {
+	return e.previewWithdraw(ctx, vaultAddress, assets, blockAndTag)
+}
+
+func (e *erc4626Contract) previewWithdraw(
+	ctx context.Context,
+	vaultAddress string,
+	assets decimal.Decimal,
+	blockAndTag evmctypes.BlockAndTag,
+) (decimal.Decimal, error) {
+	var (
+		result = new(string)
+		params = []any{
+			&evmctypes.QueryParams{To: vaultAddress, Data: GenerateERC4626PreviewWithdraw(assets)},
+			evmctypes.ToBlockAndTag(blockAndTag),
+		}
+	)
+	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
+		return decimal.Zero, fmt.Errorf("PreviewWithdraw: %w", err)
+	}
+	return evmcsoltypes.ParseSolUintToDecimal(*result)
+}
+
+func (e *erc4626Contract) PreviewRedeem(vaultAddress string, shares decimal.Decimal, blockAndTag evmctypes.BlockAndTag) (decimal.Decimal, error) {
+	return e.previewRedeem(context.Background(), vaultAddress, shares, blockAndTag)
+}
+
+func (e *erc4626Contract) PreviewRedeemWithContext(
+	ctx context.Context,
+	vaultAddress string,
+	shares decimal.Decimal,
+	blockAndTag evmctypes.BlockAndTag,
+) (decimal.Decimal, error) {
+	return e.previewRedeem(ctx, vaultAddress, shares, blockAndTag)
+}
+
+func (e *erc4626Contract) previewRedeem(
+	ctx context.Context,
+	vaultAddress string,
+	shares decimal.Decimal,
+	blockAndTag evmctypes.BlockAndTag,
+) (decimal.Decimal, error) {
+	var (
+		result = new(string)
+		params = []any{
+			&evmctypes.QueryParams{To: vaultAddress, Data: GenerateERC4626PreviewRedeem(shares)},
+			evmctypes.ToBlockAndTag(blockAndTag),
+		}
+	)
+	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
+		return decimal.Zero, fmt.Errorf("PreviewRedeem: %w", err)
+	}
+	return evmcsoltypes.ParseSolUintToDecimal(*result)
+}
+
+// --- Write methods ---
+
+func (e *erc4626Contract) Deposit(
+	tx *Tx,
+	wallet *Wallet,
+	assets decimal.Decimal,
+	receiver string,
+) (string, error) {
+	return e.deposit(context.Background(), tx, wallet, assets, receiver)
+}
+
+func (e *erc4626Contract) DepositWithContext(
+	ctx context.Context,
+	tx *Tx,
+	wallet *Wallet,
+	assets decimal.Decimal,
+	receiver string,
+) (string, error) {
+	return e.deposit(ctx, tx, wallet, assets, receiver)
+}
+
+func (e *erc4626Contract) deposit(
+	ctx context.Context,
+	tx *Tx,
+	wallet *Wallet,
+	assets decimal.Decimal,
+	receiver string,
+) (string, error) {
+	if tx == nil {
+		return "", ErrTxRequired
+	}
+	if wallet == nil {
+		return "", ErrWalletRequired
+	}
+	if err := tx.valid(); err != nil {
+		return "", err
+	}
+	tx.Data = GenerateERC4626Deposit(assets, receiver)
+	sendingTx, err := NewSendingTx(tx)
+	if err != nil {
+		return "", err
+	}
+	chainID, err := e.info.ChainID()
+	if err != nil {
+		return "", err
+	}
+	_, rawTx, err := wallet.SignTx(sendingTx, chainID)
+	if err != nil {
+		return "", err
+	}
+	return e.ts.sendRawTransaction(ctx, rawTx)
+}
+
+func (e *erc4626Contract) Mint(
+	tx *Tx,
+	wallet *Wallet,
+	shares decimal.Decimal,
+	receiver string,
+) (string, error) {
+	return e.mint(context.Background(), tx, wallet, shares, receiver)
+}
+
+func (e *erc4626Contract) MintWithContext(
+	ctx context.Context,
+	tx *Tx,
+	wallet *Wallet,
+	shares decimal.Decimal,
+	receiver string,
+) (string, error) {
+	return e.mint(ctx, tx, wallet, shares, receiver)
+}
+
+func (e *erc4626Contract) mint(
+	ctx context.Context,
+	tx *Tx,
+	wallet *Wallet,
+	shares decimal.Decimal,
+	receiver string,
+) (string, error) {
+	if tx == nil {
+		return "", ErrTxRequired
+	}
+	if wallet == nil {
+		return "", ErrWalletRequired
+	}
+	if err := tx.valid(); err != nil {
+		return "", err
+	}
+	tx.Data = GenerateERC4626Mint(shares, receiver)
+	sendingTx, err := NewSendingTx(tx)
+	if err != nil {
+		return "", err
+	}
+	chainID, err := e.info.ChainID()
+	if err != nil {
+		return "", err
+	}
+	_, rawTx, err := wallet.SignTx(sendingTx, chainID)
+	if err != nil {
+		return "", err
+	}
+	return e.ts.sendRawTransaction(ctx, rawTx)
+}
+
+func (e *erc4626Contract) Withdraw(
+	tx *Tx,
+	wallet *Wallet,
+	assets decimal.Decimal,
+	receiver string,
+	owner string,
+) (string, error) {
+	return e.withdraw(context.Background(), tx, wallet, assets, receiver, owner)
+}
+
+func (e *erc4626Contract) WithdrawWithContext(
+	ctx context.Context,
+	tx *Tx,
+	wallet *Wallet,
+	assets decimal.Decimal,
+	receiver string,
+	owner string,
+) (string, error) {
+	return e.withdraw(ctx, tx, wallet, assets, receiver, owner)
+}
+
+func (e *erc4626Contract) withdraw(
+	ctx context.Context,
+	tx *Tx,
+	wallet *Wallet,
+	assets decimal.Decimal,
+	receiver string,
+	owner string,
+) (string, error) {
+	if tx == nil {
+		return "", ErrTxRequired
+	}
+	if wallet == nil {
+		return "", ErrWalletRequired
+	}
+	if err := tx.valid(); err != nil {
+		return "", err
+	}
+	tx.Data = GenerateERC4626Withdraw(assets, receiver, owner)
+	sendingTx, err := NewSendingTx(tx)
+	if err != nil {
+		return "", err
+	}
+	chainID, err := e.info.ChainID()
+	if err != nil {
+		return "", err
+	}
+	_, rawTx, err := wallet.SignTx(sendingTx, chainID)
+	if err != nil {
+		return "", err
+	}
+	return e.ts.sendRawTransaction(ctx, rawTx)
+}
+
+func (e *erc4626Contract) Redeem(
+	tx *Tx,
+	wallet *Wallet,
+	shares decimal.Decimal,
+	receiver string,
+	owner string,
+) (string, error) {
+	return e.redeem(context.Background(), tx, wallet, shares, receiver, owner)
+}
+
+func (e *erc4626Contract) RedeemWithContext(
+	ctx context.Context,
+	tx *Tx,
+	wallet *Wallet,
+	shares decimal.Decimal,
+	receiver string,
+	owner string,
+) (string, error) {
+	return e.redeem(ctx, tx, wallet, shares, receiver, owner)
+}
+
+func (e *erc4626Contract) redeem(
+	ctx context.Context,
+	tx *Tx,
+	wallet *Wallet,
+	shares decimal.Decimal,
+	receiver string,
+	owner string,
+) (string, error) {
+	if tx == nil {
+		return "", ErrTxRequired
+	}
+	if wallet == nil {
+		return "", ErrWalletRequired
+	}
+	if err := tx.valid(); err != nil {
+		return "", err
+	}
+	tx.Data = GenerateERC4626Redeem(shares, receiver, owner)
+	sendingTx, err := NewSendingTx(tx)
+	if err != nil {
+		return "", err
+	}
+	chainID, err := e.info.ChainID()
+	if err != nil {
+		return "", err
+	}
+	_, rawTx, err := wallet.SignTx(sendingTx, chainID)
+	if err != nil {
+		return "", err
+	}
+	return e.ts.sendRawTransaction(ctx, rawTx)
+}
+
+// --- Events ---
+
+const (
+	// ERC4626DepositTopic is keccak256("Deposit(address,address,uint256,uint256)").
+	ERC4626DepositTopic = "0xdcbc1c05240f31ff3ad067ef1ee35ce4997762752e3a095284754544f4c709d7"
+	// ERC4626WithdrawTopic is keccak256("Withdraw(address,address,address,uint256,uint256)").
+	ERC4626WithdrawTopic = "0xfbde797d201c681b91056529119e0b02407c7bb96a4a2c75c01fc9667232c8db"
+)
+
+// ERC4626DepositEvent is Deposit(address indexed sender, address indexed owner, uint256 assets, uint256 shares).
+type ERC4626DepositEvent struct {
+	Vault  string          `json:"vault"`
+	Sender string          `json:"sender"`
+	Owner  string          `json:"owner"`
+	Assets decimal.Decimal `json:"assets"`
+	Shares decimal.Decimal `json:"shares"`
+}
+
+// ERC4626WithdrawEvent is Withdraw(address indexed sender, address indexed receiver,
+// address indexed owner, uint256 assets, uint256 shares).
+type ERC4626WithdrawEvent struct {
+	Vault    string          `json:"vault"`
+	Sender   string          `json:"sender"`
+	Receiver string          `json:"receiver"`
+	Owner    string          `json:"owner"`
+	Assets   decimal.Decimal `json:"assets"`
+	Shares   decimal.Decimal `json:"shares"`
+}
+
+func DecodeERC4626Deposit(log *evmctypes.Log) (*ERC4626DepositEvent, error) {
+	if !isEvent(log, ERC4626DepositTopic, 3) {
+		return nil, fmt.Errorf("DecodeERC4626Deposit: %w", ErrUnexpectedEvent)
+	}
+	words, err := decodeUintWords(log.Data, 2)
+	if err != nil {
+		return nil, fmt.Errorf("DecodeERC4626Deposit: %w", err)
+	}
+	return &ERC4626DepositEvent{
+		Vault:  log.Address,
+		Sender: topicToAddress(log.Topics[1]),
+		Owner:  topicToAddress(log.Topics[2]),
+		Assets: words[0],
+		Shares: words[1],
+	}, nil
+}
+
+func DecodeERC4626Withdraw(log *evmctypes.Log) (*ERC4626WithdrawEvent, error) {
+	if !isEvent(log, ERC4626WithdrawTopic, 4) {
+		return nil, fmt.Errorf("DecodeERC4626Withdraw: %w", ErrUnexpectedEvent)
+	}
+	words, err := decodeUintWords(log.Data, 2)
+	if err != nil {
+		return nil, fmt.Errorf("DecodeERC4626Withdraw: %w", err)
+	}
+	return &ERC4626WithdrawEvent{
+		Vault:    log.Address,
+		Sender:   topicToAddress(log.Topics[1]),
+		Receiver: topicToAddress(log.Topics[2]),
+		Owner:    topicToAddress(log.Topics[3]),
+		Assets:   words[0],
+		Shares:   words[1],
+	}, nil
+}
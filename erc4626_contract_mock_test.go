@@ -0,0 +1,116 @@
+package evmc
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_erc4626_mock_views(t *testing.T) {
+	mock := newMockRPCServer(t)
+	mock.on("eth_call", func(params json.RawMessage) any {
+		data := ethCallData(t, params)
+		switch {
+		case data == erc4626AssetSig:
+			return "0x000000000000000000000000a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+		case strings.HasPrefix(data, "0xef8b30f7"): // previewDeposit(uint256)
+			assert.Equal(t, "0xef8b30f700000000000000000000000000000000000000000000000000000000000003e8", data)
+			return "0x00000000000000000000000000000000000000000000000000000000000001f4"
+		case strings.HasPrefix(data, "0x402d267d"): // maxDeposit(address)
+			return "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"
+		}
+		return "0x"
+	})
+
+	client := testEvmc(mock.url())
+	asset, err := client.ERC4626().Asset("0xvault", evmctypes.Latest)
+	require.NoError(t, err)
+	assert.Equal(t, "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", asset)
+
+	shares, err := client.ERC4626().PreviewDeposit("0xvault", decimal.NewFromInt(1000), evmctypes.Latest)
+	require.NoError(t, err)
+	assert.Equal(t, "500", shares.String())
+
+	max, err := client.ERC4626().MaxDeposit("0xvault", "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", evmctypes.Latest)
+	require.NoError(t, err)
+	assert.True(t, max.GreaterThan(shares))
+}
+
+func Test_erc4626_mock_Deposit(t *testing.T) {
+	mock := newMockRPCServer(t)
+	mock.on("eth_chainId", func(params json.RawMessage) any {
+		return "0x1"
+	})
+	var sent string
+	mock.on("eth_sendRawTransaction", func(params json.RawMessage) any {
+		var args []string
+		require.NoError(t, json.Unmarshal(params, &args))
+		sent = args[0]
+		return "0xhash"
+	})
+
+	wallet, err := NewWallet("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	require.NoError(t, err)
+
+	client := testEvmc(mock.url())
+	receiver := "0x000000000000000000000000000000000000dEaD"
+	tx := &Tx{To: "0x000000000000000000000000000000000000bEEF", GasLimit: 100000, GasPrice: decimal.NewFromInt(1)}
+	hash, err := client.ERC4626().Deposit(tx, wallet, decimal.NewFromInt(1000), receiver)
+	require.NoError(t, err)
+	assert.Equal(t, "0xhash", hash)
+
+	decoded := new(types.Transaction)
+	require.NoError(t, decoded.UnmarshalBinary(hexutil.MustDecode(sent)))
+	assert.Equal(t, GenerateERC4626Deposit(decimal.NewFromInt(1000), receiver), hexutil.Encode(decoded.Data()))
+	assert.True(t, strings.HasPrefix(hexutil.Encode(decoded.Data()), "0x6e553f65"))
+
+	_, err = client.ERC4626().Deposit(nil, wallet, decimal.NewFromInt(1), receiver)
+	assert.ErrorIs(t, err, ErrTxRequired)
+	_, err = client.ERC4626().Redeem(tx, nil, decimal.NewFromInt(1), receiver, receiver)
+	assert.ErrorIs(t, err, ErrWalletRequired)
+}
+
+func Test_erc4626_DecodeEvents(t *testing.T) {
+	deposit := &evmctypes.Log{
+		Address: "0xvault",
+		Topics: []string{
+			ERC4626DepositTopic,
+			"0x000000000000000000000000000000000000000000000000000000000000beef",
+			"0x000000000000000000000000000000000000000000000000000000000000dead",
+		},
+		Data: "0x00000000000000000000000000000000000000000000000000000000000003e8" +
+			"00000000000000000000000000000000000000000000000000000000000001f4",
+	}
+	d, err := DecodeERC4626Deposit(deposit)
+	require.NoError(t, err)
+	assert.Equal(t, "0x000000000000000000000000000000000000bEEF", d.Sender)
+	assert.Equal(t, "0x000000000000000000000000000000000000dEaD", d.Owner)
+	assert.Equal(t, "1000", d.Assets.String())
+	assert.Equal(t, "500", d.Shares.String())
+
+	_, err = DecodeERC4626Withdraw(deposit)
+	assert.ErrorIs(t, err, ErrUnexpectedEvent)
+
+	withdraw := &evmctypes.Log{
+		Address: "0xvault",
+		Topics: []string{
+			ERC4626WithdrawTopic,
+			"0x000000000000000000000000000000000000000000000000000000000000beef",
+			"0x000000000000000000000000000000000000000000000000000000000000dead",
+			"0x000000000000000000000000000000000000000000000000000000000000beef",
+		},
+		Data: deposit.Data,
+	}
+	w, err := DecodeERC4626Withdraw(withdraw)
+	require.NoError(t, err)
+	assert.Equal(t, "0x000000000000000000000000000000000000dEaD", w.Receiver)
+	assert.Equal(t, "0x000000000000000000000000000000000000bEEF", w.Owner)
+	assert.Equal(t, "500", w.Shares.String())
+}
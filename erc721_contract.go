@@ -142,7 +142,7 @@ func (e *erc721Contract) name(
 		result = new(string)
 		params = []any{
 			&evmctypes.QueryParams{To: tokenAddress, Data: erc721NameSig},
-			evmctypes.ParseBlockAndTag(blockAndTag),
+			evmctypes.ToBlockAndTag(blockAndTag),
 		}
 	)
 	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
@@ -176,7 +176,7 @@ func (e *erc721Contract) symbol(
 		result = new(string)
 		params = []any{
 			&evmctypes.QueryParams{To: tokenAddress, Data: erc721SymbolSig},
-			evmctypes.ParseBlockAndTag(blockAndTag),
+			evmctypes.ToBlockAndTag(blockAndTag),
 		}
 	)
 	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
@@ -212,7 +212,7 @@ func (e *erc721Contract) balanceOf(
 		result = new(string)
 		params = []any{
 			&evmctypes.QueryParams{To: tokenAddress, Data: GenerateERC721BalanceOf(owner)},
-			evmctypes.ParseBlockAndTag(blockAndTag),
+			evmctypes.ToBlockAndTag(blockAndTag),
 		}
 	)
 	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
@@ -244,7 +244,7 @@ func (e *erc721Contract) ownerOf(
 		result = new(string)
 		params = []any{
 			&evmctypes.QueryParams{To: tokenAddress, Data: GenerateERC721OwnerOf(tokenID)},
-			evmctypes.ParseBlockAndTag(blockAndTag),
+			evmctypes.ToBlockAndTag(blockAndTag),
 		}
 	)
 	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
@@ -276,7 +276,7 @@ func (e *erc721Contract) tokenURI(
 		result = new(string)
 		params = []any{
 			&evmctypes.QueryParams{To: tokenAddress, Data: GenerateERC721TokenURI(tokenID)},
-			evmctypes.ParseBlockAndTag(blockAndTag),
+			evmctypes.ToBlockAndTag(blockAndTag),
 		}
 	)
 	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
@@ -308,7 +308,7 @@ func (e *erc721Contract) getApproved(
 		result = new(string)
 		params = []any{
 			&evmctypes.QueryParams{To: tokenAddress, Data: GenerateERC721GetApproved(tokenID)},
-			evmctypes.ParseBlockAndTag(blockAndTag),
+			evmctypes.ToBlockAndTag(blockAndTag),
 		}
 	)
 	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
@@ -347,7 +347,7 @@ func (e *erc721Contract) isApprovedForAll(
 		result = new(string)
 		params = []any{
 			&evmctypes.QueryParams{To: tokenAddress, Data: GenerateERC721IsApprovedForAll(owner, operator)},
-			evmctypes.ParseBlockAndTag(blockAndTag),
+			evmctypes.ToBlockAndTag(blockAndTag),
 		}
 	)
 	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
@@ -379,7 +379,7 @@ func (e *erc721Contract) totalSupply(
 		result = new(string)
 		params = []any{
 			&evmctypes.QueryParams{To: tokenAddress, Data: erc721TotalSupplySig},
-			evmctypes.ParseBlockAndTag(blockAndTag),
+			evmctypes.ToBlockAndTag(blockAndTag),
 		}
 	)
 	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
@@ -412,7 +412,7 @@ func (e *erc721Contract) tokenByIndex(
 		result = new(string)
 		params = []any{
 			&evmctypes.QueryParams{To: tokenAddress, Data: input},
-			evmctypes.ParseBlockAndTag(blockAndTag),
+			evmctypes.ToBlockAndTag(blockAndTag),
 		}
 	)
 	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
@@ -456,7 +456,7 @@ func (e *erc721Contract) tokenOfOwnerByIndex(
 		result = new(string)
 		params = []any{
 			&evmctypes.QueryParams{To: tokenAddress, Data: input},
-			evmctypes.ParseBlockAndTag(blockAndTag),
+			evmctypes.ToBlockAndTag(blockAndTag),
 		}
 	)
 	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
@@ -16,4 +16,19 @@ var (
 	ErrTxRequired                         = errors.New("tx is required")
 	ErrInvalidRange                       = errors.New("invalid range from > to")
 	ErrChainIDLessThanZero                = errors.New("chain id is required")
+	ErrUnexpectedEvent                    = errors.New("unexpected event")
+	ErrUnsupportedURI                     = errors.New("unsupported uri")
+	ErrRootMismatch                       = errors.New("root mismatch")
+	ErrBlockIntegrity                     = errors.New("block integrity check failed")
+	ErrInvalidProof                       = errors.New("invalid merkle proof")
+	ErrStateMismatch                      = errors.New("state does not match proof")
+	ErrUnsupportedKaiaTxType              = errors.New("unsupported kaia transaction type")
+	ErrNotFeeDelegated                    = errors.New("transaction is not fee-delegated")
+	ErrInvalidFeeRatio                    = errors.New("fee ratio must be between 1 and 99")
+	ErrComputationCostExceeded            = errors.New("computation cost limit exceeded")
+	ErrUnknownChain                       = errors.New("chain is not registered")
+	ErrMethodNotSupported                 = errors.New("method not supported by node")
+	ErrReceiptMismatch                    = errors.New("receipts do not match block")
+	ErrTraceMismatch                      = errors.New("traces do not match block")
+	ErrSnapshotTooLarge                   = errors.New("snapshot item count out of bounds")
 )
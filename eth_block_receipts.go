@@ -0,0 +1,370 @@
+package evmc
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// GetBlockReceipts returns the receipts of block number ordered by
+// transaction index. It uses eth_getBlockReceipts, falls back to Bor's
+// eth_getTransactionReceiptsByBlock, and finally to one
+// eth_getTransactionReceipt per transaction of the block. Once capabilities
+// are probed, unsupported methods are skipped instead of tried. Receipts of
+// the fallbacks, and with [WithBlockIntegrityCheck] of every method, are
+// validated against the transactions of the block; the extra state-sync
+// receipt returned by Bor is dropped.
+func (e *ethNamespace) GetBlockReceipts(number uint64) ([]*evmctypes.Receipt, error) {
+	return e.getBlockReceipts(context.Background(), number)
+}
+
+// GetBlockReceiptsWithContext is the context-aware variant of
+// [ethNamespace.GetBlockReceipts].
+func (e *ethNamespace) GetBlockReceiptsWithContext(ctx context.Context, number uint64) ([]*evmctypes.Receipt, error) {
+	return e.getBlockReceipts(ctx, number)
+}
+
+func (e *ethNamespace) getBlockReceipts(ctx context.Context, number uint64) ([]*evmctypes.Receipt, error) {
+	receipts, err := e.getBlockReceiptsRange(ctx, number, number)
+	if err != nil {
+		return nil, err
+	}
+	return receipts[0], nil
+}
+
+// GetBlockReceiptsByTag is [ethNamespace.GetBlockReceipts] for a block
+// identified by tag or hash (see [evmctypes.FormatHash]). The pending block
+// is not supported.
+func (e *ethNamespace) GetBlockReceiptsByTag(blockAndTag evmctypes.BlockAndTag) ([]*evmctypes.Receipt, error) {
+	return e.getBlockReceiptsByTag(context.Background(), blockAndTag)
+}
+
+// GetBlockReceiptsByTagWithContext is the context-aware variant of
+// [ethNamespace.GetBlockReceiptsByTag].
+func (e *ethNamespace) GetBlockReceiptsByTagWithContext(
+	ctx context.Context,
+	blockAndTag evmctypes.BlockAndTag,
+) ([]*evmctypes.Receipt, error) {
+	return e.getBlockReceiptsByTag(ctx, blockAndTag)
+}
+
+func (e *ethNamespace) getBlockReceiptsByTag(ctx context.Context, blockAndTag evmctypes.BlockAndTag) ([]*evmctypes.Receipt, error) {
+	if blockAndTag == evmctypes.Pending {
+		return nil, ErrPendingBlockNotSupported
+	}
+	if number, err := blockAndTag.Uint64(); err == nil {
+		return e.getBlockReceipts(ctx, number)
+	}
+	methods := e.blockReceiptsMethods()
+	if len(methods) > 0 && !e.needsBlocks(methods[0]) {
+		var receipts []*evmctypes.Receipt
+		err := e.c.call(ctx, &receipts, methods[0], blockAndTag)
+		if err == nil {
+			if receipts == nil {
+				return nil, fmt.Errorf("block %s not found", blockAndTag)
+			}
+			sortReceipts(receipts)
+			if err := validateReceiptOrder(receipts); err != nil {
+				return nil, err
+			}
+			return receipts, nil
+		}
+		if methodExists(err) {
+			return nil, err
+		}
+		methods = methods[1:]
+	}
+
+	// Resolve the block first so that the receipts are requested by hash and
+	// validated against the same block even if the tag moves meanwhile.
+	block, err := e.blockByTag(ctx, blockAndTag)
+	if err != nil {
+		return nil, err
+	}
+	byHash := evmctypes.BlockAndTag(block.Hash)
+	if _, _, ok := blockAndTag.BlockHash(); ok {
+		// Keep requireCanonical of an EIP-1898 identifier.
+		byHash = blockAndTag
+	}
+	for _, method := range methods {
+		var receipts []*evmctypes.Receipt
+		err := e.c.call(ctx, &receipts, method, byHash)
+		if err == nil {
+			if receipts == nil {
+				return nil, fmt.Errorf("block %s not found", blockAndTag)
+			}
+			return checkBlockReceipts(block, receipts)
+		}
+		if methodExists(err) {
+			return nil, err
+		}
+	}
+	receipts, err := e.receiptsOfBlocks(ctx, []*evmctypes.Block{block})
+	if err != nil {
+		return nil, err
+	}
+	return receipts[0], nil
+}
+
+// GetBlockReceiptsRange returns the receipts of the blocks from..to
+// (inclusive), one slice per block, using the same fallbacks as
+// [ethNamespace.GetBlockReceipts] in batches.
+func (e *ethNamespace) GetBlockReceiptsRange(from, to uint64) ([][]*evmctypes.Receipt, error) {
+	return e.getBlockReceiptsRange(context.Background(), from, to)
+}
+
+// GetBlockReceiptsRangeWithContext is the context-aware variant of
+// [ethNamespace.GetBlockReceiptsRange].
+func (e *ethNamespace) GetBlockReceiptsRangeWithContext(ctx context.Context, from, to uint64) ([][]*evmctypes.Receipt, error) {
+	return e.getBlockReceiptsRange(ctx, from, to)
+}
+
+func (e *ethNamespace) getBlockReceiptsRange(ctx context.Context, from, to uint64) ([][]*evmctypes.Receipt, error) {
+	if from > to {
+		return nil, ErrInvalidRange
+	}
+	for _, method := range e.blockReceiptsMethods() {
+		receipts, err := e.batchBlockReceipts(ctx, method, from, to)
+		if err == nil {
+			return receipts, nil
+		}
+		if methodExists(err) {
+			return nil, err
+		}
+	}
+	return e.blockReceiptsByTx(ctx, from, to)
+}
+
+// blockReceiptsMethods returns the block-level receipt methods to try, in
+// order. Without probed capabilities both are tried.
+func (e *ethNamespace) blockReceiptsMethods() []Procedure {
+	caps := e.caps.cachedCapabilities()
+	if caps == nil {
+		return []Procedure{EthGetBlockReceipts, EthGetTransactionReceiptsByBlock}
+	}
+	var methods []Procedure
+	if caps.BlockReceipts {
+		methods = append(methods, EthGetBlockReceipts)
+	}
+	if caps.BorBlockReceipts {
+		methods = append(methods, EthGetTransactionReceiptsByBlock)
+	}
+	return methods
+}
+
+// batchBlockReceipts fetches the receipts of the blocks with method. When
+// [ethNamespace.needsBlocks] reports so, the blocks are fetched in the same
+// batch to validate the receipts against them.
+func (e *ethNamespace) batchBlockReceipts(ctx context.Context, method Procedure, from, to uint64) ([][]*evmctypes.Receipt, error) {
+	var (
+		size       = int(to - from + 1)
+		withBlocks = e.needsBlocks(method)
+		blocks     = make([]*evmctypes.Block, size)
+		receipts   = make([][]*evmctypes.Receipt, size)
+		elements   = make([]rpc.BatchElem, 0, size*2)
+	)
+	for i := range size {
+		elements = append(elements, rpc.BatchElem{
+			Method: method.String(),
+			Args:   []any{hexutil.EncodeUint64(from + uint64(i))},
+			Result: &receipts[i],
+		})
+	}
+	if withBlocks {
+		for i := range size {
+			elements = append(elements, rpc.BatchElem{
+				Method: EthGetBlockByNumber.String(),
+				Args:   []any{hexutil.EncodeUint64(from + uint64(i)), false},
+				Result: &blocks[i],
+			})
+		}
+	}
+	if err := e.c.BatchCallWithContext(ctx, elements, -1); err != nil {
+		return nil, err
+	}
+	for _, el := range elements {
+		if el.Error != nil {
+			return nil, el.Error
+		}
+	}
+	for i := range receipts {
+		if receipts[i] == nil {
+			return nil, fmt.Errorf("block %d not found", from+uint64(i))
+		}
+		if !withBlocks {
+			sortReceipts(receipts[i])
+			if err := validateReceiptOrder(receipts[i]); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if blocks[i] == nil || blocks[i].Hash == "" {
+			return nil, fmt.Errorf("block %d not found", from+uint64(i))
+		}
+		checked, err := checkBlockReceipts(blocks[i], receipts[i])
+		if err != nil {
+			return nil, err
+		}
+		receipts[i] = checked
+	}
+	return receipts, nil
+}
+
+// needsBlocks reports whether receipts returned by method have to be checked
+// against their block. eth_getBlockReceipts is only checked with
+// [WithBlockIntegrityCheck] or on Bor, whose state-sync receipt can only be
+// told apart with the block; the fallback methods are always checked.
+func (e *ethNamespace) needsBlocks(method Procedure) bool {
+	if method != EthGetBlockReceipts || e.verifyBlocks {
+		return true
+	}
+	caps := e.caps.cachedCapabilities()
+	return caps != nil && caps.Client == Bor
+}
+
+// blockReceiptsByTx fetches the transaction hashes of the blocks and then
+// the receipt of each transaction.
+func (e *ethNamespace) blockReceiptsByTx(ctx context.Context, from, to uint64) ([][]*evmctypes.Receipt, error) {
+	var (
+		size     = to - from + 1
+		blocks   = make([]*evmctypes.Block, size)
+		elements = make([]rpc.BatchElem, size)
+	)
+	for i := range elements {
+		elements[i] = rpc.BatchElem{
+			Method: EthGetBlockByNumber.String(),
+			Args:   []any{evmctypes.FormatNumber(from + uint64(i)), false},
+			Result: &blocks[i],
+		}
+	}
+	if err := e.c.BatchCallWithContext(ctx, elements, -1); err != nil {
+		return nil, err
+	}
+	for i, el := range elements {
+		if el.Error != nil {
+			return nil, el.Error
+		}
+		if blocks[i] == nil || blocks[i].Hash == "" {
+			return nil, fmt.Errorf("block %d not found", from+uint64(i))
+		}
+	}
+	return e.receiptsOfBlocks(ctx, blocks)
+}
+
+// receiptsOfBlocks fetches every receipt of blocks in one batch and validates
+// the result against the blocks.
+func (e *ethNamespace) receiptsOfBlocks(ctx context.Context, blocks []*evmctypes.Block) ([][]*evmctypes.Receipt, error) {
+	var txElements []rpc.BatchElem
+	for _, block := range blocks {
+		for _, hash := range block.Transactions {
+			txElements = append(txElements, rpc.BatchElem{
+				Method: EthGetReceipt.String(),
+				Args:   []any{hash},
+				Result: new(*evmctypes.Receipt),
+			})
+		}
+	}
+	if err := e.c.BatchCallWithContext(ctx, txElements, -1); err != nil {
+		return nil, err
+	}
+
+	receipts := make([][]*evmctypes.Receipt, len(blocks))
+	for i, block := range blocks {
+		receipts[i] = make([]*evmctypes.Receipt, 0, len(block.Transactions))
+		for _, el := range txElements[:len(block.Transactions)] {
+			if el.Error != nil {
+				return nil, el.Error
+			}
+			receipt := *el.Result.(**evmctypes.Receipt)
+			if receipt == nil {
+				return nil, fmt.Errorf("receipt of transaction %s not found", el.Args[0])
+			}
+			receipts[i] = append(receipts[i], receipt)
+		}
+		txElements = txElements[len(block.Transactions):]
+		sortReceipts(receipts[i])
+		if err := validateBlockReceipts(block, receipts[i]); err != nil {
+			return nil, err
+		}
+	}
+	return receipts, nil
+}
+
+// checkBlockReceipts orders receipts of a block-level receipt method by
+// transaction index, drops the Bor state-sync receipt and validates the rest
+// against block.
+func checkBlockReceipts(block *evmctypes.Block, receipts []*evmctypes.Receipt) ([]*evmctypes.Receipt, error) {
+	sortReceipts(receipts)
+	receipts = dropStateSyncReceipt(block.Transactions, receipts)
+	if err := validateBlockReceipts(block, receipts); err != nil {
+		return nil, err
+	}
+	return receipts, nil
+}
+
+// dropStateSyncReceipt removes the receipt of the Bor state-sync transaction,
+// which Bor returns after the last transaction of a block although the block
+// does not list it. receipts must be ordered by transaction index.
+func dropStateSyncReceipt(txHashes []string, receipts []*evmctypes.Receipt) []*evmctypes.Receipt {
+	if len(receipts) != len(txHashes)+1 {
+		return receipts
+	}
+	last := receipts[len(txHashes)]
+	if last.TransactionIndex != uint64(len(txHashes)) || slices.Contains(txHashes, last.TransactionHash) {
+		return receipts
+	}
+	return receipts[:len(txHashes)]
+}
+
+// validateBlockReceipts checks that receipts, ordered by transaction index,
+// belong to block and cover each of its transactions once.
+func validateBlockReceipts(block *evmctypes.Block, receipts []*evmctypes.Receipt) error {
+	if len(receipts) != len(block.Transactions) {
+		return fmt.Errorf("%w: block %d has %d transactions, got %d receipts",
+			ErrReceiptMismatch, block.Number, len(block.Transactions), len(receipts))
+	}
+	for i, receipt := range receipts {
+		if receipt.BlockHash != block.Hash {
+			return fmt.Errorf("%w: receipt of %s is in block %s, want %s",
+				ErrReceiptMismatch, receipt.TransactionHash, receipt.BlockHash, block.Hash)
+		}
+		if receipt.TransactionIndex != uint64(i) {
+			return fmt.Errorf("%w: block %d is missing the receipt at index %d",
+				ErrReceiptMismatch, block.Number, i)
+		}
+		if !strings.EqualFold(receipt.TransactionHash, block.Transactions[i]) {
+			return fmt.Errorf("%w: receipt at index %d of block %d is for %s, want %s",
+				ErrReceiptMismatch, i, block.Number, receipt.TransactionHash, block.Transactions[i])
+		}
+	}
+	return nil
+}
+
+// validateReceiptOrder checks receipts, ordered by transaction index, that
+// are not compared with their block: they must belong to one block and have
+// consecutive indexes.
+func validateReceiptOrder(receipts []*evmctypes.Receipt) error {
+	for i, receipt := range receipts {
+		if receipt.BlockHash != receipts[0].BlockHash {
+			return fmt.Errorf("%w: receipts of blocks %s and %s returned together",
+				ErrReceiptMismatch, receipts[0].BlockHash, receipt.BlockHash)
+		}
+		if receipt.TransactionIndex != uint64(i) {
+			return fmt.Errorf("%w: block %s is missing the receipt at index %d",
+				ErrReceiptMismatch, receipt.BlockHash, i)
+		}
+	}
+	return nil
+}
+
+func sortReceipts(receipts []*evmctypes.Receipt) {
+	slices.SortStableFunc(receipts, func(a, b *evmctypes.Receipt) int {
+		return cmp.Compare(a.TransactionIndex, b.TransactionIndex)
+	})
+}
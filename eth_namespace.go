@@ -23,6 +23,9 @@ type ethNamespace struct {
 	c    caller
 	s    subscriber
 	ts   transactionSender
+	caps capabilityCache
+
+	verifyBlocks bool
 }
 
 func (e *ethNamespace) GetBlockIncTxRange(from, to uint64) ([]*evmctypes.BlockIncTx, error) {
@@ -67,9 +70,32 @@ func (e *ethNamespace) getBlockIncTxRange(ctx context.Context, from, to uint64)
 			results[i].UncleBlocks = uncleBlocks
 		}
 	}
+	if e.verifyBlocks {
+		if err := e.verifyBlockRange(ctx, results); err != nil {
+			return nil, err
+		}
+	}
 	return results, nil
 }
 
+// verifyBlockRange fetches the receipts of blocks, which must be consecutive,
+// in one batch and runs [VerifyBlock] on each block.
+func (e *ethNamespace) verifyBlockRange(ctx context.Context, blocks []*evmctypes.BlockIncTx) error {
+	if len(blocks) == 0 {
+		return nil
+	}
+	receipts, err := e.getBlockReceiptsRange(ctx, blocks[0].Number, blocks[len(blocks)-1].Number)
+	if err != nil {
+		return err
+	}
+	for i, block := range blocks {
+		if err := VerifyBlock(block, receipts[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (e *ethNamespace) GetBlockRange(from, to uint64) ([]*evmctypes.Block, error) {
 	return e.GetBlockRangeWithContext(context.Background(), from, to)
 }
@@ -115,6 +141,30 @@ func (e *ethNamespace) getBlockRange(ctx context.Context, from, to uint64) ([]*e
 	return blocks, nil
 }
 
+// GetBlockRangeByTag is [ethNamespace.GetBlockRange] with bounds given as
+// tags, numbers or hashes, e.g. from a number up to [evmctypes.Finalized].
+// The bounds are resolved to numbers first; pending is not supported.
+func (e *ethNamespace) GetBlockRangeByTag(from, to evmctypes.BlockAndTag) ([]*evmctypes.Block, error) {
+	return e.GetBlockRangeByTagWithContext(context.Background(), from, to)
+}
+
+// GetBlockRangeByTagWithContext is the context-aware variant of
+// [ethNamespace.GetBlockRangeByTag].
+func (e *ethNamespace) GetBlockRangeByTagWithContext(
+	ctx context.Context,
+	from, to evmctypes.BlockAndTag,
+) ([]*evmctypes.Block, error) {
+	fromNumber, err := e.blockNumberOf(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	toNumber, err := e.blockNumberOf(ctx, to)
+	if err != nil {
+		return nil, err
+	}
+	return e.getBlockRange(ctx, fromNumber, toNumber)
+}
+
 func (e *ethNamespace) SubscribeNewHeads(
 	ctx context.Context,
 	ch chan<- *evmctypes.Header,
@@ -305,8 +355,11 @@ func (e *ethNamespace) SimulateV1WithContext(ctx context.Context, payload *evmct
 }
 
 func (e *ethNamespace) simulateV1(ctx context.Context, payload *evmctypes.SimulatePayload, blockAndTag evmctypes.BlockAndTag) ([]*evmctypes.SimulateBlockResult, error) {
+	if err := requireCapability(e.caps, EthSimulateV1.String(), func(c *Capabilities) bool { return c.SimulateV1 }); err != nil {
+		return nil, err
+	}
 	result := new([]*evmctypes.SimulateBlockResult)
-	if err := e.c.call(ctx, result, EthSimulateV1, payload, blockAndTag.String()); err != nil {
+	if err := e.c.call(ctx, result, EthSimulateV1, payload, blockAndTag); err != nil {
 		return nil, err
 	}
 	return *result, nil
@@ -322,7 +375,7 @@ func (e *ethNamespace) GetProofWithContext(ctx context.Context, address string,
 
 func (e *ethNamespace) getProof(ctx context.Context, address string, storageKeys []string, blockAndTag evmctypes.BlockAndTag) (*evmctypes.AccountProof, error) {
 	result := new(evmctypes.AccountProof)
-	if err := e.c.call(ctx, result, EthGetProof, address, storageKeys, blockAndTag.String()); err != nil {
+	if err := e.c.call(ctx, result, EthGetProof, address, storageKeys, blockAndTag); err != nil {
 		return nil, err
 	}
 	return result, nil
@@ -348,7 +401,7 @@ func (e *ethNamespace) getStorageAt(
 	numOrTag evmctypes.BlockAndTag,
 ) (string, error) {
 	result := new(string)
-	if err := e.c.call(ctx, result, EthGetStorageAt, address, position, numOrTag.String()); err != nil {
+	if err := e.c.call(ctx, result, EthGetStorageAt, address, position, numOrTag); err != nil {
 		return "", err
 	}
 	return *result, nil
@@ -384,7 +437,7 @@ func (e *ethNamespace) getCode(
 	blockAndTag evmctypes.BlockAndTag,
 ) (string, error) {
 	result := new(string)
-	if err := e.c.call(ctx, result, EthGetCode, address, blockAndTag.String()); err != nil {
+	if err := e.c.call(ctx, result, EthGetCode, address, blockAndTag); err != nil {
 		return "", err
 	}
 	return *result, nil
@@ -473,7 +526,7 @@ func (e *ethNamespace) getBlockByNumber(ctx context.Context, result any, number
 	if number == evmctypes.Pending {
 		return ErrPendingBlockNotSupported
 	}
-	return e.c.call(ctx, result, EthGetBlockByNumber, number.String(), incTx)
+	return e.c.call(ctx, result, EthGetBlockByNumber, number, incTx)
 }
 
 func (e *ethNamespace) GetBlockByHash(hash string) (*evmctypes.Block, error) {
@@ -518,6 +571,39 @@ func (e *ethNamespace) getBlockByHash(ctx context.Context, result any, hash stri
 	return e.c.call(ctx, result, EthGetBlockByHash, hash, incTx)
 }
 
+// blockByTag returns the block identified by blockAndTag with transaction
+// hashes only.
+func (e *ethNamespace) blockByTag(ctx context.Context, blockAndTag evmctypes.BlockAndTag) (*evmctypes.Block, error) {
+	var (
+		block = new(evmctypes.Block)
+		err   error
+	)
+	if hash, _, ok := blockAndTag.BlockHash(); ok {
+		err = e.getBlockByHash(ctx, block, hash, false)
+	} else {
+		err = e.getBlockByNumber(ctx, block, blockAndTag, false)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if block.Hash == "" {
+		return nil, fmt.Errorf("block %s not found", blockAndTag)
+	}
+	return block, nil
+}
+
+// blockNumberOf resolves blockAndTag to a block number.
+func (e *ethNamespace) blockNumberOf(ctx context.Context, blockAndTag evmctypes.BlockAndTag) (uint64, error) {
+	if number, err := blockAndTag.Uint64(); err == nil {
+		return number, nil
+	}
+	block, err := e.blockByTag(ctx, blockAndTag)
+	if err != nil {
+		return 0, err
+	}
+	return block.Number, nil
+}
+
 func (e *ethNamespace) getUncleBlocks(ctx context.Context, blockNumber uint64, uncles []string) ([]*evmctypes.Block, error) {
 	var (
 		size        = len(uncles)
@@ -545,6 +631,40 @@ func (e *ethNamespace) getUncleBlocks(ctx context.Context, blockNumber uint64, u
 	return uncleBlocks, nil
 }
 
+// fillUncleBlocks sets UncleBlocks of every block with uncles, fetching all
+// of them in a single batch.
+func (e *ethNamespace) fillUncleBlocks(ctx context.Context, blocks []*evmctypes.BlockIncTx) error {
+	var elements []rpc.BatchElem
+	for _, block := range blocks {
+		if len(block.Uncles) == 0 {
+			continue
+		}
+		block.UncleBlocks = make([]*evmctypes.Block, len(block.Uncles))
+		for i := range block.Uncles {
+			elements = append(elements, rpc.BatchElem{
+				Method: EthGetUncleByBlockNumberAndIndex.String(),
+				Args:   []any{hexutil.EncodeUint64(block.Number), hexutil.EncodeUint64(uint64(i))},
+				Result: &block.UncleBlocks[i],
+			})
+		}
+	}
+	if len(elements) == 0 {
+		return nil
+	}
+	if err := e.c.BatchCallWithContext(ctx, elements, -1); err != nil {
+		return err
+	}
+	for _, elem := range elements {
+		if elem.Error != nil {
+			return elem.Error
+		}
+		if uncle := *elem.Result.(**evmctypes.Block); uncle == nil || uncle.Hash == "" {
+			return fmt.Errorf("%s, %s uncle does not exist", elem.Args[0], elem.Args[1])
+		}
+	}
+	return nil
+}
+
 func (e *ethNamespace) GetTransactionByHash(hash string) (*evmctypes.Transaction, error) {
 	return e.getTransactionByHash(context.Background(), hash)
 }
@@ -619,7 +739,7 @@ func (e *ethNamespace) GetBalanceWithContext(ctx context.Context, address string
 
 func (e *ethNamespace) getBalance(ctx context.Context, address string, blockAndTag evmctypes.BlockAndTag) (decimal.Decimal, error) {
 	result := new(string)
-	if err := e.c.call(ctx, result, EthGetBalance, address, blockAndTag.String()); err != nil {
+	if err := e.c.call(ctx, result, EthGetBalance, address, blockAndTag); err != nil {
 		return decimal.Zero, err
 	}
 	if *result == "" {
@@ -667,9 +787,46 @@ func (e *ethNamespace) getLogsByBlockHash(ctx context.Context, hash string) ([]*
 	return e.getLogs(ctx, filter)
 }
 
+// GetLogsByTag returns the logs of the block identified by tag, number or
+// hash. The pending block is not supported.
+func (e *ethNamespace) GetLogsByTag(blockAndTag evmctypes.BlockAndTag) ([]*evmctypes.Log, error) {
+	return e.getLogsByTag(context.Background(), blockAndTag)
+}
+
+// GetLogsByTagWithContext is the context-aware variant of
+// [ethNamespace.GetLogsByTag].
+func (e *ethNamespace) GetLogsByTagWithContext(ctx context.Context, blockAndTag evmctypes.BlockAndTag) ([]*evmctypes.Log, error) {
+	return e.getLogsByTag(ctx, blockAndTag)
+}
+
+func (e *ethNamespace) getLogsByTag(ctx context.Context, blockAndTag evmctypes.BlockAndTag) ([]*evmctypes.Log, error) {
+	params, err := blockLogsParams(blockAndTag)
+	if err != nil {
+		return nil, err
+	}
+	logs := new([]*evmctypes.Log)
+	if err := e.c.call(ctx, logs, EthGetLogs, params); err != nil {
+		return nil, err
+	}
+	return *logs, nil
+}
+
 // TODO: addresses
 func (e *ethNamespace) getLogs(ctx context.Context, filter *evmctypes.LogFilter) ([]*evmctypes.Log, error) {
 	logs := new([]*evmctypes.Log)
+	params, err := logFilterParams(filter)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.c.call(ctx, logs, EthGetLogs, params); err != nil {
+		return nil, err
+	}
+	return *logs, nil
+}
+
+// logFilterParams converts filter to the filter object of eth_getLogs and
+// kaia_getLogs.
+func logFilterParams(filter *evmctypes.LogFilter) (map[string]any, error) {
 	params := make(map[string]any)
 	if filter.BlockHash != nil {
 		params["blockHash"] = *filter.BlockHash
@@ -685,10 +842,19 @@ func (e *ethNamespace) getLogs(ctx context.Context, filter *evmctypes.LogFilter)
 	if filter.Topics != nil {
 		params["topics"] = filter.Topics
 	}
-	if err := e.c.call(ctx, logs, EthGetLogs, params); err != nil {
-		return nil, err
+	return params, nil
+}
+
+// blockLogsParams returns the filter object of eth_getLogs and kaia_getLogs
+// selecting the logs of a single block.
+func blockLogsParams(blockAndTag evmctypes.BlockAndTag) (map[string]any, error) {
+	if blockAndTag == evmctypes.Pending {
+		return nil, ErrPendingBlockNotSupported
 	}
-	return *logs, nil
+	if hash, _, ok := blockAndTag.BlockHash(); ok {
+		return map[string]any{"blockHash": hash}, nil
+	}
+	return map[string]any{"fromBlock": blockAndTag, "toBlock": blockAndTag}, nil
 }
 
 func (e *ethNamespace) GetTransactionCount(address string, blockAndTag evmctypes.BlockAndTag) (uint64, error) {
@@ -705,28 +871,12 @@ func (e *ethNamespace) GetTransactionCountWithContext(
 
 func (e *ethNamespace) getTransactionCount(ctx context.Context, address string, blockAndTag evmctypes.BlockAndTag) (uint64, error) {
 	result := new(string)
-	if err := e.c.call(ctx, result, EthGetTransactionCount, address, blockAndTag.String()); err != nil {
+	if err := e.c.call(ctx, result, EthGetTransactionCount, address, blockAndTag); err != nil {
 		return 0, err
 	}
 	return hexutil.MustDecodeUint64(*result), nil
 }
 
-func (e *ethNamespace) GetBlockReceipts(number uint64) ([]*evmctypes.Receipt, error) {
-	return e.getBlockReceipts(context.Background(), number)
-}
-
-func (e *ethNamespace) GetBlockReceiptsWithContext(ctx context.Context, number uint64) ([]*evmctypes.Receipt, error) {
-	return e.getBlockReceipts(ctx, number)
-}
-
-func (e *ethNamespace) getBlockReceipts(ctx context.Context, number uint64) ([]*evmctypes.Receipt, error) {
-	result := new([]*evmctypes.Receipt)
-	if err := e.c.call(ctx, result, EthGetBlockReceipts, hexutil.EncodeUint64(number)); err != nil {
-		return nil, err
-	}
-	return *result, nil
-}
-
 func (e *ethNamespace) GasPrice() (decimal.Decimal, error) {
 	return e.gasPrice(context.Background())
 }
@@ -838,14 +988,38 @@ func (e *ethNamespace) sendRawTransaction(ctx context.Context, rawTx string) (st
 }
 
 func (e *ethNamespace) EstimateGas(tx *Tx) (uint64, error) {
-	return e.estimateGas(context.Background(), tx)
+	return e.estimateGas(context.Background(), tx, nil, nil)
 }
 
 func (e *ethNamespace) EstimateGasWithContext(ctx context.Context, tx *Tx) (uint64, error) {
-	return e.estimateGas(ctx, tx)
+	return e.estimateGas(ctx, tx, nil, nil)
+}
+
+// EstimateGasWithOverrides estimates gas for tx against blockAndTag with the
+// given state and block overrides applied.
+func (e *ethNamespace) EstimateGasWithOverrides(
+	tx *Tx,
+	blockAndTag evmctypes.BlockAndTag,
+	overrides *evmctypes.CallOverrides,
+) (uint64, error) {
+	return e.estimateGas(context.Background(), tx, &blockAndTag, overrides)
 }
 
-func (e *ethNamespace) estimateGas(ctx context.Context, tx *Tx) (uint64, error) {
+func (e *ethNamespace) EstimateGasWithOverridesWithContext(
+	ctx context.Context,
+	tx *Tx,
+	blockAndTag evmctypes.BlockAndTag,
+	overrides *evmctypes.CallOverrides,
+) (uint64, error) {
+	return e.estimateGas(ctx, tx, &blockAndTag, overrides)
+}
+
+func (e *ethNamespace) estimateGas(
+	ctx context.Context,
+	tx *Tx,
+	blockAndTag *evmctypes.BlockAndTag,
+	overrides *evmctypes.CallOverrides,
+) (uint64, error) {
 	result := new(string)
 	msg, err := tx.parseCallMsg()
 	if err != nil {
@@ -855,28 +1029,73 @@ func (e *ethNamespace) estimateGas(ctx context.Context, tx *Tx) (uint64, error)
 	if msg["from"] == "" {
 		return 0, ErrFromRequired
 	}
-	if err := e.c.call(ctx, result, EthEstimateGas, msg); err != nil {
+	params := []any{msg}
+	if blockAndTag != nil {
+		params = append(params, blockAndTag)
+		params = appendCallOverrides(params, overrides)
+	}
+	if err := e.c.call(ctx, result, EthEstimateGas, params...); err != nil {
 		return 0, err
 	}
 	return hexutil.MustDecodeUint64(*result), nil
 }
 
 func (e *ethNamespace) Call(tx *Tx, blockAndTag evmctypes.BlockAndTag) (string, error) {
-	return e.ethCall(context.Background(), tx, blockAndTag)
+	return e.ethCall(context.Background(), tx, blockAndTag, nil)
 }
 
 func (e *ethNamespace) CallWithContext(ctx context.Context, tx *Tx, blockAndTag evmctypes.BlockAndTag) (string, error) {
-	return e.ethCall(ctx, tx, blockAndTag)
+	return e.ethCall(ctx, tx, blockAndTag, nil)
 }
 
-func (e *ethNamespace) ethCall(ctx context.Context, tx *Tx, blockAndTag evmctypes.BlockAndTag) (string, error) {
+// CallWithOverrides executes tx against blockAndTag with the given state and
+// block overrides applied, e.g. to pretend an account holds a token balance
+// or runs different code.
+func (e *ethNamespace) CallWithOverrides(
+	tx *Tx,
+	blockAndTag evmctypes.BlockAndTag,
+	overrides *evmctypes.CallOverrides,
+) (string, error) {
+	return e.ethCall(context.Background(), tx, blockAndTag, overrides)
+}
+
+func (e *ethNamespace) CallWithOverridesWithContext(
+	ctx context.Context,
+	tx *Tx,
+	blockAndTag evmctypes.BlockAndTag,
+	overrides *evmctypes.CallOverrides,
+) (string, error) {
+	return e.ethCall(ctx, tx, blockAndTag, overrides)
+}
+
+func (e *ethNamespace) ethCall(
+	ctx context.Context,
+	tx *Tx,
+	blockAndTag evmctypes.BlockAndTag,
+	overrides *evmctypes.CallOverrides,
+) (string, error) {
 	result := new(string)
 	msg, err := tx.parseCallMsg()
 	if err != nil {
 		return "", err
 	}
-	if err := e.c.call(ctx, result, EthCall, msg, blockAndTag.String()); err != nil {
+	params := appendCallOverrides([]any{msg, blockAndTag}, overrides)
+	if err := e.c.call(ctx, result, EthCall, params...); err != nil {
 		return "", err
 	}
 	return *result, nil
 }
+
+// appendCallOverrides appends the positional state and block override
+// parameters of eth_call and eth_estimateGas. The state override is sent as
+// null when only block overrides are set.
+func appendCallOverrides(params []any, overrides *evmctypes.CallOverrides) []any {
+	if overrides == nil || overrides.StateOverrides == nil && overrides.BlockOverrides == nil {
+		return params
+	}
+	params = append(params, overrides.StateOverrides)
+	if overrides.BlockOverrides != nil {
+		params = append(params, overrides.BlockOverrides)
+	}
+	return params
+}
@@ -2,6 +2,7 @@ package evmc
 
 import (
 	"encoding/json"
+	"sync/atomic"
 	"testing"
 
 	"github.com/bbaktaeho/evmc/evmctypes"
@@ -304,20 +305,115 @@ func Test_ethNamespace_mock_GetLogs(t *testing.T) {
 	assert.Equal(t, uint64(100), logs[0].BlockTimestamp)
 }
 
+// newBlockReceiptsMock은 트랜잭션 0xtx1, 0xtx2를 가진 블록과 receiptsMethod로 그 receipt를 제공한다.
+func newBlockReceiptsMock(t *testing.T, receiptsMethod string, receipts func() []map[string]any) *mockRPCServer {
+	t.Helper()
+	mock := newMockRPCServer(t)
+	mock.on("eth_getBlockByNumber", func(params json.RawMessage) any {
+		var args []any
+		require.NoError(t, json.Unmarshal(params, &args))
+		return blockJSON(args[0].(string), "0xblockhash", true)
+	})
+	mock.on(receiptsMethod, func(json.RawMessage) any { return receipts() })
+	return mock
+}
+
 func Test_ethNamespace_mock_GetBlockReceipts(t *testing.T) {
-	client := testWithMock(t, "eth_getBlockReceipts", func(params json.RawMessage) any {
-		return []map[string]any{
-			receiptJSON("0xtx1", "0x1", "0xblockhash"),
-			receiptJSON("0xtx2", "0x1", "0xblockhash"),
-		}
+	second := receiptJSON("0xtx2", "0x1", "0xblockhash")
+	second["transactionIndex"] = "0x1"
+	receipts := []map[string]any{receiptJSON("0xtx1", "0x1", "0xblockhash"), second}
+	mock := newBlockReceiptsMock(t, "eth_getBlockReceipts", func() []map[string]any { return receipts })
+	var blockCalls atomic.Int32
+	mock.on("eth_getBlockByNumber", func(params json.RawMessage) any {
+		blockCalls.Add(1)
+		var args []any
+		require.NoError(t, json.Unmarshal(params, &args))
+		return blockJSON(args[0].(string), "0xblockhash", true)
 	})
-	receipts, err := client.Eth().GetBlockReceipts(1)
+	client := testEvmc(mock.url())
+
+	got, err := client.Eth().GetBlockReceipts(1)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "0xtx1", got[0].TransactionHash)
+	assert.Equal(t, "0xtx2", got[1].TransactionHash)
+	// eth_getBlockReceipts가 성공하면 블록을 따로 조회하지 않는다.
+	assert.Zero(t, blockCalls.Load())
+
+	// 무결성 검사를 켜면 블록의 트랜잭션과 비교한다.
+	verifying, err := New(mock.url(), WithBlockIntegrityCheck())
+	require.NoError(t, err)
+	_, err = verifying.Eth().GetBlockReceipts(1)
+	require.NoError(t, err)
+	assert.NotZero(t, blockCalls.Load())
+
+	// 개수는 맞지만 다른 트랜잭션의 receipt이면 실패한다.
+	receipts[1] = receiptJSON("0xother", "0x1", "0xblockhash")
+	receipts[1]["transactionIndex"] = "0x1"
+	_, err = verifying.Eth().GetBlockReceipts(1)
+	assert.ErrorIs(t, err, ErrReceiptMismatch)
+
+	// 트랜잭션 수와 다르면 실패한다.
+	receipts = receipts[:1]
+	_, err = verifying.Eth().GetBlockReceipts(1)
+	assert.ErrorIs(t, err, ErrReceiptMismatch)
+}
+
+func Test_ethNamespace_mock_GetBlockReceipts_borFallback(t *testing.T) {
+	// eth_getBlockReceipts가 없으면 Bor 메서드를 사용하고 index 순으로 정렬한다.
+	// 블록에 없는 state-sync receipt는 제외한다.
+	mock := newBlockReceiptsMock(t, "eth_getTransactionReceiptsByBlock", func() []map[string]any {
+		second := receiptJSON("0xtx2", "0x1", "0xblockhash")
+		second["transactionIndex"] = "0x1"
+		stateSync := receiptJSON("0xstatesync", "0x1", "0xblockhash")
+		stateSync["transactionIndex"] = "0x2"
+		return []map[string]any{stateSync, second, receiptJSON("0xtx1", "0x1", "0xblockhash")}
+	})
+	receipts, err := testEvmc(mock.url()).Eth().GetBlockReceipts(1)
 	require.NoError(t, err)
 	require.Len(t, receipts, 2)
 	assert.Equal(t, "0xtx1", receipts[0].TransactionHash)
 	assert.Equal(t, "0xtx2", receipts[1].TransactionHash)
 }
 
+func Test_ethNamespace_mock_GetBlockReceipts_txFallback(t *testing.T) {
+	var blockHash = "0xblockhash"
+	mock := newMockRPCServer(t)
+	mock.on("eth_getBlockByNumber", func(params json.RawMessage) any {
+		var args []any
+		require.NoError(t, json.Unmarshal(params, &args))
+		return blockJSON(args[0].(string), "0xblockhash", true)
+	})
+	mock.on("eth_getTransactionReceipt", func(params json.RawMessage) any {
+		var args []string
+		require.NoError(t, json.Unmarshal(params, &args))
+		receipt := receiptJSON(args[0], "0x1", blockHash)
+		if args[0] == "0xtx2" {
+			receipt["transactionIndex"] = "0x1"
+		}
+		return receipt
+	})
+	client := testEvmc(mock.url())
+
+	// 블록 단위 메서드가 모두 없으면 트랜잭션별 receipt를 batch로 조회한다.
+	receipts, err := client.Eth().GetBlockReceiptsRange(1, 3)
+	require.NoError(t, err)
+	require.Len(t, receipts, 3)
+	for _, block := range receipts {
+		require.Len(t, block, 2)
+		assert.Equal(t, "0xtx1", block[0].TransactionHash)
+		assert.Equal(t, "0xtx2", block[1].TransactionHash)
+	}
+
+	// 조회 중 reorg로 다른 블록의 receipt가 오면 실패한다.
+	blockHash = "0xother"
+	_, err = client.Eth().GetBlockReceipts(1)
+	assert.ErrorIs(t, err, ErrReceiptMismatch)
+
+	_, err = client.Eth().GetBlockReceiptsRange(3, 1)
+	assert.ErrorIs(t, err, ErrInvalidRange)
+}
+
 func Test_ethNamespace_mock_BlockTransactionCountByNumber(t *testing.T) {
 	client := testWithMock(t, "eth_getBlockTransactionCountByNumber", func(params json.RawMessage) any {
 		// 0xa = 10
@@ -381,6 +477,72 @@ func Test_ethNamespace_mock_Call(t *testing.T) {
 	assert.NotEmpty(t, result)
 }
 
+func Test_ethNamespace_mock_CallWithOverrides(t *testing.T) {
+	client := testWithMock(t, "eth_call", func(params json.RawMessage) any {
+		var args []json.RawMessage
+		require.NoError(t, json.Unmarshal(params, &args))
+		require.Len(t, args, 4)
+		assert.JSONEq(t, `"latest"`, string(args[1]))
+		assert.JSONEq(t, `{"0xholder":{"balance":"0x1","stateDiff":{"0x01":"0x02"}}}`, string(args[2]))
+		assert.JSONEq(t, `{"number":"0x64","time":"0x10"}`, string(args[3]))
+		return "0x01"
+	})
+	var (
+		balance   = "0x1"
+		number    = "0x64"
+		timestamp = "0x10"
+	)
+	result, err := client.Eth().CallWithOverrides(&Tx{
+		From: ZeroAddress,
+		To:   "0xcontract",
+		Data: "0x18160ddd",
+	}, evmctypes.Latest, &evmctypes.CallOverrides{
+		StateOverrides: evmctypes.SimulateStateOverride{
+			"0xholder": {Balance: &balance, StateDiff: map[string]string{"0x01": "0x02"}},
+		},
+		BlockOverrides: &evmctypes.SimulateBlockOverride{BlockNumber: &number, Time: &timestamp},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "0x01", result)
+}
+
+func Test_ethNamespace_mock_CallWithOverrides_blockOnly(t *testing.T) {
+	client := testWithMock(t, "eth_call", func(params json.RawMessage) any {
+		var args []json.RawMessage
+		require.NoError(t, json.Unmarshal(params, &args))
+		require.Len(t, args, 4)
+		// state override 자리는 null로 채운다.
+		assert.Equal(t, "null", string(args[2]))
+		return "0x"
+	})
+	number := "0x64"
+	_, err := client.Eth().CallWithOverrides(&Tx{To: "0xcontract", Data: "0x"}, evmctypes.Latest, &evmctypes.CallOverrides{
+		BlockOverrides: &evmctypes.SimulateBlockOverride{BlockNumber: &number},
+	})
+	require.NoError(t, err)
+}
+
+func Test_ethNamespace_mock_EstimateGasWithOverrides(t *testing.T) {
+	client := testWithMock(t, "eth_estimateGas", func(params json.RawMessage) any {
+		var args []json.RawMessage
+		require.NoError(t, json.Unmarshal(params, &args))
+		require.Len(t, args, 3)
+		assert.JSONEq(t, `"pending"`, string(args[1]))
+		assert.JSONEq(t, `{"0xcontract":{"code":"0x00"}}`, string(args[2]))
+		return "0x5208"
+	})
+	code := "0x00"
+	gas, err := client.Eth().EstimateGasWithOverrides(&Tx{
+		From: ZeroAddress,
+		To:   "0xcontract",
+		Data: "0x",
+	}, evmctypes.Pending, &evmctypes.CallOverrides{
+		StateOverrides: evmctypes.SimulateStateOverride{"0xcontract": {Code: &code}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(21000), gas)
+}
+
 func Test_ethNamespace_mock_GetBlockRange(t *testing.T) {
 	mock := newMockRPCServer(t)
 	callCount := 0
@@ -463,6 +625,65 @@ func Test_ethNamespace_mock_GetLogsByBlockHash(t *testing.T) {
 	assert.Equal(t, "0xtesthash", logs[0].BlockHash)
 }
 
+func Test_ethNamespace_mock_ByTag(t *testing.T) {
+	const hash = "0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6"
+	var lastLogFilter map[string]any
+	mock := newMockRPCServer(t)
+	mock.on("eth_getBlockByHash", func(params json.RawMessage) any {
+		var args []any
+		require.NoError(t, json.Unmarshal(params, &args))
+		assert.Equal(t, hash, args[0])
+		block := blockJSON("0x1", hash, true)
+		block["transactions"] = []string{"0xtx1"}
+		return block
+	})
+	mock.on("eth_getBlockReceipts", func(params json.RawMessage) any {
+		var args []map[string]any
+		require.NoError(t, json.Unmarshal(params, &args), "EIP-1898 객체로 전송되어야 한다")
+		assert.Equal(t, hash, args[0]["blockHash"])
+		assert.Equal(t, true, args[0]["requireCanonical"])
+		return []map[string]any{receiptJSON("0xtx1", "0x1", hash)}
+	})
+	mock.on("eth_getLogs", func(params json.RawMessage) any {
+		var args []map[string]any
+		require.NoError(t, json.Unmarshal(params, &args))
+		lastLogFilter = args[0]
+		return []any{}
+	})
+	mock.on("eth_getBlockByNumber", func(params json.RawMessage) any {
+		var args []any
+		require.NoError(t, json.Unmarshal(params, &args))
+		if args[0] == "finalized" {
+			return blockJSON("0x3", "0xfinalized", true)
+		}
+		return blockJSON(args[0].(string), "0xblockhash", true)
+	})
+	client := testEvmc(mock.url())
+
+	receipts, err := client.Eth().GetBlockReceiptsByTag(evmctypes.FormatHash(hash, true))
+	require.NoError(t, err)
+	require.Len(t, receipts, 1)
+	_, err = client.Eth().GetBlockReceiptsByTag(evmctypes.Pending)
+	assert.ErrorIs(t, err, ErrPendingBlockNotSupported)
+
+	_, err = client.Eth().GetLogsByTag(evmctypes.Finalized)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"fromBlock": "finalized", "toBlock": "finalized"}, lastLogFilter)
+	_, err = client.Eth().GetLogsByTag(evmctypes.FormatHash(hash, false))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"blockHash": hash}, lastLogFilter)
+	_, err = client.Eth().GetLogsByTag(evmctypes.Pending)
+	assert.ErrorIs(t, err, ErrPendingBlockNotSupported)
+
+	// finalized는 블록 번호로 변환된다.
+	blocks, err := client.Eth().GetBlockRangeByTag(evmctypes.FormatNumber(1), evmctypes.Finalized)
+	require.NoError(t, err)
+	require.Len(t, blocks, 3)
+	assert.Equal(t, uint64(3), blocks[2].Number)
+	_, err = client.Eth().GetBlockRangeByTag(evmctypes.FormatNumber(1), evmctypes.Pending)
+	assert.ErrorIs(t, err, ErrPendingBlockNotSupported)
+}
+
 func Test_ethNamespace_mock_BlobBaseFee(t *testing.T) {
 	client := testWithMock(t, "eth_blobBaseFee", func(params json.RawMessage) any {
 		// 0x3b9aca00 = 1000000000 (1 Gwei)
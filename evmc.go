@@ -5,7 +5,7 @@ import (
 	"errors"
 	"net/http"
 	"net/url"
-	"strings"
+	"sync/atomic"
 
 	"github.com/bbaktaeho/evmc/evmctypes"
 	"github.com/ethereum/go-ethereum/common/lru"
@@ -33,8 +33,8 @@ type subscriber interface {
 	subscribe(ctx context.Context, namespace string, ch any, args ...any) (evmctypes.Subscription, error)
 }
 
-type nodeSetter interface {
-	setNode(clientVersion string)
+type capabilityCache interface {
+	cachedCapabilities() *Capabilities
 }
 
 type transactionSender interface {
@@ -47,6 +47,8 @@ type transactionSender interface {
 type Evmc struct {
 	c           *rpc.Client
 	isWebsocket bool
+	chainID     atomic.Uint64                // cached by Chain
+	caps        atomic.Pointer[Capabilities] // cached by ProbeCapabilities
 
 	maxBatchItems    int
 	batchCallWorkers int
@@ -58,10 +60,14 @@ type Evmc struct {
 	// ots   *otsNamespace
 	kaia *kaiaNamespace
 
+	storage *storageLayout
+
 	contract *contract
 	erc20    *erc20Contract
+	erc165   *erc165Contract
 	erc721   *erc721Contract
 	erc1155  *erc1155Contract
+	erc4626  *erc4626Contract
 
 	abiCache *lru.Cache[string, any]
 }
@@ -137,14 +143,17 @@ func newClient(ctx context.Context, url string, isWs bool, opts ...Options) (*Ev
 		maxBatchItems:    o.maxBatchItems,
 		batchCallWorkers: o.batchCallWorkers,
 	}
-	evmc.eth = &ethNamespace{info: evmc, c: evmc, s: evmc, ts: evmc}
+	evmc.eth = &ethNamespace{info: evmc, c: evmc, s: evmc, ts: evmc, caps: evmc, verifyBlocks: o.blockIntegrityCheck}
 	evmc.web3 = &web3Namespace{c: evmc}
-	evmc.debug = &debugNamespace{c: evmc}
-	evmc.kaia = &kaiaNamespace{c: evmc}
+	evmc.debug = &debugNamespace{c: evmc, caps: evmc}
+	evmc.kaia = &kaiaNamespace{info: evmc, c: evmc, s: evmc}
+	evmc.storage = &storageLayout{c: evmc}
 	evmc.contract = &contract{c: evmc}
 	evmc.erc20 = &erc20Contract{info: evmc, c: evmc, ts: evmc}
+	evmc.erc165 = &erc165Contract{c: evmc}
 	evmc.erc721 = &erc721Contract{info: evmc, c: evmc, ts: evmc}
 	evmc.erc1155 = &erc1155Contract{info: evmc, c: evmc, ts: evmc}
+	evmc.erc4626 = &erc4626Contract{info: evmc, c: evmc, ts: evmc}
 
 	return evmc, nil
 }
@@ -171,13 +180,11 @@ func (e *Evmc) NodeClient() (name, version string, err error) {
 	if err != nil {
 		return "", "", err
 	}
-	cvarr := strings.Split(cv, "/")
-	if len(cvarr) < 2 {
-		return
+	name, version, ok := splitClientVersion(cv)
+	if !ok {
+		return "", "", nil
 	}
-	name = cvarr[0]
-	version = cvarr[1]
-	return
+	return name, version, nil
 }
 
 // Web3 returns the web3 namespace for utility RPC methods.
@@ -201,13 +208,20 @@ func (e *Evmc) Debug() *debugNamespace {
 	return e.debug
 }
 
+// Storage returns the storage layout reader for decoding raw contract
+// storage (mappings, arrays, strings, packed values and proxy slots).
+func (e *Evmc) Storage() *storageLayout {
+	return e.storage
+}
+
 // Contract returns the contract namespace for raw smart contract calls.
 func (e *Evmc) Contract() *contract {
 	return e.contract
 }
 
-// BatchCallWithContext splits elements into chunks of maxBatchItems and
-// sends them in parallel using up to workers goroutines.
+// BatchCallWithContext splits elements into chunks of maxBatchItems, lowered
+// to the probed batch limit of the node if known, and sends them in parallel
+// using up to workers goroutines.
 // If workers is less than 1, it defaults to batchCallWorkers.
 func (e *Evmc) BatchCallWithContext(ctx context.Context, elements []rpc.BatchElem, workers int) error {
 	if workers < 1 {
@@ -215,8 +229,12 @@ func (e *Evmc) BatchCallWithContext(ctx context.Context, elements []rpc.BatchEle
 	}
 	g, ctx := errgroup.WithContext(ctx)
 	g.SetLimit(workers)
-	for i := 0; i < len(elements); i += e.maxBatchItems {
-		chunk := elements[i:min(i+e.maxBatchItems, len(elements))]
+	size := e.maxBatchItems
+	if caps := e.cachedCapabilities(); caps != nil && caps.MaxBatchItems > 0 {
+		size = min(size, caps.MaxBatchItems)
+	}
+	for i := 0; i < len(elements); i += size {
+		chunk := elements[i:min(i+size, len(elements))]
 		g.Go(func() error {
 			return e.batchCall(ctx, chunk)
 		})
@@ -235,6 +253,12 @@ func (e *Evmc) ERC20() *erc20Contract {
 	return e.erc20
 }
 
+// ERC165 returns the ERC-165 namespace for interface detection and token
+// standard classification.
+func (e *Evmc) ERC165() *erc165Contract {
+	return e.erc165
+}
+
 // ERC721 returns the ERC-721 NFT contract namespace for standard NFT operations.
 func (e *Evmc) ERC721() *erc721Contract {
 	return e.erc721
@@ -245,6 +269,11 @@ func (e *Evmc) ERC1155() *erc1155Contract {
 	return e.erc1155
 }
 
+// ERC4626 returns the ERC-4626 tokenized vault namespace.
+func (e *Evmc) ERC4626() *erc4626Contract {
+	return e.erc4626
+}
+
 func (e *Evmc) call(ctx context.Context, result any, method Procedure, params ...any) error {
 	return e.c.CallContext(ctx, result, method.String(), params...)
 }
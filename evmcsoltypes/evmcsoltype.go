@@ -191,6 +191,32 @@ func ParseSolUintArray(solReturn string) ([]decimal.Decimal, error) {
 	return result, nil
 }
 
+// ParseSolUintArrayPair decodes two consecutive dynamic uint256[] values, as in
+// the data of ERC-1155 TransferBatch(operator, from, to, ids, values).
+func ParseSolUintArrayPair(solReturn string) ([]decimal.Decimal, []decimal.Decimal, error) {
+	b, err := hexutil.Decode(solReturn)
+	if err != nil {
+		return nil, nil, err
+	}
+	args := abi.Arguments{
+		{Type: solUint256Arr},
+		{Type: solUint256Arr},
+	}
+	unpacked, err := args.Unpack(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	toDecimals := func(v any) []decimal.Decimal {
+		arr := *abi.ConvertType(v, new([]*big.Int)).(*[]*big.Int)
+		result := make([]decimal.Decimal, len(arr))
+		for i, n := range arr {
+			result[i] = decimal.NewFromBigInt(n, 0)
+		}
+		return result
+	}
+	return toDecimals(unpacked[0]), toDecimals(unpacked[1]), nil
+}
+
 func Uint256Arr(values []decimal.Decimal) SolType {
 	arr := make([]*big.Int, len(values))
 	for i, v := range values {
@@ -51,3 +51,29 @@ func TestParseBool(t *testing.T) {
 		})
 	}
 }
+
+func TestParseSolUintArrayPair(t *testing.T) {
+	// ids [1, 2], values [10, 20]
+	data := "0x" +
+		"0000000000000000000000000000000000000000000000000000000000000040" +
+		"00000000000000000000000000000000000000000000000000000000000000a0" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"000000000000000000000000000000000000000000000000000000000000000a" +
+		"0000000000000000000000000000000000000000000000000000000000000014"
+	ids, values, err := evmcsoltypes.ParseSolUintArrayPair(data)
+	if err != nil {
+		t.Fatalf("ParseSolUintArrayPair() error = %v", err)
+	}
+	if len(ids) != 2 || ids[0].IntPart() != 1 || ids[1].IntPart() != 2 {
+		t.Errorf("ParseSolUintArrayPair() ids = %v", ids)
+	}
+	if len(values) != 2 || values[0].IntPart() != 10 || values[1].IntPart() != 20 {
+		t.Errorf("ParseSolUintArrayPair() values = %v", values)
+	}
+	if _, _, err := evmcsoltypes.ParseSolUintArrayPair("0x00"); err == nil {
+		t.Error("ParseSolUintArrayPair() expected error for short data")
+	}
+}
@@ -0,0 +1,17 @@
+// Package evmctrace provides utilities for analysing callTracer results
+// ([evmctypes.CallFrame] trees) returned by the debug namespace.
+//
+// It includes depth-first and breadth-first walkers that report the trace
+// address of every frame, flattening into parity-style
+// [evmctypes.FlatCallFrame]s, filtering by call type, extraction of internal
+// native-value transfers, revert localization and per-contract gas attribution.
+//
+// For prestateTracer results in diffMode it builds per-transaction and
+// per-block [StateDiff]s and can recover ERC-20 balance changes from storage.
+//
+//	root, err := client.Debug().TraceTransactionCallTracer(hash, 0, nil, nil)
+//	transfers := evmctrace.InternalTransfers(root)
+//	if revert := evmctrace.RevertOrigin(root); revert != nil {
+//	    fmt.Println(revert.Path, revert.Reason)
+//	}
+package evmctrace
@@ -0,0 +1,365 @@
+package evmctrace
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testTree는 다음 구조의 callTracer 결과:
+//
+//	CALL eoa -> router (value 5)
+//	├─ DELEGATECALL router -> impl
+//	│  └─ CALL router -> alice (value 2)
+//	├─ CALL router -> vault (reverted, caught)
+//	│  └─ CALL vault -> bob (value 1)
+//	├─ STATICCALL router -> oracle
+//	└─ CREATE2 router -> child (value 1)
+const testTree = `{
+	"type": "CALL", "from": "0xeoa", "to": "0xrouter", "value": "0x5",
+	"gas": "0x30000", "gasUsed": "0x10000", "input": "0x", "output": "0x",
+	"calls": [
+		{
+			"type": "DELEGATECALL", "from": "0xrouter", "to": "0ximpl",
+			"gas": "0x8000", "gasUsed": "0x3000", "input": "0x",
+			"calls": [
+				{"type": "CALL", "from": "0xrouter", "to": "0xalice", "value": "0x2", "gas": "0x1000", "gasUsed": "0x800", "input": "0x"}
+			]
+		},
+		{
+			"type": "CALL", "from": "0xrouter", "to": "0xvault", "value": "0x0",
+			"gas": "0x8000", "gasUsed": "0x2000", "input": "0x",
+			"error": "execution reverted",
+			"output": "0x08c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000c696e73756666696369656e7400000000000000000000000000000000000000",
+			"calls": [
+				{"type": "CALL", "from": "0xvault", "to": "0xbob", "value": "0x1", "gas": "0x1000", "gasUsed": "0x100", "input": "0x"}
+			]
+		},
+		{"type": "STATICCALL", "from": "0xrouter", "to": "0xoracle", "gas": "0x1000", "gasUsed": "0x400", "input": "0x"},
+		{"type": "CREATE2", "from": "0xrouter", "to": "0xchild", "value": "0x1", "gas": "0x8000", "gasUsed": "0x4000", "input": "0x6080", "output": "0x6080"}
+	]
+}`
+
+func testRoot(t *testing.T) *evmctypes.CallFrame {
+	t.Helper()
+	root := new(evmctypes.CallFrame)
+	require.NoError(t, json.Unmarshal([]byte(testTree), root))
+	return root
+}
+
+func toOf(frames []*Frame) []string {
+	tos := make([]string, len(frames))
+	for i, f := range frames {
+		tos[i] = *f.To
+	}
+	return tos
+}
+
+func TestWalk(t *testing.T) {
+	root := testRoot(t)
+
+	frames := Frames(root)
+	assert.Equal(t, []string{"0xrouter", "0ximpl", "0xalice", "0xvault", "0xbob", "0xoracle", "0xchild"}, toOf(frames))
+	assert.Equal(t, []uint64{}, frames[0].Path)
+	assert.Equal(t, []uint64{0, 0}, frames[2].Path)
+	assert.Equal(t, []uint64{1, 0}, frames[4].Path)
+	assert.Equal(t, 2, frames[4].Depth())
+	assert.Equal(t, "0xvault", *frames[4].Parent.To)
+
+	var bfs []*Frame
+	require.NoError(t, WalkBreadthFirst(root, func(frame *Frame) error {
+		bfs = append(bfs, frame)
+		return nil
+	}))
+	assert.Equal(t, []string{"0xrouter", "0ximpl", "0xvault", "0xoracle", "0xchild", "0xalice", "0xbob"}, toOf(bfs))
+
+	var skipped []*Frame
+	require.NoError(t, Walk(root, func(frame *Frame) error {
+		skipped = append(skipped, frame)
+		if frame.Depth() == 1 {
+			return SkipChildren
+		}
+		return nil
+	}))
+	assert.Len(t, skipped, 5)
+
+	var count int
+	require.NoError(t, Walk(root, func(frame *Frame) error {
+		count++
+		if count == 3 {
+			return SkipAll
+		}
+		return nil
+	}))
+	assert.Equal(t, 3, count)
+}
+
+func TestFilter(t *testing.T) {
+	root := testRoot(t)
+	assert.Equal(t, []string{"0ximpl"}, toOf(Filter(root, CallTypeDelegateCall)))
+	assert.Equal(t, []string{"0xoracle", "0xchild"}, toOf(Filter(root, "create2", CallTypeStaticCall)))
+	assert.Empty(t, Filter(root, CallTypeSelfDestruct))
+}
+
+func TestFlatten(t *testing.T) {
+	root := testRoot(t)
+	flat := Flatten(root, TxContext{BlockNumber: 10, TransactionHash: "0xtx"})
+	require.Len(t, flat, 7)
+
+	assert.Equal(t, "call", flat[0].Type)
+	assert.Equal(t, uint64(4), flat[0].Subtraces)
+	assert.Equal(t, "call", *flat[0].Action.CallType)
+	assert.Equal(t, "0xtx", flat[0].TransactionHash)
+	require.NotNil(t, flat[0].Result)
+	assert.Equal(t, "0x10000", *flat[0].Result.GasUsed)
+
+	assert.Equal(t, "delegatecall", *flat[1].Action.CallType)
+	assert.Equal(t, []uint64{0, 0}, flat[2].TraceAddress)
+
+	assert.Nil(t, flat[3].Result, "failed frames have no result")
+	assert.Equal(t, "execution reverted", *flat[3].Error)
+
+	create := flat[6]
+	assert.Equal(t, "create", create.Type)
+	assert.Equal(t, "create2", *create.Action.CreationMethod)
+	assert.Equal(t, "0x6080", *create.Action.Init)
+	assert.Equal(t, "0xchild", *create.Result.Address)
+	assert.Equal(t, uint64(6), create.Index)
+}
+
+func TestInternalTransfers(t *testing.T) {
+	// root 전송과 revert된 vault 하위 전송은 제외되고,
+	// DELEGATECALL 하위의 CALL은 실제 전송으로 포함된다.
+	transfers := InternalTransfers(testRoot(t))
+	require.Len(t, transfers, 2)
+	assert.Equal(t, "0xrouter", transfers[0].From)
+	assert.Equal(t, "0xalice", transfers[0].To)
+	assert.Equal(t, "2", transfers[0].Value.String())
+	assert.Equal(t, []uint64{0, 0}, transfers[0].Path)
+	assert.Equal(t, "0xchild", transfers[1].To)
+	assert.Equal(t, []uint64{3}, transfers[1].Path)
+}
+
+func TestReverts(t *testing.T) {
+	root := testRoot(t)
+	assert.Nil(t, RevertOrigin(root), "root succeeded")
+
+	first := FirstRevert(root)
+	require.NotNil(t, first)
+	assert.Equal(t, "0xvault", *first.Frame.To)
+	assert.Equal(t, "execution reverted", first.Error)
+	assert.Equal(t, "insufficient", first.Reason)
+
+	panicOutput := "0x4e487b710000000000000000000000000000000000000000000000000000000000000011"
+	errMsg := "execution reverted"
+	root.Error = &errMsg
+	root.Output = &panicOutput
+	root.Calls[2].Error = &errMsg
+	root.Calls[2].Output = &panicOutput
+
+	// 하위 호출의 revert 데이터를 그대로 전달했으면 하위 호출이 원인이다.
+	origin := RevertOrigin(root)
+	require.NotNil(t, origin)
+	assert.Equal(t, []uint64{2}, origin.Frame.Path)
+	assert.Equal(t, "panic: arithmetic overflow or underflow (0x11)", origin.Reason)
+	assert.Len(t, Reverts(root), 3)
+
+	// 다른 데이터로 revert했으면 root가 직접 발생시킨 에러다.
+	customOutput := "0xdeadbeef"
+	root.Output = &customOutput
+	origin = RevertOrigin(root)
+	require.NotNil(t, origin)
+	assert.Empty(t, origin.Frame.Path)
+	assert.Equal(t, "custom error 0xdeadbeef", origin.Reason)
+
+	// 하위 호출이 데이터 없이 revert했고 root도 그대로 전달했으면 하위 호출이 원인이다.
+	emptyOutput := "0x"
+	root.Output = &emptyOutput
+	root.Calls[2].Output = nil
+	origin = RevertOrigin(root)
+	require.NotNil(t, origin)
+	assert.Equal(t, []uint64{2}, origin.Frame.Path)
+
+	// 하위 호출이 out of gas로 실패한 뒤 root가 메시지 없는 require(ok)로
+	// revert했으면 root가 원인이다.
+	outOfGas := "out of gas"
+	root.Calls[2].Error = &outOfGas
+	origin = RevertOrigin(root)
+	require.NotNil(t, origin)
+	assert.Empty(t, origin.Frame.Path)
+	assert.Equal(t, "execution reverted", origin.Error)
+}
+
+func TestDecodeRevertReason(t *testing.T) {
+	assert.Equal(t, "", DecodeRevertReason("0x"))
+	assert.Equal(t, "custom error 0xdeadbeef", DecodeRevertReason("0xdeadbeef00"))
+	assert.Equal(t, "panic: unknown panic (0x99)", DecodeRevertReason("0x4e487b710000000000000000000000000000000000000000000000000000000000000099"))
+}
+
+func TestGasByContract(t *testing.T) {
+	usage := GasByContract(testRoot(t))
+	byAddress := make(map[string]*GasUsage)
+	for _, u := range usage {
+		byAddress[u.Address] = u
+	}
+	// router: 0x10000 - (0x3000 + 0x2000 + 0x400 + 0x4000)
+	assert.Equal(t, uint64(0x10000-0x3000-0x2000-0x400-0x4000), byAddress["0xrouter"].SelfGasUsed)
+	assert.Equal(t, uint64(0x3000-0x800), byAddress["0ximpl"].SelfGasUsed)
+	assert.Equal(t, uint64(0x800), byAddress["0xalice"].SelfGasUsed)
+	assert.Equal(t, "0xrouter", usage[0].Address)
+	assert.Equal(t, 1, byAddress["0xrouter"].Calls)
+}
+
+// ─── state diff ─────────────────────────────────────────────────────────────
+
+const (
+	diffSender  = "0x00000000000000000000000000000000000000a1"
+	diffHolder  = "0x00000000000000000000000000000000000000b2"
+	diffToken   = "0x0000000000000000000000000000000000000070"
+	diffCreated = "0x00000000000000000000000000000000000000c3"
+	diffKilled  = "0x00000000000000000000000000000000000000d4"
+)
+
+func diffBalance(v int64) *decimal.Decimal {
+	d := decimal.NewFromInt(v)
+	return &d
+}
+
+func diffWord(v int64) string {
+	return common.BigToHash(big.NewInt(v)).Hex()
+}
+
+// testDiffFrames는 두 트랜잭션의 diffMode 결과:
+//
+//	tx1: sender가 token 40을 holder에게 전송하고 contract를 생성
+//	tx2: holder가 token 40을 sender에게 돌려주고 diffKilled가 selfdestruct
+func testDiffFrames() []*evmctypes.PrestateDiffFrame {
+	layout := ERC20Layout{BalancesSlot: "0x0"}
+	var (
+		senderSlot = balanceSlot(layout, common.HexToAddress(diffSender)).Hex()
+		holderSlot = balanceSlot(layout, common.HexToAddress(diffHolder)).Hex()
+	)
+	return []*evmctypes.PrestateDiffFrame{
+		{
+			TxHash: "0xtx1",
+			Pre: evmctypes.PrestateFrame{
+				diffSender: {Balance: diffBalance(1000), Nonce: 1},
+				diffToken:  {Balance: diffBalance(0), Nonce: 1, Code: "0x6080", Storage: map[string]string{senderSlot: diffWord(100)}},
+			},
+			Post: evmctypes.PrestateFrame{
+				diffSender:  {Balance: diffBalance(900), Nonce: 2},
+				diffToken:   {Storage: map[string]string{senderSlot: diffWord(60), holderSlot: diffWord(40)}},
+				diffCreated: {Balance: diffBalance(0), Nonce: 1, Code: "0x60016000"},
+			},
+		},
+		{
+			TxHash: "0xtx2",
+			Pre: evmctypes.PrestateFrame{
+				diffSender: {Balance: diffBalance(900), Nonce: 2},
+				diffHolder: {Balance: diffBalance(50)},
+				diffToken:  {Balance: diffBalance(0), Nonce: 1, Code: "0x6080", Storage: map[string]string{senderSlot: diffWord(60), holderSlot: diffWord(40)}},
+				diffKilled: {Balance: diffBalance(5), Nonce: 1, Code: "0xff"},
+			},
+			Post: evmctypes.PrestateFrame{
+				diffHolder: {Balance: diffBalance(45), Nonce: 1},
+				diffToken:  {Storage: map[string]string{senderSlot: diffWord(100)}},
+				diffSender: {Balance: diffBalance(905)},
+			},
+		},
+	}
+}
+
+func TestNewStateDiff(t *testing.T) {
+	frames := testDiffFrames()
+	diff := NewStateDiff(frames[0])
+	assert.Equal(t, "0xtx1", diff.TxHash)
+	require.Len(t, diff.Accounts, 3)
+
+	sender := diff.Account(diffSender)
+	require.NotNil(t, sender)
+	assert.Equal(t, "-100", sender.BalanceDelta.String())
+	assert.True(t, sender.NonceChanged())
+	assert.Equal(t, uint64(2), sender.NonceTo)
+	assert.False(t, sender.Created)
+
+	created := diff.Account(diffCreated)
+	require.NotNil(t, created)
+	assert.True(t, created.Created)
+	assert.True(t, created.CodeDeployed)
+	assert.Equal(t, "0x60016000", created.Code)
+
+	token := diff.Account(diffToken)
+	require.NotNil(t, token)
+	assert.False(t, token.BalanceChanged())
+	require.Len(t, token.Storage, 2)
+
+	changes := diff.AnnotateERC20(map[string]ERC20Layout{diffToken: {BalancesSlot: "0x0"}}, diffHolder)
+	require.Len(t, changes, 2)
+	assert.Equal(t, diffSender, changes[0].Holder)
+	assert.Equal(t, "-40", changes[0].Delta.String())
+	assert.Equal(t, diffHolder, changes[1].Holder)
+	assert.Equal(t, "40", changes[1].Delta.String())
+	holderSlot := token.Slot(changes[1].Slot)
+	require.NotNil(t, holderSlot)
+	assert.True(t, holderSlot.Created)
+	assert.Equal(t, diffHolder, holderSlot.ERC20Holder)
+
+	killed := NewStateDiff(frames[1]).Account(diffKilled)
+	require.NotNil(t, killed)
+	assert.True(t, killed.Deleted)
+	assert.True(t, killed.CodeDestroyed)
+	assert.Equal(t, "-5", killed.BalanceDelta.String())
+
+	cleared := NewStateDiff(frames[1]).Account(diffToken).Slot(changes[1].Slot)
+	require.NotNil(t, cleared)
+	assert.True(t, cleared.Deleted)
+	assert.Equal(t, diffWord(0), cleared.To)
+}
+
+func TestNewBlockStateDiff(t *testing.T) {
+	block := NewBlockStateDiff(testDiffFrames())
+	// token 잔고는 원래대로 돌아왔으므로 diff에 남지 않는다.
+	assert.Nil(t, block.Account(diffToken))
+
+	sender := block.Account(diffSender)
+	require.NotNil(t, sender)
+	assert.Equal(t, "1000", sender.BalanceFrom.String())
+	assert.Equal(t, "905", sender.BalanceTo.String())
+	assert.Equal(t, uint64(1), sender.NonceFrom)
+	assert.Equal(t, uint64(2), sender.NonceTo)
+
+	holder := block.Account(diffHolder)
+	require.NotNil(t, holder)
+	assert.Equal(t, "-5", holder.BalanceDelta.String())
+
+	assert.True(t, block.Account(diffCreated).Created)
+	assert.True(t, block.Account(diffKilled).Deleted)
+	assert.Len(t, block.Accounts, 4)
+}
+
+func TestBlockStateDiffs(t *testing.T) {
+	raw, err := json.Marshal([]map[string]any{
+		{"txHash": "0xtx1", "result": map[string]any{
+			"pre":  map[string]any{diffSender: map[string]any{"balance": "0x10", "nonce": 3}},
+			"post": map[string]any{diffSender: map[string]any{"balance": "0x8", "nonce": 4}},
+		}},
+		{"txHash": "0xtx2", "result": nil},
+	})
+	require.NoError(t, err)
+	var tracers []*evmctypes.PrestateTracer
+	require.NoError(t, json.Unmarshal(raw, &tracers))
+
+	txs, block, err := BlockStateDiffs(tracers)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	assert.Equal(t, "0xtx2", txs[1].TxHash)
+	assert.Empty(t, txs[1].Accounts)
+	require.Len(t, block.Accounts, 1)
+	assert.Equal(t, "-8", block.Accounts[0].BalanceDelta.String())
+}
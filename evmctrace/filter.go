@@ -0,0 +1,50 @@
+package evmctrace
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+)
+
+// Call frame types reported by callTracer.
+const (
+	CallTypeCall         = "CALL"
+	CallTypeStaticCall   = "STATICCALL"
+	CallTypeDelegateCall = "DELEGATECALL"
+	CallTypeCallCode     = "CALLCODE"
+	CallTypeCreate       = "CREATE"
+	CallTypeCreate2      = "CREATE2"
+	CallTypeSelfDestruct = "SELFDESTRUCT"
+)
+
+// IsCreate reports whether the frame deployed a contract.
+func IsCreate(frame *evmctypes.CallFrame) bool {
+	t := strings.ToUpper(frame.Type)
+	return t == CallTypeCreate || t == CallTypeCreate2
+}
+
+// Filter returns the frames whose type is one of types, in depth-first
+// pre-order. Type comparison is case-insensitive.
+func Filter(root *evmctypes.CallFrame, types ...string) []*Frame {
+	upper := make([]string, len(types))
+	for i, t := range types {
+		upper[i] = strings.ToUpper(t)
+	}
+	return FilterFunc(root, func(frame *Frame) bool {
+		return slices.Contains(upper, strings.ToUpper(frame.Type))
+	})
+}
+
+// FilterFunc returns the frames for which keep returns true, in depth-first
+// pre-order.
+func FilterFunc(root *evmctypes.CallFrame, keep func(frame *Frame) bool) []*Frame {
+	var frames []*Frame
+	_ = Walk(root, func(frame *Frame) error {
+		if keep(frame) {
+			frames = append(frames, frame)
+		}
+		return nil
+	})
+	return frames
+}
@@ -0,0 +1,101 @@
+package evmctrace
+
+import (
+	"strings"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// parity trace types.
+const (
+	flatTypeCall    = "call"
+	flatTypeCreate  = "create"
+	flatTypeSuicide = "suicide"
+)
+
+// TxContext carries the transaction fields that a callTracer result does not
+// include but every [evmctypes.FlatCallFrame] does.
+type TxContext struct {
+	BlockHash           string
+	BlockNumber         uint64
+	TransactionHash     string
+	TransactionPosition uint64
+}
+
+// Flatten converts a call tree into parity-style (trace_transaction) frames in
+// depth-first pre-order. As in parity, Result is nil for frames that failed.
+func Flatten(root *evmctypes.CallFrame, tx TxContext) []*evmctypes.FlatCallFrame {
+	var flat []*evmctypes.FlatCallFrame
+	_ = Walk(root, func(frame *Frame) error {
+		f := flattenFrame(frame, tx)
+		f.Index = uint64(len(flat))
+		flat = append(flat, f)
+		return nil
+	})
+	return flat
+}
+
+func flattenFrame(frame *Frame, tx TxContext) *evmctypes.FlatCallFrame {
+	var (
+		f        = new(evmctypes.FlatCallFrame)
+		callType = strings.ToUpper(frame.Type)
+		from     = frame.From
+		gas      = frame.Gas
+		gasUsed  = frame.GasUsed
+	)
+	f.BlockHash = tx.BlockHash
+	f.BlockNumber = tx.BlockNumber
+	f.TransactionHash = tx.TransactionHash
+	f.TransactionPosition = tx.TransactionPosition
+	f.TraceAddress = frame.Path
+	f.Subtraces = uint64(len(frame.Calls))
+	f.Error = frame.Error
+	f.AfterEVMTransfers = frame.AfterEVMTransfers
+	f.BeforeEVMTransfers = frame.BeforeEVMTransfers
+
+	switch callType {
+	case CallTypeCreate, CallTypeCreate2:
+		method := strings.ToLower(callType)
+		f.Type = flatTypeCreate
+		f.Action.From = &from
+		f.Action.Gas = &gas
+		f.Action.Init = frame.Input
+		f.Action.Value = frame.Value
+		f.Action.CreationMethod = &method
+		if frame.Error == nil {
+			f.Result = &struct {
+				Address *string `json:"address,omitempty"`
+				Code    *string `json:"code,omitempty"`
+				GasUsed *string `json:"gasUsed,omitempty"`
+				Output  *string `json:"output,omitempty"`
+			}{Address: frame.To, Code: frame.Output, GasUsed: &gasUsed}
+		}
+	case CallTypeSelfDestruct:
+		f.Type = flatTypeSuicide
+		f.Action.Address = &from
+		f.Action.RefundAddress = frame.To
+		if frame.Value != nil {
+			balance := hexutil.EncodeBig(frame.Value.BigInt())
+			f.Action.Balance = &balance
+		}
+	default:
+		method := strings.ToLower(callType)
+		f.Type = flatTypeCall
+		f.Action.CallType = &method
+		f.Action.From = &from
+		f.Action.To = frame.To
+		f.Action.Gas = &gas
+		f.Action.Input = frame.Input
+		f.Action.Value = frame.Value
+		if frame.Error == nil {
+			f.Result = &struct {
+				Address *string `json:"address,omitempty"`
+				Code    *string `json:"code,omitempty"`
+				GasUsed *string `json:"gasUsed,omitempty"`
+				Output  *string `json:"output,omitempty"`
+			}{GasUsed: &gasUsed, Output: frame.Output}
+		}
+	}
+	return f
+}
@@ -0,0 +1,83 @@
+package evmctrace
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// GasUsage is the gas attributed to the code of one contract.
+type GasUsage struct {
+	Address string `json:"address"`
+	// SelfGasUsed is the gas spent executing the contract's own code, excluding
+	// what its sub-calls consumed.
+	SelfGasUsed uint64 `json:"selfGasUsed"`
+	// GasUsed is the inclusive gas of every frame executing the contract's code.
+	// It double counts recursive calls into the same contract.
+	GasUsed uint64 `json:"gasUsed"`
+	Calls   int    `json:"calls"`
+}
+
+// GasByContract attributes gas to the contract whose code ran in each frame.
+// A DELEGATECALL frame is attributed to the callee (the code), not the caller
+// (the storage context). The root frame of a callTracer result includes the
+// intrinsic gas of the transaction, which is therefore attributed to the
+// transaction's target. The result is sorted by SelfGasUsed, highest first.
+func GasByContract(root *evmctypes.CallFrame) []*GasUsage {
+	usage := make(map[string]*GasUsage)
+	_ = Walk(root, func(frame *Frame) error {
+		if frame.To == nil {
+			return nil
+		}
+		address := strings.ToLower(*frame.To)
+		u, ok := usage[address]
+		if !ok {
+			u = &GasUsage{Address: address}
+			usage[address] = u
+		}
+		u.Calls++
+		u.GasUsed += parseGas(frame.GasUsed)
+		u.SelfGasUsed += SelfGasUsed(frame.CallFrame)
+		return nil
+	})
+	result := make([]*GasUsage, 0, len(usage))
+	for _, u := range usage {
+		result = append(result, u)
+	}
+	slices.SortFunc(result, func(a, b *GasUsage) int {
+		if a.SelfGasUsed != b.SelfGasUsed {
+			if a.SelfGasUsed > b.SelfGasUsed {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Address, b.Address)
+	})
+	return result
+}
+
+// SelfGasUsed returns the gas used by the frame minus the gas used by its
+// direct sub-calls.
+func SelfGasUsed(frame *evmctypes.CallFrame) uint64 {
+	used := parseGas(frame.GasUsed)
+	var children uint64
+	for _, call := range frame.Calls {
+		if call != nil {
+			children += parseGas(call.GasUsed)
+		}
+	}
+	if children > used {
+		return 0
+	}
+	return used - children
+}
+
+func parseGas(gas string) uint64 {
+	v, err := hexutil.DecodeUint64(gas)
+	if err != nil {
+		return 0
+	}
+	return v
+}
@@ -0,0 +1,169 @@
+package evmctrace
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/bbaktaeho/evmc/evmcsoltypes"
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// errorSelector is Error(string).
+	errorSelector = "0x08c379a0"
+	// panicSelector is Panic(uint256).
+	panicSelector = "0x4e487b71"
+	// errExecutionReverted is the frame error of a REVERT, as opposed to an
+	// exceptional halt such as "out of gas".
+	errExecutionReverted = "execution reverted"
+)
+
+// panicReasons describes the Solidity panic codes.
+var panicReasons = map[uint64]string{
+	0x00: "generic compiler panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array encoding",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to uninitialized function",
+}
+
+// Revert describes a failed frame.
+type Revert struct {
+	Frame *Frame
+	// Error is the error reported by the tracer, e.g. "execution reverted" or
+	// "out of gas".
+	Error string
+	// Reason is the decoded revert reason, if any.
+	Reason string
+}
+
+// RevertOrigin follows a failure down from the root to the frame where it
+// started: at every level it descends into the last failed sub-call, since a
+// revert that bubbles up is raised by the call that returned just before, but
+// only while the frame reverted with the same data as that sub-call. A frame
+// that reverted with other data raised its own error and is the origin, and so
+// is a frame that reverted with no data after a sub-call halted without
+// reverting (e.g. out of gas), as with a bare require(ok).
+// It returns nil when the root did not fail.
+func RevertOrigin(root *evmctypes.CallFrame) *Revert {
+	if root == nil || root.Error == nil {
+		return nil
+	}
+	frame := &Frame{CallFrame: root, Path: []uint64{}}
+	for {
+		next := -1
+		for i := len(frame.Calls) - 1; i >= 0; i-- {
+			if frame.Calls[i] != nil && frame.Calls[i].Error != nil {
+				next = i
+				break
+			}
+		}
+		if next < 0 || !bubbled(frame.CallFrame, frame.Calls[next]) {
+			return newRevert(frame)
+		}
+		frame = &Frame{CallFrame: frame.Calls[next], Path: childPath(frame.Path, next), Parent: frame}
+	}
+}
+
+// bubbled reports whether frame failed by passing on the failure of call.
+// A halted call returns no data, so an empty revert of frame after it is the
+// frame's own.
+func bubbled(frame, call *evmctypes.CallFrame) bool {
+	data := revertData(frame)
+	if data != revertData(call) {
+		return false
+	}
+	return data != "" || *call.Error == errExecutionReverted
+}
+
+// revertData returns the output of a failed frame, with no output and "0x"
+// treated alike.
+func revertData(frame *evmctypes.CallFrame) string {
+	if frame.Output == nil || *frame.Output == "0x" {
+		return ""
+	}
+	return strings.ToLower(*frame.Output)
+}
+
+// FirstRevert returns the first frame that failed in execution order, that is
+// the first failed frame to complete. Unlike [RevertOrigin] this also reports
+// failures that a caller caught, even when the transaction succeeded.
+// It returns nil when no frame failed.
+func FirstRevert(root *evmctypes.CallFrame) *Revert {
+	reverts := Reverts(root)
+	if len(reverts) == 0 {
+		return nil
+	}
+	return reverts[0]
+}
+
+// Reverts returns every failed frame in completion (post-) order.
+func Reverts(root *evmctypes.CallFrame) []*Revert {
+	if root == nil {
+		return nil
+	}
+	var reverts []*Revert
+	var visit func(frame *Frame)
+	visit = func(frame *Frame) {
+		for i, call := range frame.Calls {
+			if call != nil {
+				visit(&Frame{CallFrame: call, Path: childPath(frame.Path, i), Parent: frame})
+			}
+		}
+		if frame.Error != nil {
+			reverts = append(reverts, newRevert(frame))
+		}
+	}
+	visit(&Frame{CallFrame: root, Path: []uint64{}})
+	return reverts
+}
+
+func newRevert(frame *Frame) *Revert {
+	r := &Revert{Frame: frame}
+	if frame.Error != nil {
+		r.Error = *frame.Error
+	}
+	if frame.RevertReason != nil && *frame.RevertReason != "" {
+		r.Reason = *frame.RevertReason
+	} else if frame.Output != nil {
+		r.Reason = DecodeRevertReason(*frame.Output)
+	}
+	return r
+}
+
+// DecodeRevertReason decodes revert data. Error(string) yields the message,
+// Panic(uint256) yields "panic: <description> (0x..)", and any other non-empty
+// data yields "custom error 0x<selector>".
+func DecodeRevertReason(output string) string {
+	output = strings.ToLower(output)
+	if len(output) < 10 {
+		return ""
+	}
+	switch output[:10] {
+	case errorSelector:
+		reason, err := evmcsoltypes.ParseSolStringToString("0x" + output[10:])
+		if err != nil {
+			return ""
+		}
+		return reason
+	case panicSelector:
+		b, err := hexutil.Decode("0x" + output[10:])
+		if err != nil || len(b) < 32 {
+			return ""
+		}
+		code := new(big.Int).SetBytes(b[:32])
+		desc, ok := panicReasons[code.Uint64()]
+		if !ok || !code.IsUint64() {
+			desc = "unknown panic"
+		}
+		return fmt.Sprintf("panic: %s (0x%x)", desc, code)
+	}
+	return "custom error " + output[:10]
+}
@@ -0,0 +1,389 @@
+package evmctrace
+
+import (
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/shopspring/decimal"
+)
+
+// SlotDiff is the change of a single storage slot. From and To are 32-byte
+// hex words; a slot missing from the trace is the zero word.
+type SlotDiff struct {
+	Slot string `json:"slot"`
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Created reports a slot written from zero to a non-zero value.
+	Created bool `json:"created,omitempty"`
+	// Deleted reports a slot cleared to zero.
+	Deleted bool `json:"deleted,omitempty"`
+	// ERC20Holder is set by [StateDiff.AnnotateERC20] when the slot is the
+	// balanceOf entry of this holder.
+	ERC20Holder string `json:"erc20Holder,omitempty"`
+}
+
+// AccountDiff is the change of a single account.
+type AccountDiff struct {
+	Address string `json:"address"`
+	// Created reports an account that was empty or absent before.
+	Created bool `json:"created,omitempty"`
+	// Deleted reports an account removed by SELFDESTRUCT.
+	Deleted bool `json:"deleted,omitempty"`
+
+	BalanceFrom  decimal.Decimal `json:"balanceFrom"`
+	BalanceTo    decimal.Decimal `json:"balanceTo"`
+	BalanceDelta decimal.Decimal `json:"balanceDelta"`
+	NonceFrom    uint64          `json:"nonceFrom"`
+	NonceTo      uint64          `json:"nonceTo"`
+
+	CodeDeployed  bool `json:"codeDeployed,omitempty"`
+	CodeDestroyed bool `json:"codeDestroyed,omitempty"`
+	// Code is the new runtime code when it was deployed or replaced.
+	Code string `json:"code,omitempty"`
+
+	Storage []*SlotDiff `json:"storage,omitempty"`
+}
+
+// BalanceChanged reports whether the native balance changed.
+func (a *AccountDiff) BalanceChanged() bool {
+	return !a.BalanceDelta.IsZero()
+}
+
+// NonceChanged reports whether the nonce changed.
+func (a *AccountDiff) NonceChanged() bool {
+	return a.NonceFrom != a.NonceTo
+}
+
+// Slot returns the diff of slot, or nil if it did not change.
+func (a *AccountDiff) Slot(slot string) *SlotDiff {
+	key := common.HexToHash(slot).Hex()
+	for _, s := range a.Storage {
+		if s.Slot == key {
+			return s
+		}
+	}
+	return nil
+}
+
+// StateDiff is the state change of a transaction or, when built by
+// [NewBlockStateDiff], of a whole block. Accounts are sorted by address and
+// only carry what actually changed.
+type StateDiff struct {
+	TxHash   string         `json:"txHash,omitempty"`
+	Accounts []*AccountDiff `json:"accounts"`
+}
+
+// Account returns the diff of address, or nil if it did not change.
+func (s *StateDiff) Account(address string) *AccountDiff {
+	address = strings.ToLower(address)
+	for _, a := range s.Accounts {
+		if a.Address == address {
+			return a
+		}
+	}
+	return nil
+}
+
+// NewStateDiff builds the state diff of one prestateTracer diffMode result.
+func NewStateDiff(frame *evmctypes.PrestateDiffFrame) *StateDiff {
+	if frame == nil {
+		return &StateDiff{}
+	}
+	acc := newStateAccumulator()
+	acc.add(frame)
+	return &StateDiff{TxHash: frame.TxHash, Accounts: acc.diff()}
+}
+
+// NewBlockStateDiff merges the diffMode results of the transactions of a block,
+// given in execution order, into a single diff from the state before the first
+// transaction to the state after the last one. Values that changed and then
+// changed back do not appear.
+func NewBlockStateDiff(frames []*evmctypes.PrestateDiffFrame) *StateDiff {
+	acc := newStateAccumulator()
+	for _, frame := range frames {
+		if frame != nil {
+			acc.add(frame)
+		}
+	}
+	return &StateDiff{Accounts: acc.diff()}
+}
+
+// BlockStateDiffs parses the results of a diffMode prestateTracer block trace
+// and returns the per-transaction diffs together with the merged block diff.
+func BlockStateDiffs(tracers []*evmctypes.PrestateTracer) ([]*StateDiff, *StateDiff, error) {
+	var (
+		frames = make([]*evmctypes.PrestateDiffFrame, 0, len(tracers))
+		txs    = make([]*StateDiff, 0, len(tracers))
+	)
+	for _, tracer := range tracers {
+		frame, err := tracer.ParseDiffFrames()
+		if err != nil {
+			return nil, nil, err
+		}
+		if frame == nil {
+			frame = &evmctypes.PrestateDiffFrame{TxHash: tracer.TxHash}
+		}
+		frames = append(frames, frame)
+		txs = append(txs, NewStateDiff(frame))
+	}
+	return txs, NewBlockStateDiff(frames), nil
+}
+
+// ─── ERC-20 ─────────────────────────────────────────────────────────────────
+
+// ERC20Layout locates the balances mapping of an ERC-20 token.
+type ERC20Layout struct {
+	// BalancesSlot is the slot of the mapping(address => uint256) holding the
+	// balances, e.g. "0x0" for a token whose first state variable is the
+	// mapping, or the ERC-7201 namespace slot for OpenZeppelin 5 tokens.
+	BalancesSlot string
+	// Vyper selects Vyper's keccak256(slot . key) layout instead of Solidity's
+	// keccak256(key . slot).
+	Vyper bool
+}
+
+// ERC20BalanceChange is a token balance change recovered from storage.
+type ERC20BalanceChange struct {
+	Token  string          `json:"token"`
+	Holder string          `json:"holder"`
+	Slot   string          `json:"slot"`
+	From   decimal.Decimal `json:"from"`
+	To     decimal.Decimal `json:"to"`
+	Delta  decimal.Decimal `json:"delta"`
+}
+
+// AnnotateERC20 marks the storage changes of the tokens in layouts, keyed by
+// token address, that are balance entries and returns them as balance changes.
+//
+// Mapping slots are hashes, so holders have to be guessed: every account in the
+// diff is tried together with the given extra holders. Pass the transfer
+// participants (e.g. from Transfer logs) to catch holders whose own account did
+// not change.
+func (s *StateDiff) AnnotateERC20(layouts map[string]ERC20Layout, holders ...string) []*ERC20BalanceChange {
+	candidates := make([]common.Address, 0, len(s.Accounts)+len(holders))
+	for _, a := range s.Accounts {
+		candidates = append(candidates, common.HexToAddress(a.Address))
+	}
+	for _, h := range holders {
+		candidates = append(candidates, common.HexToAddress(h))
+	}
+
+	var changes []*ERC20BalanceChange
+	for token, layout := range layouts {
+		account := s.Account(token)
+		if account == nil || len(account.Storage) == 0 {
+			continue
+		}
+		slots := make(map[string]common.Address, len(candidates))
+		for _, holder := range candidates {
+			slots[balanceSlot(layout, holder).Hex()] = holder
+		}
+		for _, slot := range account.Storage {
+			holder, ok := slots[slot.Slot]
+			if !ok {
+				continue
+			}
+			slot.ERC20Holder = strings.ToLower(holder.Hex())
+			from, to := wordToDecimal(slot.From), wordToDecimal(slot.To)
+			changes = append(changes, &ERC20BalanceChange{
+				Token:  account.Address,
+				Holder: slot.ERC20Holder,
+				Slot:   slot.Slot,
+				From:   from,
+				To:     to,
+				Delta:  to.Sub(from),
+			})
+		}
+	}
+	slices.SortFunc(changes, func(a, b *ERC20BalanceChange) int {
+		if c := strings.Compare(a.Token, b.Token); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Holder, b.Holder)
+	})
+	return changes
+}
+
+func balanceSlot(layout ERC20Layout, holder common.Address) common.Hash {
+	var (
+		key  = common.BytesToHash(holder.Bytes())
+		slot = common.HexToHash(layout.BalancesSlot)
+	)
+	if layout.Vyper {
+		return crypto.Keccak256Hash(slot.Bytes(), key.Bytes())
+	}
+	return crypto.Keccak256Hash(key.Bytes(), slot.Bytes())
+}
+
+func wordToDecimal(word string) decimal.Decimal {
+	return decimal.NewFromBigInt(new(big.Int).SetBytes(common.HexToHash(word).Bytes()), 0)
+}
+
+// ─── accumulation ───────────────────────────────────────────────────────────
+
+// accountState is the state of an account as far as the trace reveals it.
+type accountState struct {
+	exists  bool
+	balance decimal.Decimal
+	nonce   uint64
+	code    string
+}
+
+type accountChange struct {
+	before, after accountState
+	// slots holds [before, after] for every slot seen.
+	slots map[common.Hash]*[2]common.Hash
+}
+
+type stateAccumulator struct {
+	accounts map[string]*accountChange
+}
+
+func newStateAccumulator() *stateAccumulator {
+	return &stateAccumulator{accounts: make(map[string]*accountChange)}
+}
+
+// add folds one diffMode result into the accumulator. In diffMode an account
+// only in pre was self-destructed, an account only in post was created, and
+// post omits every field and slot that kept its pre value, except slots that
+// were cleared to zero, which are omitted from post as well.
+func (s *stateAccumulator) add(frame *evmctypes.PrestateDiffFrame) {
+	addresses := make(map[string]struct{}, len(frame.Pre)+len(frame.Post))
+	for address := range frame.Pre {
+		addresses[address] = struct{}{}
+	}
+	for address := range frame.Post {
+		addresses[address] = struct{}{}
+	}
+	for address := range addresses {
+		var (
+			pre, inPre   = frame.Pre[address]
+			post, inPost = frame.Post[address]
+			before       = stateOf(pre)
+			after        = before
+		)
+		if !inPre || pre == nil {
+			pre = &evmctypes.PrestateAccount{}
+		}
+		if inPost && post != nil {
+			after.exists = true
+			if post.Balance != nil {
+				after.balance = *post.Balance
+			}
+			if post.Nonce != 0 {
+				after.nonce = post.Nonce
+			}
+			if post.Code != "" {
+				after.code = post.Code
+			}
+		} else if inPre {
+			after = accountState{balance: decimal.Zero}
+		}
+
+		key := strings.ToLower(address)
+		change, ok := s.accounts[key]
+		if !ok {
+			change = &accountChange{before: before, slots: make(map[common.Hash]*[2]common.Hash)}
+			s.accounts[key] = change
+		}
+		change.after = after
+
+		for slot, value := range pre.Storage {
+			change.setSlot(common.HexToHash(slot), common.HexToHash(value), common.Hash{})
+		}
+		if post != nil {
+			for slot, value := range post.Storage {
+				h := common.HexToHash(slot)
+				if _, ok := pre.Storage[slot]; !ok {
+					// a slot new to this frame had the zero value before.
+					change.setSlot(h, common.Hash{}, common.HexToHash(value))
+					continue
+				}
+				change.slots[h][1] = common.HexToHash(value)
+			}
+		}
+	}
+}
+
+// setSlot records a slot seen in the current frame. The first frame to see a
+// slot fixes its before value; every frame overwrites its after value.
+func (c *accountChange) setSlot(slot, before, after common.Hash) {
+	values, ok := c.slots[slot]
+	if !ok {
+		c.slots[slot] = &[2]common.Hash{before, after}
+		return
+	}
+	values[1] = after
+}
+
+func stateOf(account *evmctypes.PrestateAccount) accountState {
+	state := accountState{balance: decimal.Zero}
+	if account == nil {
+		return state
+	}
+	if account.Balance != nil {
+		state.balance = *account.Balance
+	}
+	state.nonce = account.Nonce
+	state.code = account.Code
+	// EIP-161: an empty account is equivalent to a non-existent one.
+	state.exists = state.balance.IsPositive() || state.nonce != 0 || hasCode(state.code)
+	return state
+}
+
+func hasCode(code string) bool {
+	return code != "" && code != "0x"
+}
+
+func (s *stateAccumulator) diff() []*AccountDiff {
+	diffs := make([]*AccountDiff, 0, len(s.accounts))
+	for address, change := range s.accounts {
+		before, after := change.before, change.after
+		d := &AccountDiff{
+			Address:      address,
+			Created:      !before.exists && after.exists,
+			Deleted:      before.exists && !after.exists,
+			BalanceFrom:  before.balance,
+			BalanceTo:    after.balance,
+			BalanceDelta: after.balance.Sub(before.balance),
+			NonceFrom:    before.nonce,
+			NonceTo:      after.nonce,
+		}
+		switch {
+		case !hasCode(before.code) && hasCode(after.code):
+			d.CodeDeployed = true
+			d.Code = after.code
+		case hasCode(before.code) && !hasCode(after.code):
+			d.CodeDestroyed = true
+		case before.code != after.code && hasCode(after.code):
+			d.Code = after.code
+		}
+		for slot, values := range change.slots {
+			if values[0] == values[1] {
+				continue
+			}
+			d.Storage = append(d.Storage, &SlotDiff{
+				Slot:    slot.Hex(),
+				From:    values[0].Hex(),
+				To:      values[1].Hex(),
+				Created: values[0] == (common.Hash{}),
+				Deleted: values[1] == (common.Hash{}),
+			})
+		}
+		slices.SortFunc(d.Storage, func(a, b *SlotDiff) int {
+			return strings.Compare(a.Slot, b.Slot)
+		})
+		if d.Created || d.Deleted || d.BalanceChanged() || d.NonceChanged() ||
+			d.CodeDeployed || d.CodeDestroyed || d.Code != "" || len(d.Storage) > 0 {
+			diffs = append(diffs, d)
+		}
+	}
+	slices.SortFunc(diffs, func(a, b *AccountDiff) int {
+		return strings.Compare(a.Address, b.Address)
+	})
+	return diffs
+}
@@ -0,0 +1,53 @@
+package evmctrace
+
+import (
+	"strings"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/shopspring/decimal"
+)
+
+// InternalTransfer is a native-value transfer made by a contract during
+// execution, i.e. by any frame below the root.
+type InternalTransfer struct {
+	From  string          `json:"from"`
+	To    string          `json:"to"`
+	Value decimal.Decimal `json:"value"`
+	Type  string          `json:"type"`
+	Path  []uint64        `json:"traceAddress"`
+	Index uint64          `json:"index"`
+}
+
+// InternalTransfers returns every effective native-value transfer below the
+// root frame in execution order. Frames that failed are skipped together with
+// their sub-calls, because their state changes were rolled back. DELEGATECALL
+// and CALLCODE frames are skipped since the value stays with the caller, and
+// STATICCALL cannot carry value.
+func InternalTransfers(root *evmctypes.CallFrame) []*InternalTransfer {
+	var transfers []*InternalTransfer
+	_ = Walk(root, func(frame *Frame) error {
+		if frame.Error != nil {
+			return SkipChildren
+		}
+		if frame.Parent == nil {
+			return nil
+		}
+		switch strings.ToUpper(frame.Type) {
+		case CallTypeDelegateCall, CallTypeCallCode, CallTypeStaticCall:
+			return nil
+		}
+		if frame.Value == nil || !frame.Value.IsPositive() || frame.To == nil {
+			return nil
+		}
+		transfers = append(transfers, &InternalTransfer{
+			From:  frame.From,
+			To:    *frame.To,
+			Value: *frame.Value,
+			Type:  frame.Type,
+			Path:  frame.Path,
+			Index: frame.Index,
+		})
+		return nil
+	})
+	return transfers
+}
@@ -0,0 +1,112 @@
+package evmctrace
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+)
+
+var (
+	// SkipChildren is returned by a [VisitFunc] to skip the sub-calls of the
+	// current frame. It is not returned as an error by the walkers.
+	SkipChildren = errors.New("skip children")
+	// SkipAll is returned by a [VisitFunc] to stop the walk. It is not returned
+	// as an error by the walkers.
+	SkipAll = errors.New("skip all")
+)
+
+// Frame is a call frame together with its position in the tree.
+type Frame struct {
+	*evmctypes.CallFrame
+	// Path is the parity-style trace address: the child index at every level
+	// below the root. The root has an empty path.
+	Path []uint64
+	// Parent is nil for the root frame.
+	Parent *Frame
+}
+
+// Depth returns the call depth of the frame; the root is 0.
+func (f *Frame) Depth() int {
+	return len(f.Path)
+}
+
+// VisitFunc is called for every frame visited by [Walk] and [WalkBreadthFirst].
+// Returning [SkipChildren] or [SkipAll] controls the walk; any other error
+// stops it and is returned to the caller.
+type VisitFunc func(frame *Frame) error
+
+// Walk visits the tree depth first in pre-order, which is the execution order
+// of the calls and matches the Index assigned by the debug namespace.
+func Walk(root *evmctypes.CallFrame, fn VisitFunc) error {
+	if root == nil {
+		return nil
+	}
+	err := walk(&Frame{CallFrame: root, Path: []uint64{}}, fn)
+	if errors.Is(err, SkipAll) {
+		return nil
+	}
+	return err
+}
+
+func walk(frame *Frame, fn VisitFunc) error {
+	if err := fn(frame); err != nil {
+		if errors.Is(err, SkipChildren) {
+			return nil
+		}
+		return err
+	}
+	for i, call := range frame.Calls {
+		if call == nil {
+			continue
+		}
+		child := &Frame{CallFrame: call, Path: childPath(frame.Path, i), Parent: frame}
+		if err := walk(child, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WalkBreadthFirst visits the tree level by level.
+func WalkBreadthFirst(root *evmctypes.CallFrame, fn VisitFunc) error {
+	if root == nil {
+		return nil
+	}
+	queue := []*Frame{{CallFrame: root, Path: []uint64{}}}
+	for len(queue) > 0 {
+		frame := queue[0]
+		queue = queue[1:]
+		if err := fn(frame); err != nil {
+			if errors.Is(err, SkipChildren) {
+				continue
+			}
+			if errors.Is(err, SkipAll) {
+				return nil
+			}
+			return err
+		}
+		for i, call := range frame.Calls {
+			if call == nil {
+				continue
+			}
+			queue = append(queue, &Frame{CallFrame: call, Path: childPath(frame.Path, i), Parent: frame})
+		}
+	}
+	return nil
+}
+
+// Frames returns every frame of the tree in depth-first pre-order.
+func Frames(root *evmctypes.CallFrame) []*Frame {
+	var frames []*Frame
+	_ = Walk(root, func(frame *Frame) error {
+		frames = append(frames, frame)
+		return nil
+	})
+	return frames
+}
+
+func childPath(parent []uint64, i int) []uint64 {
+	path := slices.Clone(parent)
+	return append(path, uint64(i))
+}
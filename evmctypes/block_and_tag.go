@@ -1,7 +1,19 @@
 package evmctypes
 
-import "github.com/ethereum/go-ethereum/common/hexutil"
+import (
+	"encoding/json"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BlockAndTag identifies a block by tag, hex number or, via [FormatHash], by
+// hash (EIP-1898).
+//
+// A hash identifier holds the JSON text of the EIP-1898 object, so its
+// String form is not a valid block parameter. Pass a BlockAndTag to RPC calls
+// as-is, never converted to a string, so that [BlockAndTag.MarshalJSON]
+// encodes it.
 type BlockAndTag string
 
 const (
@@ -20,11 +32,56 @@ func (b BlockAndTag) String() string {
 	return string(b)
 }
 
+// IsNumber reports whether b is a hex block number.
+func (b BlockAndTag) IsNumber() bool {
+	_, err := b.Uint64()
+	return err == nil
+}
+
+// BlockHash returns the hash of a block identified by hash, either a bare
+// 32-byte hex hash or an identifier made by [FormatHash].
+func (b BlockAndTag) BlockHash() (hash string, requireCanonical bool, ok bool) {
+	if strings.HasPrefix(string(b), "{") {
+		var p blockHashParam
+		if err := json.Unmarshal([]byte(b), &p); err != nil || p.BlockHash == "" {
+			return "", false, false
+		}
+		return p.BlockHash, p.RequireCanonical, true
+	}
+	if len(b) == 66 && strings.HasPrefix(string(b), "0x") {
+		return string(b), false, true
+	}
+	return "", false, false
+}
+
+// MarshalJSON encodes tags, numbers and bare hashes as strings and
+// identifiers made by [FormatHash] as EIP-1898 objects.
+func (b BlockAndTag) MarshalJSON() ([]byte, error) {
+	if strings.HasPrefix(string(b), "{") {
+		if _, _, ok := b.BlockHash(); ok {
+			return []byte(b), nil
+		}
+	}
+	return json.Marshal(string(b))
+}
+
+type blockHashParam struct {
+	BlockHash        string `json:"blockHash"`
+	RequireCanonical bool   `json:"requireCanonical,omitempty"`
+}
+
 // FormatNumber returns a BlockAndTag representing the given block number in hex.
 func FormatNumber(number uint64) BlockAndTag {
 	return BlockAndTag(hexutil.EncodeUint64(number))
 }
 
+// FormatHash returns an EIP-1898 BlockAndTag for the block with hash. With
+// requireCanonical the node rejects blocks that are not canonical.
+func FormatHash(hash string, requireCanonical bool) BlockAndTag {
+	b, _ := json.Marshal(blockHashParam{BlockHash: hash, RequireCanonical: requireCanonical})
+	return BlockAndTag(b)
+}
+
 // ParseBlockAndTag returns the string form of a block tag or number.
 // If v is a BlockAndTag or string, it is returned as-is; otherwise "latest" is returned.
 func ParseBlockAndTag(v any) string {
@@ -37,3 +94,17 @@ func ParseBlockAndTag(v any) string {
 		return Latest.String()
 	}
 }
+
+// ToBlockAndTag is [ParseBlockAndTag] returning a BlockAndTag, to be used as
+// an RPC argument so that identifiers made by [FormatHash] are encoded as
+// EIP-1898 objects.
+func ToBlockAndTag(v any) BlockAndTag {
+	switch val := v.(type) {
+	case BlockAndTag:
+		return val
+	case string:
+		return BlockAndTag(val)
+	default:
+		return Latest
+	}
+}
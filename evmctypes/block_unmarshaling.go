@@ -50,6 +50,11 @@ func (b *block) NextBaseFee() decimal.Decimal {
 	return decimal.NewFromBigInt(hexutil.MustDecodeBig(*b.BaseFeePerGas), 0).Mul(decimal.NewFromInt(2))
 }
 
+// Header returns a copy of the header fields of the block.
+func (b *block) Header() *Header {
+	return &Header{block: *b}
+}
+
 // _block is raw data from the blockchain RPC calls.
 type _block struct {
 	Number           *string  `json:"number"`
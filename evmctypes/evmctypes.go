@@ -258,25 +258,56 @@ func (p *PrestateTracer) ParseDiffFrames() (*PrestateDiffFrame, error) {
 	return &diffFrame, nil
 }
 
-// TODO: WIP
-// DefaultFrame is a response in default tracer.
-//
-//nolint:unused
+// DefaultFrame is a single opcode step of the default struct-logger tracer.
 type DefaultFrame struct {
-	Depth   int         `json:"depth"`
-	Gas     uint64      `json:"gas"`
-	GasCost uint64      `json:"gasCost"`
-	Memory  []string    `json:"memory"`
-	OP      string      `json:"op"`
-	PC      uint64      `json:"pc"`
-	Stack   []string    `json:"stack"`
-	Error   any `json:"error"`
-	Storage any `json:"storage"`
-}
-
-// nolint:unused
+	Depth      int               `json:"depth"`
+	Gas        uint64            `json:"gas"`
+	GasCost    uint64            `json:"gasCost"`
+	Memory     []string          `json:"memory,omitempty"`
+	OP         string            `json:"op"`
+	PC         uint64            `json:"pc"`
+	Stack      []string          `json:"stack,omitempty"`
+	ReturnData string            `json:"returnData,omitempty"`
+	Storage    map[string]string `json:"storage,omitempty"`
+	Refund     uint64            `json:"refund,omitempty"`
+	Error      any               `json:"error,omitempty"`
+}
+
+// StructLogResult is the result of the default struct-logger tracer for a
+// single transaction.
+type StructLogResult struct {
+	Gas         uint64          `json:"gas"`
+	Failed      bool            `json:"failed"`
+	ReturnValue string          `json:"returnValue"`
+	StructLogs  []*DefaultFrame `json:"structLogs"`
+}
+
+type StructLogTracer struct {
+	defaultTraceResult
+	Result *StructLogResult `json:"result,omitempty"`
+}
+
+// FourByteFrame maps "selector-calldatasize" (e.g. "0xa9059cbb-64") to the
+// number of times it was called.
 type FourByteFrame map[string]uint64
 
+type FourByteTracer struct {
+	defaultTraceResult
+	Result FourByteFrame `json:"result,omitempty"`
+}
+
+// MuxFrame is the result of the muxTracer, keyed by the tracers it ran.
+type MuxFrame struct {
+	CallTracer     *CallFrame      `json:"callTracer,omitempty"`
+	PrestateTracer *PrestateResult `json:"prestateTracer,omitempty"`
+	FourByteTracer FourByteFrame   `json:"4byteTracer,omitempty"`
+}
+
+type MuxTracer struct {
+	defaultTraceResult
+	Result *MuxFrame `json:"result,omitempty"`
+}
+
 // Arbitrum
 type EVMTransfer struct {
 	From    *string `json:"from"`
@@ -401,7 +432,7 @@ type Trace struct {
 type SimulateBlockOverride struct {
 	BlockNumber  *string `json:"number,omitempty"`
 	Time         *string `json:"time,omitempty"`
-	Gas          *string `json:"gas,omitempty"`
+	Gas          *string `json:"gasLimit,omitempty"` // block gas limit
 	FeeRecipient *string `json:"feeRecipient,omitempty"`
 	PrevRandao   *string `json:"prevRandao,omitempty"`
 	BaseFeePerGas *string `json:"baseFeePerGas,omitempty"`
@@ -420,6 +451,13 @@ type SimulateAccountOverride struct {
 	StateDiff map[string]string `json:"stateDiff,omitempty"`
 }
 
+// CallOverrides are the optional state and block overrides accepted by
+// eth_call, eth_estimateGas and debug_traceCall.
+type CallOverrides struct {
+	StateOverrides SimulateStateOverride  `json:"stateOverrides,omitempty"`
+	BlockOverrides *SimulateBlockOverride `json:"blockOverrides,omitempty"`
+}
+
 // SimulateCall represents a single call to simulate within a block.
 type SimulateCall struct {
 	From                 *string `json:"from,omitempty"`
@@ -535,6 +535,31 @@ func Test_BlockAndTag(t *testing.T) {
 		assert.Equal(t, uint64(100), n)
 	})
 
+	t.Run("FormatHash", func(t *testing.T) {
+		hash := "0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6"
+		tag := FormatHash(hash, true)
+		got, canonical, ok := tag.BlockHash()
+		require.True(t, ok)
+		assert.Equal(t, hash, got)
+		assert.True(t, canonical)
+		assert.False(t, tag.IsNumber())
+
+		b, err := json.Marshal([]any{tag, Latest, FormatNumber(1)})
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"blockHash":"`+hash+`","requireCanonical":true},"latest","0x1"]`, string(b))
+
+		b, err = json.Marshal(FormatHash(hash, false))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"blockHash":"`+hash+`"}`, string(b))
+
+		// 32바이트 hash 문자열도 hash로 취급한다.
+		_, canonical, ok = BlockAndTag(hash).BlockHash()
+		assert.True(t, ok)
+		assert.False(t, canonical)
+		_, _, ok = Latest.BlockHash()
+		assert.False(t, ok)
+	})
+
 	t.Run("ParseBlockAndTag with BlockAndTag", func(t *testing.T) {
 		result := ParseBlockAndTag(Latest)
 		assert.Equal(t, "latest", result)
@@ -549,6 +574,17 @@ func Test_BlockAndTag(t *testing.T) {
 		result := ParseBlockAndTag(42)
 		assert.Equal(t, "latest", result)
 	})
+
+	t.Run("ToBlockAndTag", func(t *testing.T) {
+		assert.Equal(t, Latest, ToBlockAndTag(Latest))
+		assert.Equal(t, BlockAndTag("0x64"), ToBlockAndTag("0x64"))
+		assert.Equal(t, Latest, ToBlockAndTag(42))
+
+		hash := FormatHash("0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6", true)
+		raw, err := json.Marshal([]any{ToBlockAndTag(hash)})
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"blockHash":"0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6","requireCanonical":true}]`, string(raw))
+	})
 }
 
 func Test_Transaction_EIP1559(t *testing.T) {
@@ -697,3 +733,27 @@ func Test_Block_WithdrawalsAndUncles(t *testing.T) {
 	assert.Equal(t, "0xvalidator", block.Withdrawals[0].Address)
 	assert.Equal(t, uint64(1000000000), block.Withdrawals[0].Amount)
 }
+
+func Test_CallOverrides_MarshalJSON(t *testing.T) {
+	var (
+		balance  = "0x1"
+		number   = "0x64"
+		gasLimit = "0x1c9c380"
+		baseFee  = "0x0"
+	)
+	raw, err := json.Marshal(&CallOverrides{
+		StateOverrides: SimulateStateOverride{"0xabc": {Balance: &balance}},
+		BlockOverrides: &SimulateBlockOverride{BlockNumber: &number, Gas: &gasLimit, BaseFeePerGas: &baseFee},
+	})
+	require.NoError(t, err)
+
+	var decoded map[string]map[string]any
+	require.NoError(t, json.Unmarshal(raw, &decoded))
+	assert.Contains(t, decoded, "stateOverrides")
+	// 노드는 block override의 가스 한도를 gasLimit 키로만 읽는다.
+	assert.Equal(t, map[string]any{
+		"number":        number,
+		"gasLimit":      gasLimit,
+		"baseFeePerGas": baseFee,
+	}, decoded["blockOverrides"])
+}
@@ -0,0 +1,393 @@
+package kaiatypes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// AccountKeyType identifies the kind of key attached to a Kaia account.
+type AccountKeyType uint8
+
+const (
+	AccountKeyTypeNil              AccountKeyType = 0x00
+	AccountKeyTypeLegacy           AccountKeyType = 0x01
+	AccountKeyTypePublic           AccountKeyType = 0x02
+	AccountKeyTypeFail             AccountKeyType = 0x03
+	AccountKeyTypeWeightedMultiSig AccountKeyType = 0x04
+	AccountKeyTypeRoleBased        AccountKeyType = 0x05
+)
+
+// Roles of the keys of an [AccountKeyRoleBased], in encoding order.
+const (
+	RoleTransaction = iota
+	RoleAccountUpdate
+	RoleFeePayer
+)
+
+var errInvalidAccountKey = errors.New("invalid account key")
+
+// AccountKey is one of the Kaia account key types. Use [EncodeAccountKey] to
+// obtain the RLP encoding carried by account update transactions.
+type AccountKey interface {
+	KeyType() AccountKeyType
+	encode() ([]byte, error)
+}
+
+// AccountKeyNil keeps the current key; it is only valid in account updates.
+type AccountKeyNil struct{}
+
+// AccountKeyLegacy derives the account address from the signing key, as on
+// Ethereum.
+type AccountKeyLegacy struct{}
+
+// AccountKeyPublic is a single public key decoupled from the address.
+// PublicKey is hex encoded, compressed (33 bytes) or uncompressed (64 or 65
+// bytes).
+type AccountKeyPublic struct {
+	PublicKey string
+}
+
+// AccountKeyFail makes every transaction from the account fail.
+type AccountKeyFail struct{}
+
+// WeightedPublicKey is a member of an [AccountKeyWeightedMultiSig].
+type WeightedPublicKey struct {
+	Weight    uint64
+	PublicKey string
+}
+
+// AccountKeyWeightedMultiSig accepts signatures whose total weight reaches
+// Threshold.
+type AccountKeyWeightedMultiSig struct {
+	Threshold    uint64
+	WeightedKeys []WeightedPublicKey
+}
+
+// AccountKeyRoleBased assigns a key per role, indexed by [RoleTransaction],
+// [RoleAccountUpdate] and [RoleFeePayer]. Trailing roles may be omitted, and
+// an [AccountKeyNil] keeps the current key of that role.
+type AccountKeyRoleBased struct {
+	Keys []AccountKey
+}
+
+func (AccountKeyNil) KeyType() AccountKeyType              { return AccountKeyTypeNil }
+func (AccountKeyLegacy) KeyType() AccountKeyType           { return AccountKeyTypeLegacy }
+func (AccountKeyPublic) KeyType() AccountKeyType           { return AccountKeyTypePublic }
+func (AccountKeyFail) KeyType() AccountKeyType             { return AccountKeyTypeFail }
+func (AccountKeyWeightedMultiSig) KeyType() AccountKeyType { return AccountKeyTypeWeightedMultiSig }
+func (AccountKeyRoleBased) KeyType() AccountKeyType        { return AccountKeyTypeRoleBased }
+
+func (AccountKeyNil) encode() ([]byte, error) {
+	return []byte{0x80}, nil
+}
+
+func (AccountKeyLegacy) encode() ([]byte, error) {
+	return []byte{byte(AccountKeyTypeLegacy), 0xc0}, nil
+}
+
+func (k AccountKeyPublic) encode() ([]byte, error) {
+	pub, err := compressPublicKey(k.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return typedRLP(AccountKeyTypePublic, pub)
+}
+
+func (AccountKeyFail) encode() ([]byte, error) {
+	return []byte{byte(AccountKeyTypeFail), 0xc0}, nil
+}
+
+func (k AccountKeyWeightedMultiSig) encode() ([]byte, error) {
+	if k.Threshold == 0 || len(k.WeightedKeys) == 0 {
+		return nil, fmt.Errorf("%w: empty multisig", errInvalidAccountKey)
+	}
+	keys := make([][]any, len(k.WeightedKeys))
+	for i, wk := range k.WeightedKeys {
+		pub, err := compressPublicKey(wk.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = []any{wk.Weight, pub}
+	}
+	return typedRLP(AccountKeyTypeWeightedMultiSig, []any{k.Threshold, keys})
+}
+
+func (k AccountKeyRoleBased) encode() ([]byte, error) {
+	if len(k.Keys) == 0 || len(k.Keys) > RoleFeePayer+1 {
+		return nil, fmt.Errorf("%w: %d roles", errInvalidAccountKey, len(k.Keys))
+	}
+	roles := make([][]byte, len(k.Keys))
+	for i, key := range k.Keys {
+		if key == nil || key.KeyType() == AccountKeyTypeRoleBased {
+			return nil, fmt.Errorf("%w: role %d", errInvalidAccountKey, i)
+		}
+		enc, err := key.encode()
+		if err != nil {
+			return nil, err
+		}
+		roles[i] = enc
+	}
+	return typedRLP(AccountKeyTypeRoleBased, roles)
+}
+
+// EncodeAccountKey returns the RLP encoding of key.
+func EncodeAccountKey(key AccountKey) ([]byte, error) {
+	if key == nil {
+		return nil, fmt.Errorf("%w: nil", errInvalidAccountKey)
+	}
+	return key.encode()
+}
+
+// DecodeAccountKey decodes the RLP encoding of an account key.
+func DecodeAccountKey(b []byte) (AccountKey, error) {
+	if len(b) == 0 {
+		return nil, fmt.Errorf("%w: empty", errInvalidAccountKey)
+	}
+	if len(b) == 1 && b[0] == 0x80 {
+		return AccountKeyNil{}, nil
+	}
+	body := b[1:]
+	switch AccountKeyType(b[0]) {
+	case AccountKeyTypeLegacy:
+		return AccountKeyLegacy{}, nil
+	case AccountKeyTypeFail:
+		return AccountKeyFail{}, nil
+	case AccountKeyTypePublic:
+		var pub []byte
+		if err := rlp.DecodeBytes(body, &pub); err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidAccountKey, err)
+		}
+		return AccountKeyPublic{PublicKey: hexutil.Encode(pub)}, nil
+	case AccountKeyTypeWeightedMultiSig:
+		var dec struct {
+			Threshold uint64
+			Keys      []struct {
+				Weight    uint64
+				PublicKey []byte
+			}
+		}
+		if err := rlp.DecodeBytes(body, &dec); err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidAccountKey, err)
+		}
+		key := AccountKeyWeightedMultiSig{Threshold: dec.Threshold, WeightedKeys: make([]WeightedPublicKey, len(dec.Keys))}
+		for i, k := range dec.Keys {
+			key.WeightedKeys[i] = WeightedPublicKey{Weight: k.Weight, PublicKey: hexutil.Encode(k.PublicKey)}
+		}
+		return key, nil
+	case AccountKeyTypeRoleBased:
+		var roles [][]byte
+		if err := rlp.DecodeBytes(body, &roles); err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidAccountKey, err)
+		}
+		key := AccountKeyRoleBased{Keys: make([]AccountKey, len(roles))}
+		for i, role := range roles {
+			roleKey, err := DecodeAccountKey(role)
+			if err != nil {
+				return nil, err
+			}
+			key.Keys[i] = roleKey
+		}
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: type %#x", errInvalidAccountKey, b[0])
+}
+
+func typedRLP(t AccountKeyType, v any) ([]byte, error) {
+	enc, err := rlp.EncodeToBytes(v)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{byte(t)}, enc...), nil
+}
+
+// compressPublicKey returns the 33-byte compressed form of a hex encoded
+// public key.
+func compressPublicKey(s string) ([]byte, error) {
+	b, err := hexutil.Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidAccountKey, err)
+	}
+	switch len(b) {
+	case 33:
+		if _, err := crypto.DecompressPubkey(b); err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidAccountKey, err)
+		}
+		return b, nil
+	case 64:
+		b = append([]byte{0x04}, b...)
+	}
+	pub, err := crypto.UnmarshalPubkey(b)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidAccountKey, err)
+	}
+	return crypto.CompressPubkey(pub), nil
+}
+
+// accountKeyJSON is the JSON shape of an account key returned by
+// kaia_getAccountKey and kaia_getAccount.
+type accountKeyJSON struct {
+	KeyType AccountKeyType  `json:"keyType"`
+	Key     json.RawMessage `json:"key"`
+}
+
+type publicKeyJSON struct {
+	X string `json:"x"`
+	Y string `json:"y"`
+}
+
+func (p publicKeyJSON) hex() (string, error) {
+	x, err := hexutil.DecodeBig(p.X)
+	if err != nil {
+		return "", fmt.Errorf("%w: x: %w", errInvalidAccountKey, err)
+	}
+	y, err := hexutil.DecodeBig(p.Y)
+	if err != nil {
+		return "", fmt.Errorf("%w: y: %w", errInvalidAccountKey, err)
+	}
+	pub := append([]byte{0x04}, common.LeftPadBytes(x.Bytes(), 32)...)
+	return hexutil.Encode(append(pub, common.LeftPadBytes(y.Bytes(), 32)...)), nil
+}
+
+// UnmarshalAccountKey decodes the JSON form of an account key, e.g.
+// {"keyType":2,"key":{"x":"0x..","y":"0x.."}}. Public keys are returned
+// uncompressed.
+func UnmarshalAccountKey(input []byte) (AccountKey, error) {
+	var dec accountKeyJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return nil, err
+	}
+	switch dec.KeyType {
+	case AccountKeyTypeNil:
+		return AccountKeyNil{}, nil
+	case AccountKeyTypeLegacy:
+		return AccountKeyLegacy{}, nil
+	case AccountKeyTypeFail:
+		return AccountKeyFail{}, nil
+	case AccountKeyTypePublic:
+		var pub publicKeyJSON
+		if err := json.Unmarshal(dec.Key, &pub); err != nil {
+			return nil, err
+		}
+		hex, err := pub.hex()
+		if err != nil {
+			return nil, err
+		}
+		return AccountKeyPublic{PublicKey: hex}, nil
+	case AccountKeyTypeWeightedMultiSig:
+		var multisig struct {
+			Threshold uint64 `json:"threshold"`
+			Keys      []struct {
+				Weight uint64        `json:"weight"`
+				Key    publicKeyJSON `json:"key"`
+			} `json:"keys"`
+		}
+		if err := json.Unmarshal(dec.Key, &multisig); err != nil {
+			return nil, err
+		}
+		key := AccountKeyWeightedMultiSig{Threshold: multisig.Threshold, WeightedKeys: make([]WeightedPublicKey, len(multisig.Keys))}
+		for i, k := range multisig.Keys {
+			hex, err := k.Key.hex()
+			if err != nil {
+				return nil, err
+			}
+			key.WeightedKeys[i] = WeightedPublicKey{Weight: k.Weight, PublicKey: hex}
+		}
+		return key, nil
+	case AccountKeyTypeRoleBased:
+		var roles []json.RawMessage
+		if err := json.Unmarshal(dec.Key, &roles); err != nil {
+			return nil, err
+		}
+		key := AccountKeyRoleBased{Keys: make([]AccountKey, len(roles))}
+		for i, role := range roles {
+			roleKey, err := UnmarshalAccountKey(role)
+			if err != nil {
+				return nil, err
+			}
+			key.Keys[i] = roleKey
+		}
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: type %d", errInvalidAccountKey, dec.KeyType)
+}
+
+// RoleKey returns the key that validates signatures for role. Roles missing
+// from an [AccountKeyRoleBased] fall back to the transaction role; other keys
+// are used for every role.
+func RoleKey(key AccountKey, role int) AccountKey {
+	roleBased, ok := key.(AccountKeyRoleBased)
+	if !ok {
+		return key
+	}
+	if role < len(roleBased.Keys) {
+		return roleBased.Keys[role]
+	}
+	if len(roleBased.Keys) > 0 {
+		return roleBased.Keys[RoleTransaction]
+	}
+	return AccountKeyFail{}
+}
+
+// CanSign reports whether signatures by the signers addresses are enough to
+// act for account in role under key. A legacy key accepts the account itself,
+// and a weighted multisig key requires the signers' weights to reach the
+// threshold.
+func CanSign(key AccountKey, account string, role int, signers ...string) bool {
+	isSigner := func(address string) bool {
+		for _, signer := range signers {
+			if common.HexToAddress(signer) == common.HexToAddress(address) {
+				return true
+			}
+		}
+		return false
+	}
+	switch k := RoleKey(key, role).(type) {
+	case AccountKeyLegacy:
+		return isSigner(account)
+	case AccountKeyPublic:
+		address, err := PublicKeyAddress(k.PublicKey)
+		return err == nil && isSigner(address)
+	case AccountKeyWeightedMultiSig:
+		var weight uint64
+		for _, wk := range k.WeightedKeys {
+			address, err := PublicKeyAddress(wk.PublicKey)
+			if err == nil && isSigner(address) {
+				weight += wk.Weight
+			}
+		}
+		return k.Threshold > 0 && weight >= k.Threshold
+	}
+	return false
+}
+
+// PublicKeyAddress returns the address derived from a hex encoded public
+// key, compressed or uncompressed.
+func PublicKeyAddress(publicKey string) (string, error) {
+	compressed, err := compressPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	pub, err := crypto.DecompressPubkey(compressed)
+	if err != nil {
+		return "", err
+	}
+	return crypto.PubkeyToAddress(*pub).Hex(), nil
+}
+
+// AccountKey decodes the new key of an account update transaction.
+func (t *Transaction) AccountKey() (AccountKey, error) {
+	if t.Key == "" {
+		return nil, nil
+	}
+	b, err := hexutil.Decode(t.Key)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeAccountKey(b)
+}
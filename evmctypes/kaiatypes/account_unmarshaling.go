@@ -0,0 +1,75 @@
+package kaiatypes
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/shopspring/decimal"
+)
+
+func (a *Account) UnmarshalJSON(input []byte) error {
+	type account struct {
+		Nonce         *uint64         `json:"nonce"`
+		Balance       *string         `json:"balance"`
+		HumanReadable *bool           `json:"humanReadable"`
+		Key           json.RawMessage `json:"key"`
+		StorageRoot   *string         `json:"storageRoot"`
+		CodeHash      *string         `json:"codeHash"`
+		CodeFormat    *uint64         `json:"codeFormat"`
+		VMVersion     *uint64         `json:"vmVersion"`
+	}
+	var dec struct {
+		AccType *uint64 `json:"accType"`
+		Account account `json:"account"`
+	}
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.AccType != nil {
+		a.AccType = *dec.AccType
+	}
+	if dec.Account.Nonce != nil {
+		a.Nonce = *dec.Account.Nonce
+	}
+	if dec.Account.Balance != nil {
+		balance, err := hexutil.DecodeBig(*dec.Account.Balance)
+		if err != nil {
+			return err
+		}
+		a.Balance = decimal.NewFromBigInt(balance, 0)
+	}
+	if dec.Account.HumanReadable != nil {
+		a.HumanReadable = *dec.Account.HumanReadable
+	}
+	if len(dec.Account.Key) > 0 && string(dec.Account.Key) != "null" {
+		key, err := UnmarshalAccountKey(dec.Account.Key)
+		if err != nil {
+			return err
+		}
+		a.Key = key
+	}
+	if dec.Account.StorageRoot != nil {
+		a.StorageRoot = *dec.Account.StorageRoot
+	}
+	if dec.Account.CodeHash != nil {
+		// The node serializes the code hash as base64 encoded bytes.
+		codeHash := *dec.Account.CodeHash
+		if !strings.HasPrefix(codeHash, "0x") {
+			b, err := base64.StdEncoding.DecodeString(codeHash)
+			if err != nil {
+				return err
+			}
+			codeHash = hexutil.Encode(b)
+		}
+		a.CodeHash = codeHash
+	}
+	if dec.Account.CodeFormat != nil {
+		a.CodeFormat = *dec.Account.CodeFormat
+	}
+	if dec.Account.VMVersion != nil {
+		a.VMVersion = *dec.Account.VMVersion
+	}
+	return nil
+}
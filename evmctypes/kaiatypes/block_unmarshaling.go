@@ -171,3 +171,47 @@ func (b *BlockIncTx) UnmarshalJSON(input []byte) error {
 	}
 	return nil
 }
+
+func (b *BlockWithConsensusInfo) UnmarshalJSON(input []byte) error {
+	type block struct {
+		_block
+		Transactions   []*Transaction  `json:"transactions"`
+		Committee      []string        `json:"committee"`
+		Committers     []string        `json:"committers"`
+		Proposer       *string         `json:"proposer"`
+		OriginProposer *string         `json:"originProposer"`
+		Round          json.RawMessage `json:"round"`
+		SigHash        *string         `json:"sigHash"`
+	}
+	var dec block
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if err := dec.unmarshal(&b.block); err != nil {
+		return err
+	}
+	b.Transactions = dec.Transactions
+	b.Committee = dec.Committee
+	b.Committers = dec.Committers
+	if dec.Proposer != nil {
+		b.Proposer = *dec.Proposer
+	}
+	if dec.OriginProposer != nil {
+		b.OriginProposer = *dec.OriginProposer
+	}
+	if dec.SigHash != nil {
+		b.SigHash = *dec.SigHash
+	}
+	// round is a plain JSON number on current nodes; older versions encode
+	// it as a hex quantity.
+	if len(dec.Round) > 0 && string(dec.Round) != "null" {
+		if err := json.Unmarshal(dec.Round, &b.Round); err != nil {
+			var round hexutil.Uint64
+			if err := json.Unmarshal(dec.Round, &round); err != nil {
+				return err
+			}
+			b.Round = uint64(round)
+		}
+	}
+	return nil
+}
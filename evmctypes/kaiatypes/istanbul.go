@@ -0,0 +1,93 @@
+package kaiatypes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// istanbulExtraVanity is the fixed number of vanity bytes preceding the RLP
+// encoded IstanbulExtra in extraData.
+const istanbulExtraVanity = 32
+
+var errInvalidExtraData = errors.New("invalid istanbul extraData")
+
+// IstanbulExtra decodes the validators and seals stored in extraData.
+func (b *block) IstanbulExtra() (*IstanbulExtra, error) {
+	data, err := hexutil.Decode(b.ExtraData)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < istanbulExtraVanity {
+		return nil, fmt.Errorf("%w: %d bytes", errInvalidExtraData, len(data))
+	}
+	var dec struct {
+		Validators    []common.Address
+		Seal          []byte
+		CommittedSeal [][]byte
+	}
+	if err := rlp.DecodeBytes(data[istanbulExtraVanity:], &dec); err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidExtraData, err)
+	}
+	extra := &IstanbulExtra{
+		Vanity:        data[:istanbulExtraVanity],
+		Validators:    make([]string, len(dec.Validators)),
+		Seal:          dec.Seal,
+		CommittedSeal: dec.CommittedSeal,
+	}
+	for i, v := range dec.Validators {
+		extra.Validators[i] = v.Hex()
+	}
+	return extra, nil
+}
+
+// Governance decodes governanceData, the JSON encoded governance parameters
+// that take effect from this block. It returns nil when the block carries
+// no governance update.
+func (b *block) Governance() (map[string]any, error) {
+	data, err := decodeOptionalHex(b.GovernanceData)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	var encoded []byte
+	if err := rlp.DecodeBytes(data, &encoded); err != nil {
+		return nil, err
+	}
+	var governance map[string]any
+	if err := json.Unmarshal(encoded, &governance); err != nil {
+		return nil, err
+	}
+	return governance, nil
+}
+
+// Vote decodes voteData. It returns nil when the proposer did not vote.
+func (b *block) Vote() (*Vote, error) {
+	data, err := decodeOptionalHex(b.VoteData)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	var dec struct {
+		Validator common.Address
+		Key       string
+		Value     rlp.RawValue
+	}
+	if err := rlp.DecodeBytes(data, &dec); err != nil {
+		return nil, err
+	}
+	vote := &Vote{Validator: dec.Validator.Hex(), Key: dec.Key, Value: dec.Value}
+	if kind, content, _, err := rlp.Split(dec.Value); err == nil && kind != rlp.List {
+		vote.Value = content
+	}
+	return vote, nil
+}
+
+func decodeOptionalHex(s string) ([]byte, error) {
+	if s == "" || s == "0x" {
+		return nil, nil
+	}
+	return hexutil.Decode(s)
+}
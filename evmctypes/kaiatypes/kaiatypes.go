@@ -119,3 +119,61 @@ type Rewards struct {
 	Stakers  *big.Int            `json:"stakers" validate:"required"`
 	TotalFee *big.Int            `json:"totalFee" validate:"required"`
 }
+
+// BlockWithConsensusInfo is a block returned by
+// kaia_getBlockWithConsensusInfoByNumber/Hash, with the Istanbul BFT
+// consensus details of the round that produced it.
+type BlockWithConsensusInfo struct {
+	block
+	Transactions   []*Transaction `json:"transactions"`
+	Committee      []string       `json:"committee"`
+	Committers     []string       `json:"committers,omitempty"`
+	Proposer       string         `json:"proposer"`
+	OriginProposer string         `json:"originProposer"`
+	Round          uint64         `json:"round"`
+	SigHash        string         `json:"sigHash"`
+}
+
+// IstanbulExtra is the decoded extraData of a Kaia header.
+type IstanbulExtra struct {
+	Vanity        []byte   // first 32 bytes of extraData
+	Validators    []string // validator set after this block
+	Seal          []byte   // proposer signature
+	CommittedSeal [][]byte // committee signatures
+}
+
+// Vote is the decoded voteData of a Kaia header: a governance vote cast by
+// the block proposer.
+type Vote struct {
+	Validator string
+	Key       string
+	Value     []byte // RLP string content, or the raw RLP list for list values
+}
+
+// Account is the state of a Kaia account returned by kaia_getAccount.
+type Account struct {
+	AccType       uint64          `json:"accType"` // 1: externally owned, 2: smart contract
+	Nonce         uint64          `json:"nonce"`
+	Balance       decimal.Decimal `json:"balance"`
+	HumanReadable bool            `json:"humanReadable"`
+	Key           AccountKey      `json:"key"`
+
+	// smart contract accounts only
+	StorageRoot string `json:"storageRoot,omitempty"`
+	CodeHash    string `json:"codeHash,omitempty"`
+	CodeFormat  uint64 `json:"codeFormat,omitempty"`
+	VMVersion   uint64 `json:"vmVersion,omitempty"`
+}
+
+// AnchoringData is the service chain data carried by a chain data anchoring
+// transaction.
+type AnchoringData struct {
+	BlockHash     string   `json:"BlockHash"`
+	TxHash        string   `json:"TxHash"`
+	ParentHash    string   `json:"ParentHash"`
+	ReceiptHash   string   `json:"ReceiptHash"`
+	StateRootHash string   `json:"StateRootHash"`
+	BlockNumber   *big.Int `json:"BlockNumber"`
+	BlockCount    *big.Int `json:"BlockCount"`
+	TxCount       *big.Int `json:"TxCount"`
+}
@@ -0,0 +1,52 @@
+package evmcutils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	opPush1  = 0x60
+	opPush4  = 0x63
+	opPush32 = 0x7f
+)
+
+// ExtractSelectors scans runtime bytecode and returns every 4-byte value pushed
+// with PUSH4, in order of first appearance. Solidity and Vyper dispatchers
+// compare calldata against function selectors pushed this way, so the result is
+// a superset of the contract's external functions. Immediate data of other PUSH
+// opcodes is skipped so it is never misread as an opcode.
+func ExtractSelectors(code string) ([]string, error) {
+	if code == "" || code == "0x" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(code, "0x") {
+		code = "0x" + code
+	}
+	b, err := hexutil.Decode(code)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		seen      = make(map[string]struct{})
+		selectors []string
+	)
+	for i := 0; i < len(b); i++ {
+		op := b[i]
+		if op < opPush1 || op > opPush32 {
+			continue
+		}
+		size := int(op-opPush1) + 1
+		if op == opPush4 && i+size < len(b) {
+			sel := fmt.Sprintf("0x%x", b[i+1:i+1+size])
+			if _, ok := seen[sel]; !ok {
+				seen[sel] = struct{}{}
+				selectors = append(selectors, sel)
+			}
+		}
+		i += size
+	}
+	return selectors, nil
+}
@@ -0,0 +1,55 @@
+package evmcutils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractSelectors(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "empty code",
+			code: "0x",
+			want: nil,
+		},
+		{
+			name: "dispatcher",
+			// PUSH1 0x80 PUSH1 0x40 MSTORE PUSH4 a9059cbb EQ PUSH4 70a08231 EQ PUSH4 a9059cbb
+			code: "0x608060405263a9059cbb146370a082311463a9059cbb",
+			want: []string{"0xa9059cbb", "0x70a08231"},
+		},
+		{
+			name: "push32 immediate is skipped",
+			code: "0x7f63deadbeef" + strings.Repeat("00", 27) + "6306fdde03",
+			want: []string{"0x06fdde03"},
+		},
+		{
+			name: "truncated push4",
+			code: "0x6306fd",
+			want: nil,
+		},
+		{
+			name:    "invalid hex",
+			code:    "0xzz",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExtractSelectors(tt.code)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -4,16 +4,21 @@ package evmc
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/bbaktaeho/evmc/evmctypes"
 	"github.com/bbaktaeho/evmc/evmctypes/kaiatypes"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
 type kaiaNamespace struct {
-	c caller
+	info clientInfo
+	c    caller
+	s    subscriber
 }
 
 func (k *kaiaNamespace) GetBlockIncTxRange(from, to uint64) ([]*kaiatypes.BlockIncTx, error) {
@@ -54,6 +59,43 @@ func (k *kaiaNamespace) getBlockIncTxRange(ctx context.Context, from, to uint64)
 	return results, nil
 }
 
+// GetBlockRange returns the blocks from..to with transaction hashes only.
+func (k *kaiaNamespace) GetBlockRange(from, to uint64) ([]*kaiatypes.Block, error) {
+	return k.GetBlockRangeWithContext(context.Background(), from, to)
+}
+
+// GetBlockRangeWithContext returns the blocks from..to with transaction
+// hashes only.
+func (k *kaiaNamespace) GetBlockRangeWithContext(ctx context.Context, from, to uint64) ([]*kaiatypes.Block, error) {
+	if from > to {
+		return nil, ErrInvalidRange
+	}
+	var (
+		size     = to - from + 1
+		results  = make([]*kaiatypes.Block, size)
+		elements = make([]rpc.BatchElem, size)
+	)
+	for i := range elements {
+		elements[i] = rpc.BatchElem{
+			Method: KaiaGetBlockByNumber.String(),
+			Args:   []any{evmctypes.FormatNumber(from + uint64(i)), false},
+			Result: &results[i],
+		}
+	}
+	if err := k.c.BatchCallWithContext(ctx, elements, -1); err != nil {
+		return nil, err
+	}
+	for i, el := range elements {
+		if el.Error != nil {
+			return nil, el.Error
+		}
+		if results[i] == nil || results[i].Hash == "" {
+			return nil, fmt.Errorf("block %d not found", from+uint64(i))
+		}
+	}
+	return results, nil
+}
+
 // BlockNumber returns the current block number.
 func (k *kaiaNamespace) BlockNumber() (uint64, error) {
 	return k.BlockNumberWithContext(context.Background())
@@ -169,6 +211,30 @@ func (k *kaiaNamespace) getBlockReceipts(ctx context.Context, blockNumber uint64
 	return *result, nil
 }
 
+// GetBlockReceiptsByTag returns the receipts for all transactions in the
+// block identified by tag, number or hash. The pending block is not
+// supported.
+func (k *kaiaNamespace) GetBlockReceiptsByTag(blockAndTag evmctypes.BlockAndTag) ([]*kaiatypes.Receipt, error) {
+	return k.GetBlockReceiptsByTagWithContext(context.Background(), blockAndTag)
+}
+
+// GetBlockReceiptsByTagWithContext returns the receipts for all transactions
+// in the block identified by tag, number or hash. The pending block is not
+// supported.
+func (k *kaiaNamespace) GetBlockReceiptsByTagWithContext(
+	ctx context.Context,
+	blockAndTag evmctypes.BlockAndTag,
+) ([]*kaiatypes.Receipt, error) {
+	if blockAndTag == evmctypes.Pending {
+		return nil, ErrPendingBlockNotSupported
+	}
+	var result []*kaiatypes.Receipt
+	if err := k.c.call(ctx, &result, KaiaGetBlockReceipts, blockAndTag); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // GetTransactionReceipt returns the receipt for a transaction by transaction hash.
 func (k *kaiaNamespace) GetTransactionReceipt(hash string) (*kaiatypes.Receipt, error) {
 	return k.GetTransactionReceiptWithContext(context.Background(), hash)
@@ -240,3 +306,665 @@ func (k *kaiaNamespace) getRewards(ctx context.Context, blockNumber uint64) (*ka
 	}
 	return &result, nil
 }
+
+// GetHeaderByNumber returns the header of a block by block number.
+func (k *kaiaNamespace) GetHeaderByNumber(blockNumber uint64) (*kaiatypes.Header, error) {
+	return k.GetHeaderByNumberWithContext(context.Background(), blockNumber)
+}
+
+// GetHeaderByNumberWithContext returns the header of a block by block number.
+func (k *kaiaNamespace) GetHeaderByNumberWithContext(ctx context.Context, blockNumber uint64) (*kaiatypes.Header, error) {
+	header := new(kaiatypes.Header)
+	if err := k.c.call(ctx, header, KaiaGetHeaderByNumber, evmctypes.FormatNumber(blockNumber)); err != nil {
+		return nil, err
+	}
+	if header.Hash == "" {
+		return nil, fmt.Errorf("header %d not found", blockNumber)
+	}
+	return header, nil
+}
+
+// GetHeaderByHash returns the header of a block by hash.
+func (k *kaiaNamespace) GetHeaderByHash(hash string) (*kaiatypes.Header, error) {
+	return k.GetHeaderByHashWithContext(context.Background(), hash)
+}
+
+// GetHeaderByHashWithContext returns the header of a block by hash.
+func (k *kaiaNamespace) GetHeaderByHashWithContext(ctx context.Context, hash string) (*kaiatypes.Header, error) {
+	header := new(kaiatypes.Header)
+	if err := k.c.call(ctx, header, KaiaGetHeaderByHash, hash); err != nil {
+		return nil, err
+	}
+	if header.Hash == "" {
+		return nil, fmt.Errorf("header %s not found", hash)
+	}
+	return header, nil
+}
+
+// GetBlockWithConsensusInfoByNumber returns a block with its proposer,
+// committee and round.
+func (k *kaiaNamespace) GetBlockWithConsensusInfoByNumber(blockNumber uint64) (*kaiatypes.BlockWithConsensusInfo, error) {
+	return k.GetBlockWithConsensusInfoByNumberWithContext(context.Background(), blockNumber)
+}
+
+// GetBlockWithConsensusInfoByNumberWithContext returns a block with its
+// proposer, committee and round.
+func (k *kaiaNamespace) GetBlockWithConsensusInfoByNumberWithContext(
+	ctx context.Context,
+	blockNumber uint64,
+) (*kaiatypes.BlockWithConsensusInfo, error) {
+	block := new(kaiatypes.BlockWithConsensusInfo)
+	if err := k.c.call(ctx, block, KaiaGetBlockWithConsensusInfoByNumber, evmctypes.FormatNumber(blockNumber)); err != nil {
+		return nil, err
+	}
+	if block.Hash == "" {
+		return nil, fmt.Errorf("block %d not found", blockNumber)
+	}
+	return block, nil
+}
+
+// GetBlockWithConsensusInfoByHash returns a block with its proposer,
+// committee and round.
+func (k *kaiaNamespace) GetBlockWithConsensusInfoByHash(hash string) (*kaiatypes.BlockWithConsensusInfo, error) {
+	return k.GetBlockWithConsensusInfoByHashWithContext(context.Background(), hash)
+}
+
+// GetBlockWithConsensusInfoByHashWithContext returns a block with its
+// proposer, committee and round.
+func (k *kaiaNamespace) GetBlockWithConsensusInfoByHashWithContext(
+	ctx context.Context,
+	hash string,
+) (*kaiatypes.BlockWithConsensusInfo, error) {
+	block := new(kaiatypes.BlockWithConsensusInfo)
+	if err := k.c.call(ctx, block, KaiaGetBlockWithConsensusInfoByHash, hash); err != nil {
+		return nil, err
+	}
+	if block.Hash == "" {
+		return nil, fmt.Errorf("block %s not found", hash)
+	}
+	return block, nil
+}
+
+// GetBlockWithConsensusInfoRange returns the blocks from..to with consensus
+// information. Unlike kaia_getBlockWithConsensusInfoByNumberRange, which is
+// capped at 50 blocks, the range is fetched as batched per-block calls.
+func (k *kaiaNamespace) GetBlockWithConsensusInfoRange(from, to uint64) ([]*kaiatypes.BlockWithConsensusInfo, error) {
+	return k.GetBlockWithConsensusInfoRangeWithContext(context.Background(), from, to)
+}
+
+// GetBlockWithConsensusInfoRangeWithContext returns the blocks from..to with
+// consensus information.
+func (k *kaiaNamespace) GetBlockWithConsensusInfoRangeWithContext(
+	ctx context.Context,
+	from, to uint64,
+) ([]*kaiatypes.BlockWithConsensusInfo, error) {
+	if from > to {
+		return nil, ErrInvalidRange
+	}
+	var (
+		size     = to - from + 1
+		results  = make([]*kaiatypes.BlockWithConsensusInfo, size)
+		elements = make([]rpc.BatchElem, size)
+	)
+	for i := range elements {
+		elements[i] = rpc.BatchElem{
+			Method: KaiaGetBlockWithConsensusInfoByNumber.String(),
+			Args:   []any{evmctypes.FormatNumber(from + uint64(i))},
+			Result: &results[i],
+		}
+	}
+	if err := k.c.BatchCallWithContext(ctx, elements, -1); err != nil {
+		return nil, err
+	}
+	for i, el := range elements {
+		if el.Error != nil {
+			return nil, el.Error
+		}
+		if results[i] == nil || results[i].Hash == "" {
+			return nil, fmt.Errorf("block %d not found", from+uint64(i))
+		}
+	}
+	return results, nil
+}
+
+// GetCommittee returns the addresses of the committee members that
+// validated the given block.
+func (k *kaiaNamespace) GetCommittee(blockAndTag evmctypes.BlockAndTag) ([]string, error) {
+	return k.GetCommitteeWithContext(context.Background(), blockAndTag)
+}
+
+// GetCommitteeWithContext returns the addresses of the committee members
+// that validated the given block.
+func (k *kaiaNamespace) GetCommitteeWithContext(ctx context.Context, blockAndTag evmctypes.BlockAndTag) ([]string, error) {
+	return k.getValidators(ctx, KaiaGetCommittee, blockAndTag)
+}
+
+// GetCommitteeSize returns the size of the committee at the given block.
+func (k *kaiaNamespace) GetCommitteeSize(blockAndTag evmctypes.BlockAndTag) (uint64, error) {
+	return k.GetCommitteeSizeWithContext(context.Background(), blockAndTag)
+}
+
+// GetCommitteeSizeWithContext returns the size of the committee at the given
+// block.
+func (k *kaiaNamespace) GetCommitteeSizeWithContext(ctx context.Context, blockAndTag evmctypes.BlockAndTag) (uint64, error) {
+	return k.getValidatorsSize(ctx, KaiaGetCommitteeSize, blockAndTag)
+}
+
+// GetCouncil returns the addresses of the validator council at the given
+// block.
+func (k *kaiaNamespace) GetCouncil(blockAndTag evmctypes.BlockAndTag) ([]string, error) {
+	return k.GetCouncilWithContext(context.Background(), blockAndTag)
+}
+
+// GetCouncilWithContext returns the addresses of the validator council at
+// the given block.
+func (k *kaiaNamespace) GetCouncilWithContext(ctx context.Context, blockAndTag evmctypes.BlockAndTag) ([]string, error) {
+	return k.getValidators(ctx, KaiaGetCouncil, blockAndTag)
+}
+
+// GetCouncilSize returns the size of the validator council at the given
+// block.
+func (k *kaiaNamespace) GetCouncilSize(blockAndTag evmctypes.BlockAndTag) (uint64, error) {
+	return k.GetCouncilSizeWithContext(context.Background(), blockAndTag)
+}
+
+// GetCouncilSizeWithContext returns the size of the validator council at the
+// given block.
+func (k *kaiaNamespace) GetCouncilSizeWithContext(ctx context.Context, blockAndTag evmctypes.BlockAndTag) (uint64, error) {
+	return k.getValidatorsSize(ctx, KaiaGetCouncilSize, blockAndTag)
+}
+
+func (k *kaiaNamespace) getValidators(ctx context.Context, method Procedure, blockAndTag evmctypes.BlockAndTag) ([]string, error) {
+	var result []string
+	if err := k.c.call(ctx, &result, method, blockAndTag); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (k *kaiaNamespace) getValidatorsSize(ctx context.Context, method Procedure, blockAndTag evmctypes.BlockAndTag) (uint64, error) {
+	var result json.RawMessage
+	if err := k.c.call(ctx, &result, method, blockAndTag); err != nil {
+		return 0, err
+	}
+	// The size is a plain JSON number; accept hex quantities as well.
+	var size uint64
+	if err := json.Unmarshal(result, &size); err == nil {
+		return size, nil
+	}
+	var hexSize hexutil.Uint64
+	if err := json.Unmarshal(result, &hexSize); err != nil {
+		return 0, err
+	}
+	return uint64(hexSize), nil
+}
+
+// SendRawTransaction submits a signed raw Kaia transaction and returns its
+// hash.
+func (k *kaiaNamespace) SendRawTransaction(rawTx string) (string, error) {
+	return k.SendRawTransactionWithContext(context.Background(), rawTx)
+}
+
+// SendRawTransactionWithContext submits a signed raw Kaia transaction and
+// returns its hash.
+func (k *kaiaNamespace) SendRawTransactionWithContext(ctx context.Context, rawTx string) (string, error) {
+	var result string
+	if err := k.c.call(ctx, &result, KaiaSendRawTransaction, rawTx); err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// SendTransaction signs tx with the sender wallet and, for fee-delegated
+// types, with the feePayer wallet, then submits it. feePayer is ignored for
+// other types.
+func (k *kaiaNamespace) SendTransaction(tx *KaiaTx, sender, feePayer *Wallet) (string, error) {
+	return k.SendTransactionWithContext(context.Background(), tx, sender, feePayer)
+}
+
+// SendTransactionWithContext signs tx with the sender wallet and, for
+// fee-delegated types, with the feePayer wallet, then submits it. feePayer is
+// ignored for other types.
+func (k *kaiaNamespace) SendTransactionWithContext(
+	ctx context.Context,
+	tx *KaiaTx,
+	sender, feePayer *Wallet,
+) (string, error) {
+	if tx == nil {
+		return "", ErrTxRequired
+	}
+	if sender == nil || (tx.Type.IsFeeDelegated() && feePayer == nil) {
+		return "", ErrWalletRequired
+	}
+	_, rawTx, err := sender.SignKaiaTx(tx)
+	if err != nil {
+		return "", err
+	}
+	if tx.Type.IsFeeDelegated() {
+		if _, rawTx, err = feePayer.SignKaiaTxAsFeePayer(tx); err != nil {
+			return "", err
+		}
+	}
+	return k.SendRawTransactionWithContext(ctx, rawTx)
+}
+
+// SendTransactionAsFeePayer has the node sign a sender-signed fee-delegated
+// tx with the fee payer account tx.FeePayer, which must be unlocked on the
+// node, and submit it.
+func (k *kaiaNamespace) SendTransactionAsFeePayer(tx *KaiaTx) (string, error) {
+	return k.SendTransactionAsFeePayerWithContext(context.Background(), tx)
+}
+
+// SendTransactionAsFeePayerWithContext has the node sign a sender-signed
+// fee-delegated tx with the fee payer account tx.FeePayer, which must be
+// unlocked on the node, and submit it.
+func (k *kaiaNamespace) SendTransactionAsFeePayerWithContext(ctx context.Context, tx *KaiaTx) (string, error) {
+	args, err := feePayerArgs(tx)
+	if err != nil {
+		return "", err
+	}
+	var result string
+	if err := k.c.call(ctx, &result, KaiaSendTransactionAsFeePayer, args); err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// SignTransactionAsFeePayer has the node sign a sender-signed fee-delegated
+// tx with the fee payer account tx.FeePayer, which must be unlocked on the
+// node, and returns the raw transaction without submitting it.
+func (k *kaiaNamespace) SignTransactionAsFeePayer(tx *KaiaTx) (string, error) {
+	return k.SignTransactionAsFeePayerWithContext(context.Background(), tx)
+}
+
+// SignTransactionAsFeePayerWithContext has the node sign a sender-signed
+// fee-delegated tx with the fee payer account tx.FeePayer, which must be
+// unlocked on the node, and returns the raw transaction without submitting
+// it.
+func (k *kaiaNamespace) SignTransactionAsFeePayerWithContext(ctx context.Context, tx *KaiaTx) (string, error) {
+	args, err := feePayerArgs(tx)
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		Raw string `json:"raw"`
+	}
+	if err := k.c.call(ctx, &result, KaiaSignTransactionAsFeePayer, args); err != nil {
+		return "", err
+	}
+	return result.Raw, nil
+}
+
+func feePayerArgs(tx *KaiaTx) (map[string]any, error) {
+	if tx == nil {
+		return nil, ErrTxRequired
+	}
+	if !tx.Type.IsFeeDelegated() {
+		return nil, ErrNotFeeDelegated
+	}
+	if !common.IsHexAddress(tx.FeePayer) {
+		return nil, errors.New("fee payer address is required")
+	}
+	if len(tx.Signatures) == 0 {
+		return nil, errors.New("sender signature is required")
+	}
+	return tx.rpcArgs()
+}
+
+// GetAccount returns the account type, balance, nonce and key of address.
+func (k *kaiaNamespace) GetAccount(address string, blockAndTag evmctypes.BlockAndTag) (*kaiatypes.Account, error) {
+	return k.GetAccountWithContext(context.Background(), address, blockAndTag)
+}
+
+// GetAccountWithContext returns the account type, balance, nonce and key of
+// address.
+func (k *kaiaNamespace) GetAccountWithContext(
+	ctx context.Context,
+	address string,
+	blockAndTag evmctypes.BlockAndTag,
+) (*kaiatypes.Account, error) {
+	var result json.RawMessage
+	if err := k.c.call(ctx, &result, KaiaGetAccount, address, blockAndTag); err != nil {
+		return nil, err
+	}
+	if len(result) == 0 || string(result) == "null" {
+		return nil, fmt.Errorf("account %s not found", address)
+	}
+	account := new(kaiatypes.Account)
+	if err := json.Unmarshal(result, account); err != nil {
+		return nil, fmt.Errorf("GetAccount: %w", err)
+	}
+	return account, nil
+}
+
+// GetAccountKey returns the key of address. Use [kaiatypes.CanSign] to check
+// which signers may act for the account.
+func (k *kaiaNamespace) GetAccountKey(address string, blockAndTag evmctypes.BlockAndTag) (kaiatypes.AccountKey, error) {
+	return k.GetAccountKeyWithContext(context.Background(), address, blockAndTag)
+}
+
+// GetAccountKeyWithContext returns the key of address. Use
+// [kaiatypes.CanSign] to check which signers may act for the account.
+func (k *kaiaNamespace) GetAccountKeyWithContext(
+	ctx context.Context,
+	address string,
+	blockAndTag evmctypes.BlockAndTag,
+) (kaiatypes.AccountKey, error) {
+	var result json.RawMessage
+	if err := k.c.call(ctx, &result, KaiaGetAccountKey, address, blockAndTag); err != nil {
+		return nil, err
+	}
+	if len(result) == 0 || string(result) == "null" {
+		return nil, fmt.Errorf("account %s not found", address)
+	}
+	key, err := kaiatypes.UnmarshalAccountKey(result)
+	if err != nil {
+		return nil, fmt.Errorf("GetAccountKey: %w", err)
+	}
+	return key, nil
+}
+
+// GetTransactionBySenderTxHash returns a transaction by the hash the sender
+// signed, which differs from the transaction hash for fee-delegated
+// transactions.
+func (k *kaiaNamespace) GetTransactionBySenderTxHash(senderTxHash string) (*kaiatypes.Transaction, error) {
+	return k.GetTransactionBySenderTxHashWithContext(context.Background(), senderTxHash)
+}
+
+// GetTransactionBySenderTxHashWithContext returns a transaction by the hash
+// the sender signed, which differs from the transaction hash for
+// fee-delegated transactions.
+func (k *kaiaNamespace) GetTransactionBySenderTxHashWithContext(
+	ctx context.Context,
+	senderTxHash string,
+) (*kaiatypes.Transaction, error) {
+	result := new(kaiatypes.Transaction)
+	if err := k.c.call(ctx, result, KaiaGetTransactionBySenderTxHash, senderTxHash); err != nil {
+		return nil, err
+	}
+	if result.Hash == "" {
+		return nil, fmt.Errorf("transaction %s not found", senderTxHash)
+	}
+	return result, nil
+}
+
+// GetTransactionReceiptBySenderTxHash returns the receipt of a transaction by
+// the hash the sender signed.
+func (k *kaiaNamespace) GetTransactionReceiptBySenderTxHash(senderTxHash string) (*kaiatypes.Receipt, error) {
+	return k.GetTransactionReceiptBySenderTxHashWithContext(context.Background(), senderTxHash)
+}
+
+// GetTransactionReceiptBySenderTxHashWithContext returns the receipt of a
+// transaction by the hash the sender signed.
+func (k *kaiaNamespace) GetTransactionReceiptBySenderTxHashWithContext(
+	ctx context.Context,
+	senderTxHash string,
+) (*kaiatypes.Receipt, error) {
+	result := new(kaiatypes.Receipt)
+	if err := k.c.call(ctx, result, KaiaGetTransactionReceiptBySenderTxHash, senderTxHash); err != nil {
+		return nil, err
+	}
+	if result.TransactionHash == "" {
+		return nil, fmt.Errorf("receipt %s not found", senderTxHash)
+	}
+	return result, nil
+}
+
+// GetDecodedAnchoringTransactionByHash returns the service chain data
+// anchored by a chain data anchoring transaction.
+func (k *kaiaNamespace) GetDecodedAnchoringTransactionByHash(hash string) (*kaiatypes.AnchoringData, error) {
+	return k.GetDecodedAnchoringTransactionByHashWithContext(context.Background(), hash)
+}
+
+// GetDecodedAnchoringTransactionByHashWithContext returns the service chain
+// data anchored by a chain data anchoring transaction.
+func (k *kaiaNamespace) GetDecodedAnchoringTransactionByHashWithContext(
+	ctx context.Context,
+	hash string,
+) (*kaiatypes.AnchoringData, error) {
+	result := new(kaiatypes.AnchoringData)
+	if err := k.c.call(ctx, result, KaiaGetDecodedAnchoringTransactionByHash, hash); err != nil {
+		return nil, err
+	}
+	if result.BlockHash == "" {
+		return nil, fmt.Errorf("anchoring transaction %s not found", hash)
+	}
+	return result, nil
+}
+
+// GetRawTransactionByHash returns the raw transaction by hash. Kaia native
+// types can be decoded with [DecodeKaiaRawTx].
+func (k *kaiaNamespace) GetRawTransactionByHash(hash string) (string, error) {
+	return k.GetRawTransactionByHashWithContext(context.Background(), hash)
+}
+
+// GetRawTransactionByHashWithContext returns the raw transaction by hash.
+// Kaia native types can be decoded with [DecodeKaiaRawTx].
+func (k *kaiaNamespace) GetRawTransactionByHashWithContext(ctx context.Context, hash string) (string, error) {
+	return k.getRawTransaction(ctx, KaiaGetRawTransactionByHash, hash)
+}
+
+// GetRawTransactionByBlockNumberAndIndex returns the raw transaction at
+// index in a block.
+func (k *kaiaNamespace) GetRawTransactionByBlockNumberAndIndex(blockNumber, index uint64) (string, error) {
+	return k.GetRawTransactionByBlockNumberAndIndexWithContext(context.Background(), blockNumber, index)
+}
+
+// GetRawTransactionByBlockNumberAndIndexWithContext returns the raw
+// transaction at index in a block.
+func (k *kaiaNamespace) GetRawTransactionByBlockNumberAndIndexWithContext(
+	ctx context.Context,
+	blockNumber, index uint64,
+) (string, error) {
+	return k.getRawTransaction(
+		ctx,
+		KaiaGetRawTransactionByBlockNumberAndIndex,
+		evmctypes.FormatNumber(blockNumber),
+		hexutil.EncodeUint64(index),
+	)
+}
+
+// GetRawTransactionByBlockHashAndIndex returns the raw transaction at index
+// in a block.
+func (k *kaiaNamespace) GetRawTransactionByBlockHashAndIndex(blockHash string, index uint64) (string, error) {
+	return k.GetRawTransactionByBlockHashAndIndexWithContext(context.Background(), blockHash, index)
+}
+
+// GetRawTransactionByBlockHashAndIndexWithContext returns the raw
+// transaction at index in a block.
+func (k *kaiaNamespace) GetRawTransactionByBlockHashAndIndexWithContext(
+	ctx context.Context,
+	blockHash string,
+	index uint64,
+) (string, error) {
+	return k.getRawTransaction(ctx, KaiaGetRawTransactionByBlockHashAndIndex, blockHash, hexutil.EncodeUint64(index))
+}
+
+func (k *kaiaNamespace) getRawTransaction(ctx context.Context, method Procedure, args ...any) (string, error) {
+	var result string
+	if err := k.c.call(ctx, &result, method, args...); err != nil {
+		return "", err
+	}
+	if result == "" || result == "0x" {
+		return "", fmt.Errorf("raw transaction %v not found", args)
+	}
+	return result, nil
+}
+
+// SubscribeNewHeads subscribes to new block headers. It requires a websocket
+// connection.
+func (k *kaiaNamespace) SubscribeNewHeads(
+	ctx context.Context,
+	ch chan<- *kaiatypes.Header,
+) (evmctypes.Subscription, error) {
+	return k.subscribe(ctx, ch, newHeads)
+}
+
+// SubscribeLogs subscribes to logs matching params, or all logs if params is
+// nil. It requires a websocket connection.
+func (k *kaiaNamespace) SubscribeLogs(
+	ctx context.Context,
+	ch chan<- *kaiatypes.Log,
+	params *evmctypes.SubLog,
+) (evmctypes.Subscription, error) {
+	if params == nil {
+		return k.subscribe(ctx, ch, logs)
+	}
+	return k.subscribe(ctx, ch, logs, params)
+}
+
+func (k *kaiaNamespace) subscribe(ctx context.Context, ch any, args ...any) (evmctypes.Subscription, error) {
+	if !k.info.IsWebsocket() {
+		return nil, ErrWebsocketRequired
+	}
+	return k.s.subscribe(ctx, "kaia", ch, args...)
+}
+
+// GetLogs returns the logs matching filter.
+func (k *kaiaNamespace) GetLogs(filter *evmctypes.LogFilter) ([]*kaiatypes.Log, error) {
+	return k.GetLogsWithContext(context.Background(), filter)
+}
+
+// GetLogsWithContext returns the logs matching filter.
+func (k *kaiaNamespace) GetLogsWithContext(ctx context.Context, filter *evmctypes.LogFilter) ([]*kaiatypes.Log, error) {
+	params, err := logFilterParams(filter)
+	if err != nil {
+		return nil, err
+	}
+	var result []*kaiatypes.Log
+	if err := k.c.call(ctx, &result, KaiaGetLogs, params); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetLogsByBlockNumber returns the logs of a block by block number.
+func (k *kaiaNamespace) GetLogsByBlockNumber(number uint64) ([]*kaiatypes.Log, error) {
+	return k.GetLogsByBlockNumberWithContext(context.Background(), number)
+}
+
+// GetLogsByBlockNumberWithContext returns the logs of a block by block
+// number.
+func (k *kaiaNamespace) GetLogsByBlockNumberWithContext(ctx context.Context, number uint64) ([]*kaiatypes.Log, error) {
+	return k.GetLogsWithContext(ctx, &evmctypes.LogFilter{FromBlock: &number, ToBlock: &number})
+}
+
+// GetLogsByBlockHash returns the logs of a block by hash.
+func (k *kaiaNamespace) GetLogsByBlockHash(hash string) ([]*kaiatypes.Log, error) {
+	return k.GetLogsByBlockHashWithContext(context.Background(), hash)
+}
+
+// GetLogsByBlockHashWithContext returns the logs of a block by hash.
+func (k *kaiaNamespace) GetLogsByBlockHashWithContext(ctx context.Context, hash string) ([]*kaiatypes.Log, error) {
+	return k.GetLogsWithContext(ctx, &evmctypes.LogFilter{BlockHash: &hash})
+}
+
+// GetLogsByTag returns the logs of the block identified by tag, number or
+// hash. The pending block is not supported.
+func (k *kaiaNamespace) GetLogsByTag(blockAndTag evmctypes.BlockAndTag) ([]*kaiatypes.Log, error) {
+	return k.GetLogsByTagWithContext(context.Background(), blockAndTag)
+}
+
+// GetLogsByTagWithContext returns the logs of the block identified by tag,
+// number or hash. The pending block is not supported.
+func (k *kaiaNamespace) GetLogsByTagWithContext(ctx context.Context, blockAndTag evmctypes.BlockAndTag) ([]*kaiatypes.Log, error) {
+	params, err := blockLogsParams(blockAndTag)
+	if err != nil {
+		return nil, err
+	}
+	var result []*kaiatypes.Log
+	if err := k.c.call(ctx, &result, KaiaGetLogs, params); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// EstimateGas estimates the gas needed to execute tx.
+func (k *kaiaNamespace) EstimateGas(tx *Tx) (uint64, error) {
+	return k.EstimateGasWithContext(context.Background(), tx)
+}
+
+// EstimateGasWithContext estimates the gas needed to execute tx.
+func (k *kaiaNamespace) EstimateGasWithContext(ctx context.Context, tx *Tx) (uint64, error) {
+	msg, err := tx.parseCallMsg()
+	if err != nil {
+		return 0, err
+	}
+	var result hexutil.Uint64
+	if err := k.c.call(ctx, &result, KaiaEstimateGas, msg); err != nil {
+		return 0, err
+	}
+	return uint64(result), nil
+}
+
+// EstimateComputationCost estimates the computation cost of executing tx
+// against blockAndTag. Transactions whose cost exceeds the protocol limit,
+// see [KaiaComputationCostLimit], fail.
+func (k *kaiaNamespace) EstimateComputationCost(tx *Tx, blockAndTag evmctypes.BlockAndTag) (uint64, error) {
+	return k.EstimateComputationCostWithContext(context.Background(), tx, blockAndTag)
+}
+
+// EstimateComputationCostWithContext estimates the computation cost of
+// executing tx against blockAndTag. Transactions whose cost exceeds the
+// protocol limit, see [KaiaComputationCostLimit], fail.
+func (k *kaiaNamespace) EstimateComputationCostWithContext(
+	ctx context.Context,
+	tx *Tx,
+	blockAndTag evmctypes.BlockAndTag,
+) (uint64, error) {
+	msg, err := tx.parseCallMsg()
+	if err != nil {
+		return 0, err
+	}
+	var result hexutil.Uint64
+	if err := k.c.call(ctx, &result, KaiaEstimateComputationCost, msg, blockAndTag); err != nil {
+		return 0, err
+	}
+	return uint64(result), nil
+}
+
+// CheckComputationCost estimates the computation cost of tx and returns
+// [ErrComputationCostExceeded] if it is above limit, e.g.
+// [KaiaComputationCostLimit].
+func (k *kaiaNamespace) CheckComputationCost(tx *Tx, blockAndTag evmctypes.BlockAndTag, limit uint64) (uint64, error) {
+	return k.CheckComputationCostWithContext(context.Background(), tx, blockAndTag, limit)
+}
+
+// CheckComputationCostWithContext estimates the computation cost of tx and
+// returns [ErrComputationCostExceeded] if it is above limit, e.g.
+// [KaiaComputationCostLimit].
+func (k *kaiaNamespace) CheckComputationCostWithContext(
+	ctx context.Context,
+	tx *Tx,
+	blockAndTag evmctypes.BlockAndTag,
+	limit uint64,
+) (uint64, error) {
+	cost, err := k.EstimateComputationCostWithContext(ctx, tx, blockAndTag)
+	if err != nil {
+		return 0, err
+	}
+	if cost > limit {
+		return cost, fmt.Errorf("%w: %d > %d", ErrComputationCostExceeded, cost, limit)
+	}
+	return cost, nil
+}
+
+// Call executes tx against blockAndTag without creating a transaction and
+// returns the output.
+func (k *kaiaNamespace) Call(tx *Tx, blockAndTag evmctypes.BlockAndTag) (string, error) {
+	return k.CallWithContext(context.Background(), tx, blockAndTag)
+}
+
+// CallWithContext executes tx against blockAndTag without creating a
+// transaction and returns the output.
+func (k *kaiaNamespace) CallWithContext(ctx context.Context, tx *Tx, blockAndTag evmctypes.BlockAndTag) (string, error) {
+	msg, err := tx.parseCallMsg()
+	if err != nil {
+		return "", err
+	}
+	var result string
+	if err := k.c.call(ctx, &result, KaiaCall, msg, blockAndTag); err != nil {
+		return "", err
+	}
+	return result, nil
+}
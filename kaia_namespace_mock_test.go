@@ -0,0 +1,351 @@
+package evmc
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/bbaktaeho/evmc/evmctypes/kaiatypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	kaiaValidator1 = common.HexToAddress("0x571e53df607be97431a5bbefca1dffe5aef56f4d")
+	kaiaValidator2 = common.HexToAddress("0x5cb1a7dccbd0dc446e3640898ede8820368554c8")
+)
+
+// kaiaConsensusBlockJSON은 Istanbul extraData/governanceData/voteData를 포함한 블록을 만든다.
+func kaiaConsensusBlockJSON(t *testing.T, number string) map[string]any {
+	t.Helper()
+	extra, err := rlp.EncodeToBytes(struct {
+		Validators    []common.Address
+		Seal          []byte
+		CommittedSeal [][]byte
+	}{
+		Validators:    []common.Address{kaiaValidator1, kaiaValidator2},
+		Seal:          []byte{0x01, 0x02},
+		CommittedSeal: [][]byte{{0x03}, {0x04}},
+	})
+	require.NoError(t, err)
+	governance, err := rlp.EncodeToBytes([]byte(`{"governance.unitprice":25000000000}`))
+	require.NoError(t, err)
+	vote, err := rlp.EncodeToBytes(struct {
+		Validator common.Address
+		Key       string
+		Value     uint64
+	}{kaiaValidator1, "governance.unitprice", 25000000000})
+	require.NoError(t, err)
+
+	return map[string]any{
+		"number":           number,
+		"hash":             "0xblockhash",
+		"parentHash":       "0xparent",
+		"timestamp":        "0x6502e3a1",
+		"extraData":        hexutil.Encode(append(make([]byte, 32), extra...)),
+		"governanceData":   hexutil.Encode(governance),
+		"voteData":         hexutil.Encode(vote),
+		"committee":        []string{kaiaValidator1.Hex(), kaiaValidator2.Hex()},
+		"committers":       []string{kaiaValidator2.Hex()},
+		"proposer":         kaiaValidator1.Hex(),
+		"originProposer":   kaiaValidator1.Hex(),
+		"round":            2,
+		"sigHash":          "0xsighash",
+		"transactions":     []any{},
+		"transactionsRoot": "0xroot",
+	}
+}
+
+func Test_kaiaNamespace_mock_GetBlockWithConsensusInfoByNumber(t *testing.T) {
+	client := testWithMock(t, "kaia_getBlockWithConsensusInfoByNumber", func(params json.RawMessage) any {
+		return kaiaConsensusBlockJSON(t, "0x64")
+	})
+
+	block, err := client.Kaia().GetBlockWithConsensusInfoByNumber(100)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), block.Number)
+	assert.Equal(t, kaiaValidator1.Hex(), block.Proposer)
+	assert.Equal(t, uint64(2), block.Round)
+	assert.Len(t, block.Committee, 2)
+	assert.Equal(t, []string{kaiaValidator2.Hex()}, block.Committers)
+
+	extra, err := block.IstanbulExtra()
+	require.NoError(t, err)
+	assert.Equal(t, []string{kaiaValidator1.Hex(), kaiaValidator2.Hex()}, extra.Validators)
+	assert.Equal(t, []byte{0x01, 0x02}, extra.Seal)
+	assert.Len(t, extra.CommittedSeal, 2)
+
+	governance, err := block.Governance()
+	require.NoError(t, err)
+	assert.Equal(t, float64(25000000000), governance["governance.unitprice"])
+
+	vote, err := block.Vote()
+	require.NoError(t, err)
+	assert.Equal(t, kaiaValidator1.Hex(), vote.Validator)
+	assert.Equal(t, "governance.unitprice", vote.Key)
+	assert.Equal(t, new(big.Int).SetUint64(25000000000).Bytes(), vote.Value)
+}
+
+func Test_kaiaNamespace_mock_GetBlockWithConsensusInfoRange(t *testing.T) {
+	mock := newMockRPCServer(t)
+	mock.on("kaia_getBlockWithConsensusInfoByNumber", func(params json.RawMessage) any {
+		var args []string
+		require.NoError(t, json.Unmarshal(params, &args))
+		block := kaiaConsensusBlockJSON(t, args[0])
+		// 빈 governanceData/voteData
+		block["governanceData"] = "0x"
+		block["voteData"] = "0x"
+		block["round"] = "0x1"
+		return block
+	})
+	client := testEvmc(mock.url())
+
+	blocks, err := client.Kaia().GetBlockWithConsensusInfoRange(10, 12)
+	require.NoError(t, err)
+	require.Len(t, blocks, 3)
+	for i, block := range blocks {
+		assert.Equal(t, uint64(10+i), block.Number)
+		assert.Equal(t, uint64(1), block.Round)
+		vote, err := block.Vote()
+		require.NoError(t, err)
+		assert.Nil(t, vote)
+		governance, err := block.Governance()
+		require.NoError(t, err)
+		assert.Nil(t, governance)
+	}
+
+	_, err = client.Kaia().GetBlockWithConsensusInfoRange(2, 1)
+	assert.ErrorIs(t, err, ErrInvalidRange)
+}
+
+func Test_kaiaNamespace_mock_CommitteeAndCouncil(t *testing.T) {
+	mock := newMockRPCServer(t)
+	mock.on("kaia_getCommittee", func(params json.RawMessage) any {
+		return []string{kaiaValidator1.Hex(), kaiaValidator2.Hex()}
+	})
+	mock.on("kaia_getCommitteeSize", func(params json.RawMessage) any { return 2 })
+	mock.on("kaia_getCouncil", func(params json.RawMessage) any { return []string{kaiaValidator1.Hex()} })
+	mock.on("kaia_getCouncilSize", func(params json.RawMessage) any { return "0x1" })
+	mock.on("kaia_getHeaderByNumber", func(params json.RawMessage) any { return kaiaConsensusBlockJSON(t, "0x64") })
+	client := testEvmc(mock.url())
+
+	committee, err := client.Kaia().GetCommittee(evmctypes.Latest)
+	require.NoError(t, err)
+	assert.Len(t, committee, 2)
+	size, err := client.Kaia().GetCommitteeSize(evmctypes.Latest)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), size)
+
+	council, err := client.Kaia().GetCouncil(evmctypes.FormatNumber(100))
+	require.NoError(t, err)
+	assert.Equal(t, []string{kaiaValidator1.Hex()}, council)
+	size, err = client.Kaia().GetCouncilSize(evmctypes.FormatNumber(100))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), size)
+
+	header, err := client.Kaia().GetHeaderByNumber(100)
+	require.NoError(t, err)
+	extra, err := header.IstanbulExtra()
+	require.NoError(t, err)
+	assert.Len(t, extra.Validators, 2)
+}
+
+func Test_kaiaNamespace_mock_GetAccountKey(t *testing.T) {
+	sender, err := NewWallet(kaiaSenderKey)
+	require.NoError(t, err)
+	feePayer, err := NewWallet(kaiaFeePayerKey)
+	require.NoError(t, err)
+	xy := func(w *Wallet) map[string]string {
+		return map[string]string{
+			"x": hexutil.EncodeBig(w.pk.PublicKey.X),
+			"y": hexutil.EncodeBig(w.pk.PublicKey.Y),
+		}
+	}
+	// role-based: transaction 역할은 2-of-2 multisig, account update는 public key
+	roleBased := map[string]any{
+		"keyType": 5,
+		"key": []any{
+			map[string]any{"keyType": 4, "key": map[string]any{
+				"threshold": 2,
+				"keys": []any{
+					map[string]any{"weight": 1, "key": xy(sender)},
+					map[string]any{"weight": 1, "key": xy(feePayer)},
+				},
+			}},
+			map[string]any{"keyType": 2, "key": xy(feePayer)},
+		},
+	}
+	account := "0x00000000000000000000000000000000000000aa"
+
+	mock := newMockRPCServer(t)
+	mock.on("kaia_getAccountKey", func(params json.RawMessage) any { return roleBased })
+	mock.on("kaia_getAccount", func(params json.RawMessage) any {
+		return map[string]any{
+			"accType": 2,
+			"account": map[string]any{
+				"nonce":         3,
+				"balance":       "0x3e8",
+				"humanReadable": false,
+				"key":           map[string]any{"keyType": 3, "key": map[string]any{}},
+				"storageRoot":   common.HexToHash("0x01").Hex(),
+				"codeHash":      "AQI=",
+				"codeFormat":    0,
+				"vmVersion":     1,
+			},
+		}
+	})
+	client := testEvmc(mock.url())
+
+	key, err := client.Kaia().GetAccountKey(account, evmctypes.Latest)
+	require.NoError(t, err)
+	require.IsType(t, kaiatypes.AccountKeyRoleBased{}, key)
+
+	assert.False(t, kaiatypes.CanSign(key, account, kaiatypes.RoleTransaction, sender.Address()))
+	assert.True(t, kaiatypes.CanSign(key, account, kaiatypes.RoleTransaction, sender.Address(), feePayer.Address()))
+	assert.True(t, kaiatypes.CanSign(key, account, kaiatypes.RoleAccountUpdate, feePayer.Address()))
+	assert.False(t, kaiatypes.CanSign(key, account, kaiatypes.RoleAccountUpdate, account))
+	// fee payer 역할이 없으면 transaction 역할의 키를 사용한다.
+	assert.True(t, kaiatypes.CanSign(key, account, kaiatypes.RoleFeePayer, sender.Address(), feePayer.Address()))
+	assert.True(t, kaiatypes.CanSign(kaiatypes.AccountKeyLegacy{}, account, kaiatypes.RoleTransaction, account))
+
+	acc, err := client.Kaia().GetAccount(account, evmctypes.Latest)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), acc.AccType)
+	assert.Equal(t, uint64(3), acc.Nonce)
+	assert.Equal(t, "1000", acc.Balance.String())
+	assert.Equal(t, kaiatypes.AccountKeyFail{}, acc.Key)
+	assert.Equal(t, "0x0102", acc.CodeHash)
+	assert.Equal(t, uint64(1), acc.VMVersion)
+}
+
+func Test_kaiaNamespace_mock_SenderTxHash(t *testing.T) {
+	mock := newMockRPCServer(t)
+	mock.on("kaia_getTransactionBySenderTxHash", func(params json.RawMessage) any {
+		return map[string]any{
+			"blockHash":        "0xblock",
+			"blockNumber":      "0x64",
+			"hash":             "0xtxhash",
+			"senderTxHash":     "0xsender",
+			"transactionIndex": "0x0",
+			"typeInt":          9,
+			"value":            "0xa",
+			"key":              "0x01c0",
+		}
+	})
+	mock.on("kaia_getTransactionReceiptBySenderTxHash", func(params json.RawMessage) any {
+		return map[string]any{
+			"blockNumber":      "0x64",
+			"transactionHash":  "0xtxhash",
+			"senderTxHash":     "0xsender",
+			"transactionIndex": "0x0",
+			"logs":             []any{},
+		}
+	})
+	mock.on("kaia_getRawTransactionByHash", func(params json.RawMessage) any { return "0x09f8" })
+	mock.on("kaia_getRawTransactionByBlockNumberAndIndex", func(params json.RawMessage) any { return "0x" })
+	mock.on("kaia_getDecodedAnchoringTransactionByHash", func(params json.RawMessage) any {
+		return map[string]any{"BlockHash": "0xanchored", "BlockNumber": 1024, "BlockCount": 1, "TxCount": 3}
+	})
+	client := testEvmc(mock.url())
+
+	tx, err := client.Kaia().GetTransactionBySenderTxHash("0xsender")
+	require.NoError(t, err)
+	assert.Equal(t, "0xtxhash", tx.Hash)
+	key, err := tx.AccountKey()
+	require.NoError(t, err)
+	assert.Equal(t, kaiatypes.AccountKeyLegacy{}, key)
+
+	receipt, err := client.Kaia().GetTransactionReceiptBySenderTxHash("0xsender")
+	require.NoError(t, err)
+	assert.Equal(t, "0xtxhash", receipt.TransactionHash)
+
+	raw, err := client.Kaia().GetRawTransactionByHash("0xtxhash")
+	require.NoError(t, err)
+	assert.Equal(t, "0x09f8", raw)
+	_, err = client.Kaia().GetRawTransactionByBlockNumberAndIndex(100, 5)
+	assert.Error(t, err)
+
+	anchoring, err := client.Kaia().GetDecodedAnchoringTransactionByHash("0xtxhash")
+	require.NoError(t, err)
+	assert.Equal(t, "0xanchored", anchoring.BlockHash)
+	assert.Equal(t, int64(1024), anchoring.BlockNumber.Int64())
+	assert.Equal(t, int64(3), anchoring.TxCount.Int64())
+}
+
+func Test_kaiaNamespace_mock_EthParity(t *testing.T) {
+	mock := newMockRPCServer(t)
+	mock.on("kaia_getBlockByNumber", func(params json.RawMessage) any {
+		var args []any
+		require.NoError(t, json.Unmarshal(params, &args))
+		assert.Equal(t, false, args[1])
+		block := kaiaConsensusBlockJSON(t, args[0].(string))
+		block["transactions"] = []string{"0xtx"}
+		return block
+	})
+	mock.on("kaia_getLogs", func(params json.RawMessage) any {
+		var args []map[string]any
+		require.NoError(t, json.Unmarshal(params, &args))
+		assert.Equal(t, "0x64", args[0]["fromBlock"])
+		return []any{map[string]any{
+			"address":          "0xcontract",
+			"topics":           []string{"0xtopic"},
+			"data":             "0x",
+			"blockNumber":      "0x64",
+			"transactionHash":  "0xtx",
+			"transactionIndex": "0x0",
+			"blockHash":        "0xblock",
+			"logIndex":         "0x1",
+		}}
+	})
+	mock.on("kaia_estimateGas", func(params json.RawMessage) any { return "0x5208" })
+	mock.on("kaia_estimateComputationCost", func(params json.RawMessage) any { return "0x9896800" })
+	mock.on("kaia_call", func(params json.RawMessage) any { return "0x2a" })
+	client := testEvmc(mock.url())
+
+	blocks, err := client.Kaia().GetBlockRange(10, 11)
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	assert.Equal(t, []string{"0xtx"}, blocks[1].Transactions)
+
+	logs, err := client.Kaia().GetLogsByBlockNumber(100)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Equal(t, uint64(1), logs[0].LogIndex)
+
+	logs, err = client.Kaia().GetLogsByTag(evmctypes.FormatNumber(100))
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	_, err = client.Kaia().GetLogsByTag(evmctypes.Pending)
+	assert.ErrorIs(t, err, ErrPendingBlockNotSupported)
+	_, err = client.Kaia().GetBlockReceiptsByTag(evmctypes.Pending)
+	assert.ErrorIs(t, err, ErrPendingBlockNotSupported)
+
+	tx := &Tx{From: "0xa94f5374fce5edbc8e2a8697c15331677e6ebf0b", To: "0xcontract"}
+	gas, err := client.Kaia().EstimateGas(tx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(21000), gas)
+
+	out, err := client.Kaia().Call(tx, evmctypes.Latest)
+	require.NoError(t, err)
+	assert.Equal(t, "0x2a", out)
+
+	// 160,000,000 > 150,000,000
+	cost, err := client.Kaia().EstimateComputationCost(tx, evmctypes.Latest)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(160000000), cost)
+	_, err = client.Kaia().CheckComputationCost(tx, evmctypes.Latest, KaiaComputationCostLimit)
+	assert.ErrorIs(t, err, ErrComputationCostExceeded)
+	_, err = client.Kaia().CheckComputationCost(tx, evmctypes.Latest, 200000000)
+	assert.NoError(t, err)
+
+	// http 연결에서는 구독할 수 없다.
+	_, err = client.Kaia().SubscribeNewHeads(context.Background(), make(chan *kaiatypes.Header))
+	assert.ErrorIs(t, err, ErrWebsocketRequired)
+	_, err = client.Kaia().SubscribeLogs(context.Background(), make(chan *kaiatypes.Log), nil)
+	assert.ErrorIs(t, err, ErrWebsocketRequired)
+}
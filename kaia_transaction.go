@@ -0,0 +1,484 @@
+package evmc
+
+// https://docs.kaia.io/build/transactions/
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bbaktaeho/evmc/evmctypes/kaiatypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/shopspring/decimal"
+)
+
+// KaiaComputationCostLimit is the maximum computation cost of a transaction
+// since the Cancun hardfork; it was 100,000,000 before.
+const KaiaComputationCostLimit uint64 = 150_000_000
+
+// KaiaTxType is a Kaia native transaction type. Every basic type is followed
+// by its fee-delegated variant (+1) and its partial fee-delegated variant
+// with a fee ratio (+2).
+type KaiaTxType uint8
+
+const (
+	KaiaTxTypeValueTransfer                               KaiaTxType = 0x08
+	KaiaTxTypeFeeDelegatedValueTransfer                   KaiaTxType = 0x09
+	KaiaTxTypeFeeDelegatedValueTransferWithRatio          KaiaTxType = 0x0a
+	KaiaTxTypeAccountUpdate                               KaiaTxType = 0x20
+	KaiaTxTypeFeeDelegatedAccountUpdate                   KaiaTxType = 0x21
+	KaiaTxTypeFeeDelegatedAccountUpdateWithRatio          KaiaTxType = 0x22
+	KaiaTxTypeSmartContractExecution                      KaiaTxType = 0x30
+	KaiaTxTypeFeeDelegatedSmartContractExecution          KaiaTxType = 0x31
+	KaiaTxTypeFeeDelegatedSmartContractExecutionWithRatio KaiaTxType = 0x32
+)
+
+func (t KaiaTxType) basic() KaiaTxType {
+	return t &^ 0x07
+}
+
+// IsFeeDelegated reports whether a fee payer signs transactions of type t.
+func (t KaiaTxType) IsFeeDelegated() bool {
+	return t&0x07 != 0
+}
+
+// HasFeeRatio reports whether transactions of type t carry a fee ratio.
+func (t KaiaTxType) HasFeeRatio() bool {
+	return t&0x07 == 0x02
+}
+
+func (t KaiaTxType) valid() bool {
+	switch t.basic() {
+	case KaiaTxTypeValueTransfer, KaiaTxTypeAccountUpdate, KaiaTxTypeSmartContractExecution:
+		return t&0x07 <= 0x02
+	}
+	return false
+}
+
+// KaiaSignature is a Kaia transaction signature. V follows EIP-155
+// (recovery id + chainID*2 + 35).
+type KaiaSignature struct {
+	V *big.Int
+	R *big.Int
+	S *big.Int
+}
+
+// KaiaTx is a Kaia native transaction. Sign it with [Wallet.SignKaiaTx] and,
+// for fee-delegated types, [Wallet.SignKaiaTxAsFeePayer], then submit the raw
+// transaction with [kaiaNamespace.SendRawTransaction].
+//
+// Signatures are appended, so an account with a multisig key is signed by
+// calling SignKaiaTx once per key.
+type KaiaTx struct {
+	Type     KaiaTxType
+	ChainID  uint64
+	Nonce    uint64
+	GasPrice decimal.Decimal
+	GasLimit uint64
+	From     string
+	To       string // value transfer and smart contract execution
+	Value    decimal.Decimal
+	Data     string               // smart contract execution
+	Key      kaiatypes.AccountKey // account update
+	FeeRatio uint8                // with ratio types, 1-99 percent paid by the fee payer
+
+	Signatures         []KaiaSignature
+	FeePayer           string
+	FeePayerSignatures []KaiaSignature
+}
+
+// NewKaiaValueTransferTx builds a value transfer from tx.From to tx.To.
+func NewKaiaValueTransferTx(tx *Tx) (*KaiaTx, error) {
+	return newKaiaTx(KaiaTxTypeValueTransfer, tx, nil, 0)
+}
+
+// NewFeeDelegatedValueTransferTx builds a value transfer whose fee is paid by
+// a fee payer.
+func NewFeeDelegatedValueTransferTx(tx *Tx) (*KaiaTx, error) {
+	return newKaiaTx(KaiaTxTypeFeeDelegatedValueTransfer, tx, nil, 0)
+}
+
+// NewFeeDelegatedValueTransferWithRatioTx builds a value transfer whose fee
+// is paid by a fee payer for feeRatio percent.
+func NewFeeDelegatedValueTransferWithRatioTx(tx *Tx, feeRatio uint8) (*KaiaTx, error) {
+	return newKaiaTx(KaiaTxTypeFeeDelegatedValueTransferWithRatio, tx, nil, feeRatio)
+}
+
+// NewKaiaSmartContractExecutionTx builds a call of the contract tx.To with
+// tx.Data as input.
+func NewKaiaSmartContractExecutionTx(tx *Tx) (*KaiaTx, error) {
+	return newKaiaTx(KaiaTxTypeSmartContractExecution, tx, nil, 0)
+}
+
+// NewFeeDelegatedSmartContractExecutionTx builds a contract call whose fee is
+// paid by a fee payer.
+func NewFeeDelegatedSmartContractExecutionTx(tx *Tx) (*KaiaTx, error) {
+	return newKaiaTx(KaiaTxTypeFeeDelegatedSmartContractExecution, tx, nil, 0)
+}
+
+// NewFeeDelegatedSmartContractExecutionWithRatioTx builds a contract call
+// whose fee is paid by a fee payer for feeRatio percent.
+func NewFeeDelegatedSmartContractExecutionWithRatioTx(tx *Tx, feeRatio uint8) (*KaiaTx, error) {
+	return newKaiaTx(KaiaTxTypeFeeDelegatedSmartContractExecutionWithRatio, tx, nil, feeRatio)
+}
+
+// NewKaiaAccountUpdateTx builds a transaction replacing the key of tx.From
+// with key. tx.To, tx.Value and tx.Data are ignored.
+func NewKaiaAccountUpdateTx(tx *Tx, key kaiatypes.AccountKey) (*KaiaTx, error) {
+	return newKaiaTx(KaiaTxTypeAccountUpdate, tx, key, 0)
+}
+
+// NewFeeDelegatedAccountUpdateTx builds an account update whose fee is paid
+// by a fee payer.
+func NewFeeDelegatedAccountUpdateTx(tx *Tx, key kaiatypes.AccountKey) (*KaiaTx, error) {
+	return newKaiaTx(KaiaTxTypeFeeDelegatedAccountUpdate, tx, key, 0)
+}
+
+// NewFeeDelegatedAccountUpdateWithRatioTx builds an account update whose fee
+// is paid by a fee payer for feeRatio percent.
+func NewFeeDelegatedAccountUpdateWithRatioTx(tx *Tx, key kaiatypes.AccountKey, feeRatio uint8) (*KaiaTx, error) {
+	return newKaiaTx(KaiaTxTypeFeeDelegatedAccountUpdateWithRatio, tx, key, feeRatio)
+}
+
+func newKaiaTx(txType KaiaTxType, tx *Tx, key kaiatypes.AccountKey, feeRatio uint8) (*KaiaTx, error) {
+	if tx == nil {
+		return nil, ErrTxRequired
+	}
+	kaiaTx := &KaiaTx{
+		Type:     txType,
+		ChainID:  tx.ChainID,
+		Nonce:    tx.Nonce,
+		GasPrice: tx.GasPrice,
+		GasLimit: tx.GasLimit,
+		From:     tx.From,
+		Value:    tx.Value,
+		Key:      key,
+		FeeRatio: feeRatio,
+	}
+	switch txType.basic() {
+	case KaiaTxTypeValueTransfer:
+		kaiaTx.To = tx.To
+	case KaiaTxTypeSmartContractExecution:
+		kaiaTx.To = tx.To
+		kaiaTx.Data = tx.Data
+	}
+	if err := kaiaTx.valid(); err != nil {
+		return nil, err
+	}
+	return kaiaTx, nil
+}
+
+func (t *KaiaTx) valid() error {
+	if !t.Type.valid() {
+		return fmt.Errorf("%w: %#x", ErrUnsupportedKaiaTxType, uint8(t.Type))
+	}
+	if t.ChainID == 0 {
+		return ErrChainIDLessThanZero
+	}
+	if t.GasLimit == 0 {
+		return ErrTxGasLimitZero
+	}
+	if t.GasPrice.IsNegative() {
+		return ErrTxGasPriceLessThanZero
+	}
+	if t.Value.IsNegative() {
+		return ErrTxValueLessThanZero
+	}
+	if !common.IsHexAddress(t.From) {
+		return ErrFromRequired
+	}
+	switch t.Type.basic() {
+	case KaiaTxTypeValueTransfer, KaiaTxTypeSmartContractExecution:
+		if !common.IsHexAddress(t.To) {
+			return ErrToRequired
+		}
+	case KaiaTxTypeAccountUpdate:
+		if t.Key == nil {
+			return errors.New("account key is required")
+		}
+		if !t.Value.IsZero() {
+			return errors.New("account update can not transfer value")
+		}
+	}
+	if t.Type.HasFeeRatio() && (t.FeeRatio == 0 || t.FeeRatio > 99) {
+		return fmt.Errorf("%w: %d", ErrInvalidFeeRatio, t.FeeRatio)
+	}
+	return nil
+}
+
+// fields returns the type specific fields following the type byte, in
+// encoding order.
+func (t *KaiaTx) fields() ([]any, error) {
+	if err := t.valid(); err != nil {
+		return nil, err
+	}
+	var (
+		from   = common.HexToAddress(t.From)
+		fields = []any{t.Nonce, t.GasPrice.BigInt(), t.GasLimit}
+	)
+	switch t.Type.basic() {
+	case KaiaTxTypeValueTransfer:
+		fields = append(fields, common.HexToAddress(t.To), t.Value.BigInt(), from)
+	case KaiaTxTypeSmartContractExecution:
+		data, to, err := parseDataAndToAddress(t.Data, t.To)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, to, t.Value.BigInt(), from, data)
+	case KaiaTxTypeAccountUpdate:
+		key, err := kaiatypes.EncodeAccountKey(t.Key)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, from, key)
+	}
+	if t.Type.HasFeeRatio() {
+		fields = append(fields, t.FeeRatio)
+	}
+	return fields, nil
+}
+
+// sigFields returns the RLP encoding of the type byte and the type specific
+// fields, which both the sender and the fee payer sign.
+func (t *KaiaTx) sigFields() ([]byte, error) {
+	fields, err := t.fields()
+	if err != nil {
+		return nil, err
+	}
+	return rlp.EncodeToBytes(append([]any{uint8(t.Type)}, fields...))
+}
+
+// SigRLP returns the RLP encoding hashed for the sender signatures.
+func (t *KaiaTx) SigRLP() ([]byte, error) {
+	enc, err := t.sigFields()
+	if err != nil {
+		return nil, err
+	}
+	return rlp.EncodeToBytes([]any{enc, t.ChainID, uint(0), uint(0)})
+}
+
+// FeePayerSigRLP returns the RLP encoding hashed for the fee payer
+// signatures.
+func (t *KaiaTx) FeePayerSigRLP() ([]byte, error) {
+	if !t.Type.IsFeeDelegated() {
+		return nil, ErrNotFeeDelegated
+	}
+	if !common.IsHexAddress(t.FeePayer) {
+		return nil, errors.New("invalid fee payer address")
+	}
+	enc, err := t.sigFields()
+	if err != nil {
+		return nil, err
+	}
+	return rlp.EncodeToBytes([]any{enc, common.HexToAddress(t.FeePayer), t.ChainID, uint(0), uint(0)})
+}
+
+// RawTx returns the type byte followed by the RLP encoding of the fields and
+// all signatures collected so far.
+func (t *KaiaTx) RawTx() ([]byte, error) {
+	fields, err := t.fields()
+	if err != nil {
+		return nil, err
+	}
+	fields = append(fields, encodeKaiaSignatures(t.Signatures))
+	if t.Type.IsFeeDelegated() {
+		var feePayer common.Address
+		if t.FeePayer != "" {
+			feePayer = common.HexToAddress(t.FeePayer)
+		}
+		fields = append(fields, feePayer, encodeKaiaSignatures(t.FeePayerSignatures))
+	}
+	enc, err := rlp.EncodeToBytes(fields)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{byte(t.Type)}, enc...), nil
+}
+
+// Hash returns the transaction hash. For fee-delegated types it changes once
+// the fee payer signs; see [KaiaTx.SenderTxHash].
+func (t *KaiaTx) Hash() (string, error) {
+	raw, err := t.RawTx()
+	if err != nil {
+		return "", err
+	}
+	return crypto.Keccak256Hash(raw).Hex(), nil
+}
+
+// SenderTxHash returns the hash of the transaction without the fee payer
+// part, which is known to the sender before the fee payer signs. It equals
+// Hash for types that are not fee-delegated.
+func (t *KaiaTx) SenderTxHash() (string, error) {
+	fields, err := t.fields()
+	if err != nil {
+		return "", err
+	}
+	enc, err := rlp.EncodeToBytes(append(fields, encodeKaiaSignatures(t.Signatures)))
+	if err != nil {
+		return "", err
+	}
+	return crypto.Keccak256Hash([]byte{byte(t.Type)}, enc).Hex(), nil
+}
+
+func encodeKaiaSignatures(sigs []KaiaSignature) [][]*big.Int {
+	enc := make([][]*big.Int, len(sigs))
+	for i, sig := range sigs {
+		enc[i] = []*big.Int{sig.V, sig.R, sig.S}
+	}
+	return enc
+}
+
+// signKaia signs the keccak256 hash of sigRLP and returns the signature with
+// an EIP-155 V.
+func signKaia(wallet *Wallet, sigRLP []byte, chainID uint64) (KaiaSignature, error) {
+	sig, err := crypto.Sign(crypto.Keccak256(sigRLP), wallet.pk)
+	if err != nil {
+		return KaiaSignature{}, err
+	}
+	r, s, _ := decodeSignature(sig)
+	v := new(big.Int).SetUint64(uint64(sig[64]) + chainID*2 + 35)
+	return KaiaSignature{V: v, R: r, S: s}, nil
+}
+
+// DecodeKaiaRawTx decodes a raw Kaia transaction, e.g. one signed by the
+// sender and handed over to the fee payer. The chain id is taken from the
+// first signature.
+func DecodeKaiaRawTx(rawTx string) (*KaiaTx, error) {
+	raw, err := hexutil.Decode(rawTx)
+	if err != nil {
+		return nil, fmt.Errorf("DecodeKaiaRawTx: %w", err)
+	}
+	if len(raw) == 0 || !KaiaTxType(raw[0]).valid() {
+		return nil, ErrUnsupportedKaiaTxType
+	}
+	t := &KaiaTx{Type: KaiaTxType(raw[0])}
+	var elems []rlp.RawValue
+	if err := rlp.DecodeBytes(raw[1:], &elems); err != nil {
+		return nil, fmt.Errorf("DecodeKaiaRawTx: %w", err)
+	}
+
+	var (
+		from, to           common.Address
+		gasPrice, value    = new(big.Int), new(big.Int)
+		data, key          []byte
+		feePayer           common.Address
+		sigs, feePayerSigs [][]*big.Int
+	)
+	targets := []any{&t.Nonce, gasPrice, &t.GasLimit}
+	switch t.Type.basic() {
+	case KaiaTxTypeValueTransfer:
+		targets = append(targets, &to, value, &from)
+	case KaiaTxTypeSmartContractExecution:
+		targets = append(targets, &to, value, &from, &data)
+	case KaiaTxTypeAccountUpdate:
+		targets = append(targets, &from, &key)
+	}
+	if t.Type.HasFeeRatio() {
+		targets = append(targets, &t.FeeRatio)
+	}
+	targets = append(targets, &sigs)
+	if t.Type.IsFeeDelegated() {
+		targets = append(targets, &feePayer, &feePayerSigs)
+	}
+	if len(elems) != len(targets) {
+		return nil, fmt.Errorf("DecodeKaiaRawTx: %d fields, want %d", len(elems), len(targets))
+	}
+	for i, target := range targets {
+		if err := rlp.DecodeBytes(elems[i], target); err != nil {
+			return nil, fmt.Errorf("DecodeKaiaRawTx: field %d: %w", i, err)
+		}
+	}
+
+	t.GasPrice = decimal.NewFromBigInt(gasPrice, 0)
+	t.Value = decimal.NewFromBigInt(value, 0)
+	t.From = from.Hex()
+	switch t.Type.basic() {
+	case KaiaTxTypeValueTransfer:
+		t.To = to.Hex()
+	case KaiaTxTypeSmartContractExecution:
+		t.To = to.Hex()
+		t.Data = hexutil.Encode(data)
+	case KaiaTxTypeAccountUpdate:
+		if t.Key, err = kaiatypes.DecodeAccountKey(key); err != nil {
+			return nil, fmt.Errorf("DecodeKaiaRawTx: %w", err)
+		}
+	}
+	if t.Signatures, err = decodeKaiaSignatures(sigs); err != nil {
+		return nil, fmt.Errorf("DecodeKaiaRawTx: %w", err)
+	}
+	if t.FeePayerSignatures, err = decodeKaiaSignatures(feePayerSigs); err != nil {
+		return nil, fmt.Errorf("DecodeKaiaRawTx: %w", err)
+	}
+	if feePayer != (common.Address{}) {
+		t.FeePayer = feePayer.Hex()
+	}
+	if len(t.Signatures) > 0 && t.Signatures[0].V.Uint64() >= 35 {
+		t.ChainID = (t.Signatures[0].V.Uint64() - 35) / 2
+	}
+	return t, nil
+}
+
+func decodeKaiaSignatures(enc [][]*big.Int) ([]KaiaSignature, error) {
+	var sigs []KaiaSignature
+	for _, sig := range enc {
+		if len(sig) != 3 {
+			return nil, errors.New("invalid signature")
+		}
+		// Unsigned fee-delegated transactions carry a placeholder signature.
+		if sig[1].Sign() == 0 && sig[2].Sign() == 0 {
+			continue
+		}
+		sigs = append(sigs, KaiaSignature{V: sig[0], R: sig[1], S: sig[2]})
+	}
+	return sigs, nil
+}
+
+// rpcArgs returns the transaction in the shape of the Kaia SendTxArgs
+// object, used when the node signs on behalf of an account.
+func (t *KaiaTx) rpcArgs() (map[string]any, error) {
+	if err := t.valid(); err != nil {
+		return nil, err
+	}
+	args := map[string]any{
+		"typeInt":  uint8(t.Type),
+		"from":     t.From,
+		"gas":      hexutil.EncodeUint64(t.GasLimit),
+		"gasPrice": hexutil.EncodeBig(t.GasPrice.BigInt()),
+		"nonce":    hexutil.EncodeUint64(t.Nonce),
+		"chainId":  hexutil.EncodeUint64(t.ChainID),
+	}
+	switch t.Type.basic() {
+	case KaiaTxTypeValueTransfer:
+		args["to"] = t.To
+		args["value"] = hexutil.EncodeBig(t.Value.BigInt())
+	case KaiaTxTypeSmartContractExecution:
+		args["to"] = t.To
+		args["value"] = hexutil.EncodeBig(t.Value.BigInt())
+		args["input"] = t.Data
+	case KaiaTxTypeAccountUpdate:
+		key, err := kaiatypes.EncodeAccountKey(t.Key)
+		if err != nil {
+			return nil, err
+		}
+		args["key"] = hexutil.Encode(key)
+	}
+	if t.Type.HasFeeRatio() {
+		args["feeRatio"] = hexutil.EncodeUint64(uint64(t.FeeRatio))
+	}
+	if t.FeePayer != "" {
+		args["feePayer"] = t.FeePayer
+	}
+	sigs := make([]map[string]string, len(t.Signatures))
+	for i, sig := range t.Signatures {
+		sigs[i] = map[string]string{
+			"V": hexutil.EncodeBig(sig.V),
+			"R": hexutil.EncodeBig(sig.R),
+			"S": hexutil.EncodeBig(sig.S),
+		}
+	}
+	args["signatures"] = sigs
+	return args, nil
+}
@@ -0,0 +1,249 @@
+package evmc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bbaktaeho/evmc/evmctypes/kaiatypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// Kaia 문서 예제의 sender 키 (0xa94f5374fce5edbc8e2a8697c15331677e6ebf0b)
+	kaiaSenderKey   = "0x45a915e4d060149eb4365960e6a7a45f334393093061116b197e3240065ff2d8"
+	kaiaFeePayerKey = "0xb9d5558443585bca6f225b935950e3f6e69f9da8a5809a83f51c3365dff53936"
+)
+
+func kaiaValueTransferTx(t *testing.T) *KaiaTx {
+	t.Helper()
+	tx, err := NewFeeDelegatedValueTransferTx(&Tx{
+		ChainID:  1,
+		Nonce:    1234,
+		GasPrice: decimal.NewFromInt(25),
+		GasLimit: 1000000,
+		From:     "0xa94f5374fce5edbc8e2a8697c15331677e6ebf0b",
+		To:       "0x7b65B75d204aBed71587c9E519a89277766EE1d0",
+		Value:    decimal.NewFromInt(10),
+	})
+	require.NoError(t, err)
+	return tx
+}
+
+// recoverKaiaSigner는 sigRLP에 대한 서명자 주소를 복구한다.
+func recoverKaiaSigner(t *testing.T, sigRLP []byte, sig KaiaSignature, chainID uint64) string {
+	t.Helper()
+	recID := sig.V.Uint64() - chainID*2 - 35
+	raw := append(common.LeftPadBytes(sig.R.Bytes(), 32), common.LeftPadBytes(sig.S.Bytes(), 32)...)
+	pub, err := crypto.SigToPub(crypto.Keccak256(sigRLP), append(raw, byte(recID)))
+	require.NoError(t, err)
+	return crypto.PubkeyToAddress(*pub).Hex()
+}
+
+func Test_KaiaTx_SigRLP(t *testing.T) {
+	tx := kaiaValueTransferTx(t)
+	sigRLP, err := tx.SigRLP()
+	require.NoError(t, err)
+	assert.Equal(t,
+		"0xf839b5f4098204d219830f4240947b65b75d204abed71587c9e519a89277766ee1d00a94a94f5374fce5edbc8e2a8697c15331677e6ebf0b018080",
+		hexutil.Encode(sigRLP),
+	)
+
+	_, err = tx.FeePayerSigRLP()
+	assert.Error(t, err)
+}
+
+func Test_Wallet_SignKaiaTx_feeDelegated(t *testing.T) {
+	sender, err := NewWallet(kaiaSenderKey)
+	require.NoError(t, err)
+	feePayer, err := NewWallet(kaiaFeePayerKey)
+	require.NoError(t, err)
+
+	tx := kaiaValueTransferTx(t)
+	_, senderRaw, err := sender.SignKaiaTx(tx)
+	require.NoError(t, err)
+	senderTxHash, err := tx.SenderTxHash()
+	require.NoError(t, err)
+
+	// fee payer는 sender가 서명한 raw tx를 받아 서명한다.
+	received, err := DecodeKaiaRawTx(senderRaw)
+	require.NoError(t, err)
+	assert.Equal(t, tx.Signatures, received.Signatures)
+	assert.Empty(t, received.FeePayer)
+	assert.Equal(t, uint64(1), received.ChainID)
+
+	hash, raw, err := feePayer.SignKaiaTxAsFeePayer(received)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.Keccak256Hash(hexutil.MustDecode(raw)).Hex(), hash)
+	assert.Equal(t, byte(KaiaTxTypeFeeDelegatedValueTransfer), hexutil.MustDecode(raw)[0])
+
+	// fee payer 서명은 sender tx hash를 바꾸지 않는다.
+	afterFeePayer, err := received.SenderTxHash()
+	require.NoError(t, err)
+	assert.Equal(t, senderTxHash, afterFeePayer)
+
+	decoded, err := DecodeKaiaRawTx(raw)
+	require.NoError(t, err)
+	assert.Equal(t, feePayer.Address(), decoded.FeePayer)
+	assert.Equal(t, received.To, decoded.To)
+	assert.True(t, decoded.Value.Equal(decimal.NewFromInt(10)))
+	require.Len(t, decoded.Signatures, 1)
+	require.Len(t, decoded.FeePayerSignatures, 1)
+
+	sigRLP, err := decoded.SigRLP()
+	require.NoError(t, err)
+	assert.Equal(t, sender.Address(), recoverKaiaSigner(t, sigRLP, decoded.Signatures[0], 1))
+	feePayerSigRLP, err := decoded.FeePayerSigRLP()
+	require.NoError(t, err)
+	assert.Equal(t, feePayer.Address(), recoverKaiaSigner(t, feePayerSigRLP, decoded.FeePayerSignatures[0], 1))
+}
+
+func Test_KaiaTx_accountUpdate(t *testing.T) {
+	sender, err := NewWallet(kaiaSenderKey)
+	require.NoError(t, err)
+	feePayer, err := NewWallet(kaiaFeePayerKey)
+	require.NoError(t, err)
+	pub := hexutil.Encode(crypto.FromECDSAPub(&feePayer.pk.PublicKey))
+
+	key := kaiatypes.AccountKeyRoleBased{Keys: []kaiatypes.AccountKey{
+		kaiatypes.AccountKeyPublic{PublicKey: pub},
+		kaiatypes.AccountKeyWeightedMultiSig{Threshold: 2, WeightedKeys: []kaiatypes.WeightedPublicKey{
+			{Weight: 1, PublicKey: pub},
+			{Weight: 1, PublicKey: hexutil.Encode(crypto.CompressPubkey(&sender.pk.PublicKey))},
+		}},
+		kaiatypes.AccountKeyNil{},
+	}}
+	tx, err := NewFeeDelegatedAccountUpdateWithRatioTx(&Tx{
+		ChainID:  1001,
+		GasPrice: decimal.NewFromInt(25000000000),
+		GasLimit: 100000,
+		From:     sender.Address(),
+	}, key, 30)
+	require.NoError(t, err)
+
+	_, _, err = sender.SignKaiaTx(tx)
+	require.NoError(t, err)
+	_, raw, err := feePayer.SignKaiaTxAsFeePayer(tx)
+	require.NoError(t, err)
+
+	decoded, err := DecodeKaiaRawTx(raw)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1001), decoded.ChainID)
+	assert.Equal(t, uint8(30), decoded.FeeRatio)
+	roleBased, ok := decoded.Key.(kaiatypes.AccountKeyRoleBased)
+	require.True(t, ok)
+	require.Len(t, roleBased.Keys, 3)
+	// 공개키는 압축된 형태로 인코딩된다.
+	assert.Equal(t, hexutil.Encode(crypto.CompressPubkey(&feePayer.pk.PublicKey)), roleBased.Keys[0].(kaiatypes.AccountKeyPublic).PublicKey)
+	assert.Equal(t, uint64(2), roleBased.Keys[1].(kaiatypes.AccountKeyWeightedMultiSig).Threshold)
+	assert.Equal(t, kaiatypes.AccountKeyNil{}, roleBased.Keys[2])
+
+	reencoded, err := decoded.RawTx()
+	require.NoError(t, err)
+	assert.Equal(t, raw, hexutil.Encode(reencoded))
+}
+
+func Test_AccountKey_encoding(t *testing.T) {
+	for _, tc := range []struct {
+		key  kaiatypes.AccountKey
+		want string
+	}{
+		{kaiatypes.AccountKeyNil{}, "0x80"},
+		{kaiatypes.AccountKeyLegacy{}, "0x01c0"},
+		{kaiatypes.AccountKeyFail{}, "0x03c0"},
+	} {
+		enc, err := kaiatypes.EncodeAccountKey(tc.key)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, hexutil.Encode(enc))
+		dec, err := kaiatypes.DecodeAccountKey(enc)
+		require.NoError(t, err)
+		assert.Equal(t, tc.key, dec)
+	}
+
+	_, err := kaiatypes.EncodeAccountKey(kaiatypes.AccountKeyPublic{PublicKey: "0x1234"})
+	assert.Error(t, err)
+	_, err = kaiatypes.EncodeAccountKey(kaiatypes.AccountKeyRoleBased{Keys: []kaiatypes.AccountKey{
+		kaiatypes.AccountKeyRoleBased{Keys: []kaiatypes.AccountKey{kaiatypes.AccountKeyLegacy{}}},
+	}})
+	assert.Error(t, err)
+}
+
+func Test_NewKaiaTx_invalid(t *testing.T) {
+	base := &Tx{ChainID: 1, GasLimit: 21000, From: "0xa94f5374fce5edbc8e2a8697c15331677e6ebf0b", To: "0x7b65B75d204aBed71587c9E519a89277766EE1d0"}
+
+	_, err := NewFeeDelegatedValueTransferWithRatioTx(base, 100)
+	assert.ErrorIs(t, err, ErrInvalidFeeRatio)
+	_, err = NewFeeDelegatedValueTransferWithRatioTx(base, 0)
+	assert.ErrorIs(t, err, ErrInvalidFeeRatio)
+
+	_, err = NewKaiaAccountUpdateTx(base, nil)
+	assert.Error(t, err)
+
+	noFrom := *base
+	noFrom.From = ""
+	_, err = NewKaiaValueTransferTx(&noFrom)
+	assert.ErrorIs(t, err, ErrFromRequired)
+
+	_, err = DecodeKaiaRawTx("0x7f")
+	assert.ErrorIs(t, err, ErrUnsupportedKaiaTxType)
+}
+
+func Test_kaiaNamespace_mock_SendTransaction(t *testing.T) {
+	sender, err := NewWallet(kaiaSenderKey)
+	require.NoError(t, err)
+	feePayer, err := NewWallet(kaiaFeePayerKey)
+	require.NoError(t, err)
+
+	var submitted string
+	mock := newMockRPCServer(t)
+	mock.on("kaia_sendRawTransaction", func(params json.RawMessage) any {
+		var args []string
+		require.NoError(t, json.Unmarshal(params, &args))
+		submitted = args[0]
+		return crypto.Keccak256Hash(hexutil.MustDecode(args[0])).Hex()
+	})
+	mock.on("kaia_signTransactionAsFeePayer", func(params json.RawMessage) any {
+		var args []map[string]any
+		require.NoError(t, json.Unmarshal(params, &args))
+		assert.Equal(t, float64(KaiaTxTypeFeeDelegatedValueTransfer), args[0]["typeInt"])
+		assert.Equal(t, feePayer.Address(), args[0]["feePayer"])
+		assert.Len(t, args[0]["signatures"], 1)
+		return map[string]any{"raw": "0x09f8", "tx": map[string]any{}}
+	})
+	client := testEvmc(mock.url())
+
+	tx := kaiaValueTransferTx(t)
+	hash, err := client.Kaia().SendTransaction(tx, sender, feePayer)
+	require.NoError(t, err)
+	want, err := tx.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, want, hash)
+	decoded, err := DecodeKaiaRawTx(submitted)
+	require.NoError(t, err)
+	assert.Len(t, decoded.FeePayerSignatures, 1)
+
+	_, err = client.Kaia().SendTransaction(kaiaValueTransferTx(t), sender, nil)
+	assert.ErrorIs(t, err, ErrWalletRequired)
+	_, err = client.Kaia().SendTransaction(nil, sender, feePayer)
+	assert.ErrorIs(t, err, ErrTxRequired)
+	_, err = client.Kaia().SignTransactionAsFeePayer(nil)
+	assert.ErrorIs(t, err, ErrTxRequired)
+
+	// 노드가 보관한 fee payer 계정으로 서명
+	tx = kaiaValueTransferTx(t)
+	_, err = client.Kaia().SignTransactionAsFeePayer(tx)
+	assert.Error(t, err, "sender 서명 없이 요청할 수 없다")
+	_, _, err = sender.SignKaiaTx(tx)
+	require.NoError(t, err)
+	tx.FeePayer = feePayer.Address()
+	raw, err := client.Kaia().SignTransactionAsFeePayer(tx)
+	require.NoError(t, err)
+	assert.Equal(t, "0x09f8", raw)
+
+	_, err = client.Kaia().SendTransactionAsFeePayer(&KaiaTx{Type: KaiaTxTypeValueTransfer})
+	assert.ErrorIs(t, err, ErrNotFeeDelegated)
+}
@@ -14,6 +14,15 @@ type mockRPCServer struct {
 	mu       sync.Mutex
 	handlers map[string]func(params json.RawMessage) any
 	server   *httptest.Server
+
+	// batchLimit가 0보다 크면 그보다 큰 batch 요청을 거부한다.
+	batchLimit int
+}
+
+// mockRPCError를 handler가 반환하면 result 대신 JSON-RPC error 응답을 보낸다.
+type mockRPCError struct {
+	code    int
+	message string
 }
 
 func newMockRPCServer(t *testing.T) *mockRPCServer {
@@ -53,6 +62,10 @@ func (m *mockRPCServer) handle(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "bad request", http.StatusBadRequest)
 			return
 		}
+		if m.batchLimit > 0 && len(reqs) > m.batchLimit {
+			http.Error(w, "batch too large", http.StatusRequestEntityTooLarge)
+			return
+		}
 		responses := make([]map[string]any, 0, len(reqs))
 		for _, req := range reqs {
 			responses = append(responses, m.dispatch(req.ID, req.Method, req.Params))
@@ -91,6 +104,16 @@ func (m *mockRPCServer) dispatch(id any, method string, params json.RawMessage)
 		}
 	}
 	result := handler(params)
+	if rpcErr, ok := result.(mockRPCError); ok {
+		return map[string]any{
+			"jsonrpc": "2.0",
+			"id":      id,
+			"error": map[string]any{
+				"code":    rpcErr.code,
+				"message": rpcErr.message,
+			},
+		}
+	}
 	return map[string]any{
 		"jsonrpc": "2.0",
 		"id":      id,
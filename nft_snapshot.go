@@ -0,0 +1,516 @@
+package evmc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/bbaktaeho/evmc/evmcsoltypes"
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	defaultSnapshotLogChunkSize uint64 = 10_000
+	zeroAddress                        = "0x0000000000000000000000000000000000000000"
+	// maxSnapshotItems bounds the number of tokens read through
+	// ERC721Enumerable, as balanceOf and totalSupply come from the contract.
+	maxSnapshotItems = 1_000_000
+	// snapshotLogWindow is the number of eth_getLogs chunks sent per batch,
+	// so that a scan from an early block does not build one huge request.
+	snapshotLogWindow = 100
+)
+
+// TokenBalance is a holding of one token id. Amount is always 1 for ERC-721.
+type TokenBalance struct {
+	TokenID decimal.Decimal `json:"tokenId"`
+	Amount  decimal.Decimal `json:"amount"`
+}
+
+// HolderSnapshot maps every holder of a collection to its holdings at BlockNumber.
+// Holdings are sorted by token id.
+type HolderSnapshot struct {
+	Token       string                     `json:"token"`
+	Standard    TokenStandard              `json:"standard"`
+	BlockNumber uint64                     `json:"blockNumber"`
+	Holders     map[string][]*TokenBalance `json:"holders"`
+}
+
+// Owners returns the holder addresses in ascending order.
+func (h *HolderSnapshot) Owners() []string {
+	owners := make([]string, 0, len(h.Holders))
+	for owner := range h.Holders {
+		owners = append(owners, owner)
+	}
+	sort.Strings(owners)
+	return owners
+}
+
+// SnapshotConfig controls how a [HolderSnapshot] is built.
+type SnapshotConfig struct {
+	// FromBlock is the first block scanned for transfer logs, usually the
+	// deployment block of the collection. Default: 0.
+	FromBlock uint64
+	// LogChunkSize is the block span of a single eth_getLogs request.
+	// Default: 10000.
+	LogChunkSize uint64
+	// ForceLogs skips ERC721Enumerable and always replays Transfer logs.
+	ForceLogs bool
+}
+
+func (s *SnapshotConfig) chunkSize() uint64 {
+	if s == nil || s.LogChunkSize == 0 {
+		return defaultSnapshotLogChunkSize
+	}
+	return s.LogChunkSize
+}
+
+func (s *SnapshotConfig) fromBlock() uint64 {
+	if s == nil {
+		return 0
+	}
+	return s.FromBlock
+}
+
+// --- ERC-721 ---
+
+// TokensOfOwner returns every token id held by owner using balanceOf and a
+// batch of ERC721Enumerable tokenOfOwnerByIndex calls. The pending block is
+// not supported, as it may change between the calls.
+func (e *erc721Contract) TokensOfOwner(
+	tokenAddress string,
+	owner string,
+	blockAndTag evmctypes.BlockAndTag,
+) ([]decimal.Decimal, error) {
+	return e.tokensOfOwner(context.Background(), tokenAddress, owner, blockAndTag)
+}
+
+func (e *erc721Contract) TokensOfOwnerWithContext(
+	ctx context.Context,
+	tokenAddress string,
+	owner string,
+	blockAndTag evmctypes.BlockAndTag,
+) ([]decimal.Decimal, error) {
+	return e.tokensOfOwner(ctx, tokenAddress, owner, blockAndTag)
+}
+
+func (e *erc721Contract) tokensOfOwner(
+	ctx context.Context,
+	tokenAddress string,
+	owner string,
+	blockAndTag evmctypes.BlockAndTag,
+) ([]decimal.Decimal, error) {
+	if blockAndTag == evmctypes.Pending {
+		return nil, fmt.Errorf("TokensOfOwner: %w", ErrPendingBlockNotSupported)
+	}
+	balance, err := e.balanceOf(ctx, tokenAddress, owner, blockAndTag)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSnapshotItems(balance); err != nil {
+		return nil, fmt.Errorf("TokensOfOwner: balanceOf: %w", err)
+	}
+	inputs := make([]string, balance.IntPart())
+	for i := range inputs {
+		inputs[i] = fmt.Sprintf("%s%064s%064x", erc721TokenOfOwnerByIndexSig, strings.TrimPrefix(owner, "0x"), i)
+	}
+	ids, err := e.batchUintCalls(ctx, tokenAddress, inputs, blockAndTag)
+	if err != nil {
+		return nil, fmt.Errorf("TokensOfOwner: %w", err)
+	}
+	return ids, nil
+}
+
+// AllTokens returns every token id of the collection using totalSupply and a
+// batch of ERC721Enumerable tokenByIndex calls. The pending block is not
+// supported, as it may change between the calls.
+func (e *erc721Contract) AllTokens(tokenAddress string, blockAndTag evmctypes.BlockAndTag) ([]decimal.Decimal, error) {
+	return e.allTokens(context.Background(), tokenAddress, blockAndTag)
+}
+
+func (e *erc721Contract) AllTokensWithContext(
+	ctx context.Context,
+	tokenAddress string,
+	blockAndTag evmctypes.BlockAndTag,
+) ([]decimal.Decimal, error) {
+	return e.allTokens(ctx, tokenAddress, blockAndTag)
+}
+
+func (e *erc721Contract) allTokens(
+	ctx context.Context,
+	tokenAddress string,
+	blockAndTag evmctypes.BlockAndTag,
+) ([]decimal.Decimal, error) {
+	if blockAndTag == evmctypes.Pending {
+		return nil, fmt.Errorf("AllTokens: %w", ErrPendingBlockNotSupported)
+	}
+	supply, err := e.totalSupply(ctx, tokenAddress, blockAndTag)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSnapshotItems(supply); err != nil {
+		return nil, fmt.Errorf("AllTokens: totalSupply: %w", err)
+	}
+	inputs := make([]string, supply.IntPart())
+	for i := range inputs {
+		inputs[i] = fmt.Sprintf("%s%064x", erc721TokenByIndexSig, i)
+	}
+	ids, err := e.batchUintCalls(ctx, tokenAddress, inputs, blockAndTag)
+	if err != nil {
+		return nil, fmt.Errorf("AllTokens: %w", err)
+	}
+	return ids, nil
+}
+
+// OwnersOf returns the owner of each token id in a single batch. The owner of
+// a token whose ownerOf reverts (burned or never minted) is an empty string.
+func (e *erc721Contract) OwnersOf(
+	tokenAddress string,
+	tokenIDs []decimal.Decimal,
+	blockAndTag evmctypes.BlockAndTag,
+) ([]string, error) {
+	return e.ownersOf(context.Background(), tokenAddress, tokenIDs, blockAndTag)
+}
+
+func (e *erc721Contract) OwnersOfWithContext(
+	ctx context.Context,
+	tokenAddress string,
+	tokenIDs []decimal.Decimal,
+	blockAndTag evmctypes.BlockAndTag,
+) ([]string, error) {
+	return e.ownersOf(ctx, tokenAddress, tokenIDs, blockAndTag)
+}
+
+func (e *erc721Contract) ownersOf(
+	ctx context.Context,
+	tokenAddress string,
+	tokenIDs []decimal.Decimal,
+	blockAndTag evmctypes.BlockAndTag,
+) ([]string, error) {
+	var (
+		elements = make([]rpc.BatchElem, len(tokenIDs))
+		results  = make([]string, len(tokenIDs))
+	)
+	for i, id := range tokenIDs {
+		elements[i] = rpc.BatchElem{
+			Method: EthCall.String(),
+			Args: []any{
+				&evmctypes.QueryParams{To: tokenAddress, Data: GenerateERC721OwnerOf(id)},
+				evmctypes.ToBlockAndTag(blockAndTag),
+			},
+			Result: &results[i],
+		}
+	}
+	if err := e.c.BatchCallWithContext(ctx, elements, -1); err != nil {
+		return nil, fmt.Errorf("OwnersOf: %w", err)
+	}
+	owners := make([]string, len(tokenIDs))
+	for i, el := range elements {
+		if el.Error != nil {
+			continue
+		}
+		owner, err := evmcsoltypes.ParseSolAddress(results[i])
+		if err != nil {
+			return nil, fmt.Errorf("OwnersOf: %w", err)
+		}
+		owners[i] = owner
+	}
+	return owners, nil
+}
+
+// Snapshot builds the holder snapshot of an ERC-721 collection at blockNumber.
+// Enumerable collections are read with AllTokens and OwnersOf; others are
+// reconstructed by replaying Transfer logs from cfg.FromBlock.
+// A nil cfg uses the defaults of [SnapshotConfig].
+func (e *erc721Contract) Snapshot(tokenAddress string, blockNumber uint64, cfg *SnapshotConfig) (*HolderSnapshot, error) {
+	return e.snapshot(context.Background(), tokenAddress, blockNumber, cfg)
+}
+
+func (e *erc721Contract) SnapshotWithContext(
+	ctx context.Context,
+	tokenAddress string,
+	blockNumber uint64,
+	cfg *SnapshotConfig,
+) (*HolderSnapshot, error) {
+	return e.snapshot(ctx, tokenAddress, blockNumber, cfg)
+}
+
+func (e *erc721Contract) snapshot(
+	ctx context.Context,
+	tokenAddress string,
+	blockNumber uint64,
+	cfg *SnapshotConfig,
+) (*HolderSnapshot, error) {
+	blockAndTag := evmctypes.FormatNumber(blockNumber)
+	enumerable := false
+	if cfg == nil || !cfg.ForceLogs {
+		erc165 := &erc165Contract{c: e.c}
+		enumerable, _ = erc165.supportsInterface(ctx, tokenAddress, InterfaceIDERC721Enumerable, blockAndTag)
+	}
+
+	if enumerable {
+		ids, err := e.allTokens(ctx, tokenAddress, blockAndTag)
+		if err != nil {
+			return nil, fmt.Errorf("Snapshot: %w", err)
+		}
+		owners, err := e.ownersOf(ctx, tokenAddress, ids, blockAndTag)
+		if err != nil {
+			return nil, fmt.Errorf("Snapshot: %w", err)
+		}
+		snapshot := newHolderSnapshot(tokenAddress, TokenStandardERC721, blockNumber)
+		for i, id := range ids {
+			if owners[i] == "" || owners[i] == zeroAddress {
+				continue
+			}
+			snapshot.add(owners[i], id, decimal.NewFromInt(1))
+		}
+		snapshot.sort()
+		return snapshot, nil
+	}
+
+	logs, err := getLogsInChunks(ctx, e.c, tokenAddress, TransferTopic, cfg.fromBlock(), blockNumber, cfg.chunkSize())
+	if err != nil {
+		return nil, fmt.Errorf("Snapshot: %w", err)
+	}
+	ownerOf := make(map[string]string)
+	tokenIDs := make(map[string]decimal.Decimal)
+	for _, log := range logs {
+		transfer, err := DecodeERC721Transfer(log)
+		if err != nil {
+			continue
+		}
+		key := transfer.TokenID.String()
+		ownerOf[key] = transfer.To
+		tokenIDs[key] = transfer.TokenID
+	}
+	snapshot := newHolderSnapshot(tokenAddress, TokenStandardERC721, blockNumber)
+	for key, owner := range ownerOf {
+		if owner == zeroAddress {
+			continue
+		}
+		snapshot.add(owner, tokenIDs[key], decimal.NewFromInt(1))
+	}
+	snapshot.sort()
+	return snapshot, nil
+}
+
+// checkSnapshotItems rejects a contract-reported item count that is not a
+// whole number between 0 and maxSnapshotItems.
+func checkSnapshotItems(count decimal.Decimal) error {
+	if !count.IsInteger() || count.IsNegative() || count.GreaterThan(decimal.NewFromInt(maxSnapshotItems)) {
+		return fmt.Errorf("%w: %s", ErrSnapshotTooLarge, count)
+	}
+	return nil
+}
+
+func (e *erc721Contract) batchUintCalls(
+	ctx context.Context,
+	tokenAddress string,
+	inputs []string,
+	blockAndTag evmctypes.BlockAndTag,
+) ([]decimal.Decimal, error) {
+	var (
+		elements = make([]rpc.BatchElem, len(inputs))
+		results  = make([]string, len(inputs))
+	)
+	for i, input := range inputs {
+		elements[i] = rpc.BatchElem{
+			Method: EthCall.String(),
+			Args: []any{
+				&evmctypes.QueryParams{To: tokenAddress, Data: input},
+				evmctypes.ToBlockAndTag(blockAndTag),
+			},
+			Result: &results[i],
+		}
+	}
+	if err := e.c.BatchCallWithContext(ctx, elements, -1); err != nil {
+		return nil, err
+	}
+	values := make([]decimal.Decimal, len(inputs))
+	for i, el := range elements {
+		if el.Error != nil {
+			return nil, el.Error
+		}
+		v, err := evmcsoltypes.ParseSolUintToDecimal(results[i])
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// --- ERC-1155 ---
+
+// Snapshot builds the holder snapshot of an ERC-1155 collection at blockNumber
+// by replaying TransferSingle and TransferBatch logs from cfg.FromBlock.
+// A nil cfg uses the defaults of [SnapshotConfig].
+func (e *erc1155Contract) Snapshot(tokenAddress string, blockNumber uint64, cfg *SnapshotConfig) (*HolderSnapshot, error) {
+	return e.snapshot(context.Background(), tokenAddress, blockNumber, cfg)
+}
+
+func (e *erc1155Contract) SnapshotWithContext(
+	ctx context.Context,
+	tokenAddress string,
+	blockNumber uint64,
+	cfg *SnapshotConfig,
+) (*HolderSnapshot, error) {
+	return e.snapshot(ctx, tokenAddress, blockNumber, cfg)
+}
+
+func (e *erc1155Contract) snapshot(
+	ctx context.Context,
+	tokenAddress string,
+	blockNumber uint64,
+	cfg *SnapshotConfig,
+) (*HolderSnapshot, error) {
+	var (
+		from  = cfg.fromBlock()
+		chunk = cfg.chunkSize()
+	)
+	singles, err := getLogsInChunks(ctx, e.c, tokenAddress, TransferSingleTopic, from, blockNumber, chunk)
+	if err != nil {
+		return nil, fmt.Errorf("Snapshot: %w", err)
+	}
+	batches, err := getLogsInChunks(ctx, e.c, tokenAddress, TransferBatchTopic, from, blockNumber, chunk)
+	if err != nil {
+		return nil, fmt.Errorf("Snapshot: %w", err)
+	}
+
+	type holding struct {
+		id     decimal.Decimal
+		amount decimal.Decimal
+	}
+	balances := make(map[string]map[string]*holding)
+	move := func(account string, id, delta decimal.Decimal) {
+		if account == zeroAddress {
+			return
+		}
+		held, ok := balances[account]
+		if !ok {
+			held = make(map[string]*holding)
+			balances[account] = held
+		}
+		h, ok := held[id.String()]
+		if !ok {
+			h = &holding{id: id}
+			held[id.String()] = h
+		}
+		h.amount = h.amount.Add(delta)
+	}
+	// balances are order independent, so singles and batches need no merge.
+	for _, log := range singles {
+		ev, err := DecodeTransferSingle(log)
+		if err != nil {
+			continue
+		}
+		move(ev.From, ev.ID, ev.Value.Neg())
+		move(ev.To, ev.ID, ev.Value)
+	}
+	for _, log := range batches {
+		ev, err := DecodeTransferBatch(log)
+		if err != nil {
+			continue
+		}
+		for i, id := range ev.IDs {
+			move(ev.From, id, ev.Values[i].Neg())
+			move(ev.To, id, ev.Values[i])
+		}
+	}
+
+	snapshot := newHolderSnapshot(tokenAddress, TokenStandardERC1155, blockNumber)
+	for account, held := range balances {
+		for _, h := range held {
+			if h.amount.IsPositive() {
+				snapshot.add(account, h.id, h.amount)
+			}
+		}
+	}
+	snapshot.sort()
+	return snapshot, nil
+}
+
+// --- helpers ---
+
+func newHolderSnapshot(token string, standard TokenStandard, blockNumber uint64) *HolderSnapshot {
+	return &HolderSnapshot{
+		Token:       token,
+		Standard:    standard,
+		BlockNumber: blockNumber,
+		Holders:     make(map[string][]*TokenBalance),
+	}
+}
+
+func (h *HolderSnapshot) add(owner string, id, amount decimal.Decimal) {
+	h.Holders[owner] = append(h.Holders[owner], &TokenBalance{TokenID: id, Amount: amount})
+}
+
+func (h *HolderSnapshot) sort() {
+	for _, balances := range h.Holders {
+		slices.SortFunc(balances, func(a, b *TokenBalance) int {
+			return a.TokenID.Cmp(b.TokenID)
+		})
+	}
+}
+
+// getLogsInChunks fetches the logs of address with topic0 in [from, to],
+// splitting the range into chunk sized eth_getLogs requests sent in batches of
+// snapshotLogWindow. Logs are returned in block order.
+func getLogsInChunks(
+	ctx context.Context,
+	c caller,
+	address string,
+	topic string,
+	from, to, chunk uint64,
+) ([]*evmctypes.Log, error) {
+	if from > to {
+		return nil, ErrInvalidRange
+	}
+	var logs []*evmctypes.Log
+	for start := from; ; {
+		var (
+			elements []rpc.BatchElem
+			results  []*[]*evmctypes.Log
+			end      uint64
+		)
+		for len(elements) < snapshotLogWindow {
+			end = start + min(chunk-1, to-start)
+			result := new([]*evmctypes.Log)
+			results = append(results, result)
+			elements = append(elements, rpc.BatchElem{
+				Method: EthGetLogs.String(),
+				Args: []any{map[string]any{
+					"fromBlock": hexutil.EncodeUint64(start),
+					"toBlock":   hexutil.EncodeUint64(end),
+					"address":   address,
+					"topics":    []string{topic},
+				}},
+				Result: result,
+			})
+			if end == to {
+				break
+			}
+			start = end + 1
+		}
+		if err := c.BatchCallWithContext(ctx, elements, -1); err != nil {
+			return nil, err
+		}
+		for i, el := range elements {
+			if el.Error != nil {
+				return nil, el.Error
+			}
+			for _, log := range *results[i] {
+				if !log.Removed {
+					logs = append(logs, log)
+				}
+			}
+		}
+		if end == to {
+			return logs, nil
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package evmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func uintWord(v int64) string {
+	return fmt.Sprintf("0x%064x", v)
+}
+
+func Test_erc721_mock_Snapshot_enumerable(t *testing.T) {
+	mock := newMockRPCServer(t)
+	mock.on("eth_call", func(params json.RawMessage) any {
+		data := ethCallData(t, params)
+		switch {
+		case strings.HasPrefix(data, erc165SupportsInterfaceSig):
+			return solTrue
+		case data == erc721TotalSupplySig:
+			return uintWord(3)
+		case strings.HasPrefix(data, erc721TokenByIndexSig):
+			index, _ := decodeUintWords("0x"+data[10:], 1)
+			return uintWord(index[0].IntPart() + 10)
+		case strings.HasPrefix(data, erc721OwnerOfSig):
+			id, _ := decodeUintWords("0x"+data[10:], 1)
+			if id[0].IntPart() == 11 {
+				return "0x" + topicBob[2:]
+			}
+			return "0x" + topicAlice[2:]
+		}
+		return "0x"
+	})
+
+	client := testEvmc(mock.url())
+	snapshot, err := client.ERC721().Snapshot("0xnft", 100, nil)
+	require.NoError(t, err)
+	assert.Equal(t, TokenStandardERC721, snapshot.Standard)
+	assert.Equal(t, []string{addrBob, addrAlice}, snapshot.Owners())
+	require.Len(t, snapshot.Holders[addrAlice], 2)
+	assert.Equal(t, "10", snapshot.Holders[addrAlice][0].TokenID.String())
+	assert.Equal(t, "12", snapshot.Holders[addrAlice][1].TokenID.String())
+	assert.Equal(t, "11", snapshot.Holders[addrBob][0].TokenID.String())
+}
+
+func Test_erc721_mock_TokensOfOwner(t *testing.T) {
+	mock := newMockRPCServer(t)
+	mock.on("eth_call", func(params json.RawMessage) any {
+		data := ethCallData(t, params)
+		switch {
+		case strings.HasPrefix(data, erc721BalanceOfSig):
+			return uintWord(2)
+		case strings.HasPrefix(data, erc721TokenOfOwnerByIndexSig):
+			index, _ := decodeUintWords("0x"+data[74:], 1)
+			return uintWord(index[0].IntPart() + 100)
+		}
+		return "0x"
+	})
+
+	client := testEvmc(mock.url())
+	ids, err := client.ERC721().TokensOfOwner("0xnft", addrAlice, evmctypes.Latest)
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+	assert.Equal(t, "100", ids[0].String())
+	assert.Equal(t, "101", ids[1].String())
+
+	_, err = client.ERC721().TokensOfOwner("0xnft", addrAlice, evmctypes.Pending)
+	assert.ErrorIs(t, err, ErrPendingBlockNotSupported)
+	_, err = client.ERC721().AllTokens("0xnft", evmctypes.Pending)
+	assert.ErrorIs(t, err, ErrPendingBlockNotSupported)
+}
+
+func Test_erc721_mock_AllTokens_outOfBounds(t *testing.T) {
+	mock := newMockRPCServer(t)
+	mock.on("eth_call", func(params json.RawMessage) any {
+		// 컨트랙트가 비정상적으로 큰 totalSupply를 돌려준다.
+		return "0x" + strings.Repeat("f", 64)
+	})
+
+	client := testEvmc(mock.url())
+	_, err := client.ERC721().AllTokens("0xnft", evmctypes.Latest)
+	assert.ErrorIs(t, err, ErrSnapshotTooLarge)
+	_, err = client.ERC721().TokensOfOwner("0xnft", addrAlice, evmctypes.Latest)
+	assert.ErrorIs(t, err, ErrSnapshotTooLarge)
+}
+
+func Test_erc721_mock_Snapshot_logs(t *testing.T) {
+	mock := newMockRPCServer(t)
+	transfer := func(block uint64, from, to string, id int64) map[string]any {
+		return map[string]any{
+			"address":          "0xnft",
+			"topics":           []string{TransferTopic, from, to, uintWord(id)},
+			"data":             "0x",
+			"blockNumber":      hexutil.EncodeUint64(block),
+			"transactionHash":  "0xtx",
+			"transactionIndex": "0x0",
+			"blockHash":        "0xblock",
+			"logIndex":         "0x0",
+			"removed":          false,
+		}
+	}
+	all := []map[string]any{
+		transfer(5, topicZero, topicAlice, 1),
+		transfer(6, topicZero, topicAlice, 2),
+		transfer(15, topicAlice, topicBob, 1),
+		transfer(25, topicAlice, topicZero, 2),
+	}
+	var chunks [][2]uint64
+	mock.on("eth_getLogs", func(params json.RawMessage) any {
+		var args []map[string]any
+		require.NoError(t, json.Unmarshal(params, &args))
+		from := hexutil.MustDecodeUint64(args[0]["fromBlock"].(string))
+		to := hexutil.MustDecodeUint64(args[0]["toBlock"].(string))
+		chunks = append(chunks, [2]uint64{from, to})
+		var logs []map[string]any
+		for _, l := range all {
+			n := hexutil.MustDecodeUint64(l["blockNumber"].(string))
+			if n >= from && n <= to {
+				logs = append(logs, l)
+			}
+		}
+		return logs
+	})
+
+	client := testEvmc(mock.url())
+	snapshot, err := client.ERC721().Snapshot("0xnft", 30, &SnapshotConfig{FromBlock: 1, LogChunkSize: 10, ForceLogs: true})
+	require.NoError(t, err)
+	assert.Equal(t, [][2]uint64{{1, 10}, {11, 20}, {21, 30}}, chunks)
+	assert.Equal(t, []string{addrBob}, snapshot.Owners())
+	assert.Equal(t, "1", snapshot.Holders[addrBob][0].TokenID.String())
+}
+
+func Test_mock_getLogsInChunks_window(t *testing.T) {
+	mock := newMockRPCServer(t)
+	var requests []uint64
+	mock.on("eth_getLogs", func(params json.RawMessage) any {
+		var args []map[string]any
+		require.NoError(t, json.Unmarshal(params, &args))
+		from := hexutil.MustDecodeUint64(args[0]["fromBlock"].(string))
+		requests = append(requests, from)
+		if from == 1 {
+			return mockRPCError{code: -32000, message: "query timeout"}
+		}
+		return []any{}
+	})
+
+	// 첫 window에서 실패하면 이후 window는 요청하지 않는다.
+	client := testEvmc(mock.url())
+	_, err := getLogsInChunks(context.Background(), client, "0xnft", TransferTopic, 1, snapshotLogWindow*3, 1)
+	require.Error(t, err)
+	assert.Len(t, requests, snapshotLogWindow)
+
+	requests = nil
+	logs, err := getLogsInChunks(context.Background(), client, "0xnft", TransferTopic, 2, snapshotLogWindow*3, 1)
+	require.NoError(t, err)
+	assert.Empty(t, logs)
+	assert.Len(t, requests, snapshotLogWindow*3-1)
+}
+
+func Test_erc1155_mock_Snapshot(t *testing.T) {
+	mock := newMockRPCServer(t)
+	mock.on("eth_getLogs", func(params json.RawMessage) any {
+		var args []map[string]any
+		require.NoError(t, json.Unmarshal(params, &args))
+		topic := args[0]["topics"].([]any)[0].(string)
+		base := map[string]any{
+			"address": "0xmulti", "blockNumber": "0x1", "transactionHash": "0xtx",
+			"transactionIndex": "0x0", "blockHash": "0xblock", "logIndex": "0x0", "removed": false,
+		}
+		log := func(topics []string, data string) map[string]any {
+			l := make(map[string]any, len(base)+2)
+			for k, v := range base {
+				l[k] = v
+			}
+			l["topics"], l["data"] = topics, data
+			return l
+		}
+		if topic == TransferSingleTopic {
+			return []map[string]any{
+				// mint 10 of id 1 to alice, then alice sends 4 to bob
+				log([]string{TransferSingleTopic, topicAlice, topicZero, topicAlice}, "0x"+word1+strings.Repeat("0", 63)+"a"),
+				log([]string{TransferSingleTopic, topicAlice, topicAlice, topicBob}, "0x"+word1+strings.Repeat("0", 63)+"4"),
+			}
+		}
+		// batch: alice sends ids [1, 2] amounts [6, 0] to bob
+		return []map[string]any{
+			log([]string{TransferBatchTopic, topicAlice, topicAlice, topicBob}, "0x"+
+				"0000000000000000000000000000000000000000000000000000000000000040"+
+				"00000000000000000000000000000000000000000000000000000000000000a0"+
+				word2+word1+word2+
+				word2+strings.Repeat("0", 63)+"6"+strings.Repeat("0", 64)),
+		}
+	})
+
+	client := testEvmc(mock.url())
+	snapshot, err := client.ERC1155().Snapshot("0xmulti", 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{addrBob}, snapshot.Owners())
+	require.Len(t, snapshot.Holders[addrBob], 1)
+	assert.True(t, snapshot.Holders[addrBob][0].Amount.Equal(decimal.NewFromInt(10)))
+}
@@ -27,6 +27,8 @@ type options struct {
 	maxBatchSize     int
 	batchCallWorkers int
 
+	blockIntegrityCheck bool
+
 	wsReadBufferSize   int
 	wsWriteBufferSize  int
 	wsMessageSizeLimit int
@@ -106,6 +108,15 @@ func WithBatchCallWorkers(workers int) Options {
 	})
 }
 
+// WithBlockIntegrityCheck makes [ethNamespace.GetBlockIncTxRange] fetch the
+// receipts of every block and verify it with [VerifyBlock]. A block that
+// fails the check is reported as a [*BlockIntegrityError]. Default: disabled.
+func WithBlockIntegrityCheck() Options {
+	return optionFunc(func(o *options) {
+		o.blockIntegrityCheck = true
+	})
+}
+
 // WithWsReadBufferSize sets the WebSocket read buffer size in bytes.
 // Default: 1024.
 func WithWsReadBufferSize(size int) Options {
@@ -16,6 +16,8 @@ func (p Procedure) String() string {
 
 const (
 	Web3ClientVersion Procedure = "web3_clientVersion"
+	RPCModules        Procedure = "rpc_modules"
+	TxpoolStatus      Procedure = "txpool_status"
 
 	EthNewBlockFilter              Procedure = "eth_newBlockFilter"
 	EthNewPendingTransactionFilter Procedure = "eth_newPendingTransactionFilter"
@@ -69,6 +71,7 @@ const (
 	DebugGetBadBlocks      Procedure = "debug_getBadBlocks"
 
 	OtsGetContractCreator Procedure = "ots_getContractCreator" // erigon
+	OtsGetAPILevel        Procedure = "ots_getApiLevel"        // erigon
 	TraceBlock            Procedure = "trace_block"            // erigon
 
 	// arb_trace methods on the Arbitrum One chain should be called on blocks prior to 22207815
@@ -77,6 +80,8 @@ const (
 
 const (
 	KaiaBlockNumber                            Procedure = "kaia_blockNumber"
+	KaiaGetAccount                             Procedure = "kaia_getAccount"
+	KaiaGetAccountKey                          Procedure = "kaia_getAccountKey"
 	KaiaGetBlockByHash                         Procedure = "kaia_getBlockByHash"
 	KaiaGetBlockByNumber                       Procedure = "kaia_getBlockByNumber"
 	KaiaGetBlockReceipts                       Procedure = "kaia_getBlockReceipts"
@@ -91,6 +96,7 @@ const (
 	KaiaGetCouncilSize                         Procedure = "kaia_getCouncilSize"
 	KaiaGetHeaderByHash                        Procedure = "kaia_getHeaderByHash"
 	KaiaGetHeaderByNumber                      Procedure = "kaia_getHeaderByNumber"
+	KaiaGetLogs                                Procedure = "kaia_getLogs"
 	KaiaGetRewards                             Procedure = "kaia_getRewards"
 	KaiaGetStorageAt                           Procedure = "kaia_getStorageAt"
 	KaiaSyncing                                Procedure = "kaia_syncing"
@@ -0,0 +1,160 @@
+package evmc
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/shopspring/decimal"
+)
+
+// ProvenAccount is the account state proven by an eth_getProof response.
+type ProvenAccount struct {
+	Address     string
+	Exists      bool // false when the proof proves the account is absent
+	Nonce       uint64
+	Balance     decimal.Decimal
+	CodeHash    string
+	StorageHash string
+	Storage     []*ProvenSlot
+}
+
+// ProvenSlot is a storage slot value proven against an account's storage
+// root. Value is the 32-byte word stored at Key.
+type ProvenSlot struct {
+	Key   string
+	Value string
+}
+
+// VerifyAccountProof verifies proof against stateRoot and every storage proof
+// it contains against the proven storage root. It fails with
+// [ErrInvalidProof] when a Merkle path does not resolve or when a value
+// claimed by the response differs from the proven one.
+func VerifyAccountProof(stateRoot string, proof *evmctypes.AccountProof) (*ProvenAccount, error) {
+	if !common.IsHexAddress(proof.Address) {
+		return nil, fmt.Errorf("VerifyAccountProof: %w: address %q", ErrInvalidProof, proof.Address)
+	}
+	key := crypto.Keccak256(common.HexToAddress(proof.Address).Bytes())
+	value, err := verifyMerkleProof(common.HexToHash(stateRoot), key, proof.AccountProof)
+	if err != nil {
+		return nil, fmt.Errorf("VerifyAccountProof: account: %w", err)
+	}
+
+	result := &ProvenAccount{Address: proof.Address}
+	account := &types.StateAccount{
+		Root:     types.EmptyRootHash,
+		CodeHash: types.EmptyCodeHash.Bytes(),
+	}
+	if value != nil {
+		if err := rlp.DecodeBytes(value, account); err != nil {
+			return nil, fmt.Errorf("VerifyAccountProof: %w: %w", ErrInvalidProof, err)
+		}
+		result.Exists = true
+		result.Nonce = account.Nonce
+		result.Balance = decimal.NewFromBigInt(account.Balance.ToBig(), 0)
+	}
+	result.CodeHash = common.BytesToHash(account.CodeHash).Hex()
+	result.StorageHash = account.Root.Hex()
+
+	if err := checkProvenNumber("balance", proof.Balance, result.Balance.BigInt()); err != nil {
+		return nil, fmt.Errorf("VerifyAccountProof: %w", err)
+	}
+	if err := checkProvenNumber("nonce", proof.Nonce, new(big.Int).SetUint64(result.Nonce)); err != nil {
+		return nil, fmt.Errorf("VerifyAccountProof: %w", err)
+	}
+	// Nodes report zero hashes for absent accounts, so the hashes are only
+	// compared for accounts that exist.
+	if result.Exists {
+		if common.HexToHash(proof.CodeHash) != common.BytesToHash(account.CodeHash) {
+			return nil, fmt.Errorf("VerifyAccountProof: %w: codeHash %s, proven %s", ErrInvalidProof, proof.CodeHash, result.CodeHash)
+		}
+		if common.HexToHash(proof.StorageHash) != account.Root {
+			return nil, fmt.Errorf("VerifyAccountProof: %w: storageHash %s, proven %s", ErrInvalidProof, proof.StorageHash, result.StorageHash)
+		}
+	}
+
+	result.Storage = make([]*ProvenSlot, len(proof.StorageProof))
+	for i, sp := range proof.StorageProof {
+		if result.Storage[i], err = VerifyStorageProof(result.StorageHash, sp); err != nil {
+			return nil, fmt.Errorf("VerifyAccountProof: storage %s: %w", sp.Key, err)
+		}
+	}
+	return result, nil
+}
+
+// VerifyStorageProof verifies a single storage proof against storageHash and
+// returns the proven slot value.
+func VerifyStorageProof(storageHash string, proof *evmctypes.StorageProof) (*ProvenSlot, error) {
+	key, err := hexutil.DecodeBig(trimLeadingZeroHex(proof.Key))
+	if err != nil || key.BitLen() > 256 {
+		return nil, fmt.Errorf("%w: storage key %q", ErrInvalidProof, proof.Key)
+	}
+	slot := common.BigToHash(key)
+	value, err := verifyMerkleProof(common.HexToHash(storageHash), crypto.Keccak256(slot.Bytes()), proof.Proof)
+	if err != nil {
+		return nil, err
+	}
+	var word []byte
+	if value != nil {
+		if _, word, _, err = rlp.Split(value); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidProof, err)
+		}
+	}
+	proven := new(big.Int).SetBytes(word)
+	if err := checkProvenNumber("value", proof.Value, proven); err != nil {
+		return nil, err
+	}
+	return &ProvenSlot{Key: slot.Hex(), Value: common.BigToHash(proven).Hex()}, nil
+}
+
+// verifyMerkleProof resolves key in the trie rooted at root using the given
+// proof nodes. A nil value proves that the key is absent.
+func verifyMerkleProof(root common.Hash, key []byte, proof []string) ([]byte, error) {
+	if root == types.EmptyRootHash && len(proof) == 0 {
+		return nil, nil
+	}
+	db := memorydb.New()
+	for _, node := range proof {
+		b, err := hexutil.Decode(node)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidProof, err)
+		}
+		if err := db.Put(crypto.Keccak256(b), b); err != nil {
+			return nil, err
+		}
+	}
+	value, err := trie.VerifyProof(root, key, db)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+	return value, nil
+}
+
+func checkProvenNumber(field, claimed string, proven *big.Int) error {
+	v, err := hexutil.DecodeBig(trimLeadingZeroHex(claimed))
+	if err != nil {
+		return fmt.Errorf("%w: %s %q", ErrInvalidProof, field, claimed)
+	}
+	if v.Cmp(proven) != 0 {
+		return fmt.Errorf("%w: %s %s, proven %s", ErrInvalidProof, field, claimed, hexutil.EncodeBig(proven))
+	}
+	return nil
+}
+
+// trimLeadingZeroHex turns zero-padded quantities such as storage keys and
+// values ("0x00…01") into the form accepted by hexutil.DecodeBig.
+func trimLeadingZeroHex(s string) string {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if s = strings.TrimLeft(s, "0"); s == "" {
+		return "0x0"
+	}
+	return "0x" + s
+}
@@ -0,0 +1,123 @@
+package evmc
+
+import (
+	"testing"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// proofNodes는 trie에서 key의 Merkle proof를 만들어 eth_getProof 형식으로 반환한다.
+func proofNodes(t *testing.T, tr *trie.Trie, key []byte) []string {
+	t.Helper()
+	db := memorydb.New()
+	require.NoError(t, tr.Prove(crypto.Keccak256(key), db))
+	var nodes []string
+	it := db.NewIterator(nil, nil)
+	defer it.Release()
+	for it.Next() {
+		nodes = append(nodes, hexutil.Encode(it.Value()))
+	}
+	return nodes
+}
+
+// proofFixture는 slot 1 = 0x2a 인 컨트랙트 하나를 가진 state trie와 eth_getProof 응답을 만든다.
+func proofFixture(t *testing.T) (common.Hash, *evmctypes.AccountProof, *trie.Trie) {
+	t.Helper()
+	tdb := triedb.NewDatabase(rawdb.NewMemoryDatabase(), nil)
+	slot := common.BigToHash(common.Big1)
+
+	storage := trie.NewEmpty(tdb)
+	value, _ := rlp.EncodeToBytes([]byte{0x2a})
+	require.NoError(t, storage.Update(crypto.Keccak256(slot.Bytes()), value))
+	storageRoot := storage.Hash()
+
+	addr := common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	codeHash := crypto.Keccak256Hash([]byte{0x60, 0x00})
+	account, _ := rlp.EncodeToBytes(&types.StateAccount{
+		Nonce: 1, Balance: uint256.NewInt(1000), Root: storageRoot, CodeHash: codeHash.Bytes(),
+	})
+	state := trie.NewEmpty(tdb)
+	require.NoError(t, state.Update(crypto.Keccak256(addr.Bytes()), account))
+	other, _ := rlp.EncodeToBytes(types.NewEmptyStateAccount())
+	require.NoError(t, state.Update(crypto.Keccak256(common.HexToAddress("0x01").Bytes()), other))
+
+	return state.Hash(), &evmctypes.AccountProof{
+		Address:      addr.Hex(),
+		AccountProof: proofNodes(t, state, addr.Bytes()),
+		Balance:      "0x3e8",
+		CodeHash:     codeHash.Hex(),
+		Nonce:        "0x1",
+		StorageHash:  storageRoot.Hex(),
+		StorageProof: []*evmctypes.StorageProof{
+			{Key: "0x1", Value: "0x2a", Proof: proofNodes(t, storage, slot.Bytes())},
+			{Key: common.BigToHash(common.Big2).Hex(), Value: "0x0", Proof: proofNodes(t, storage, common.BigToHash(common.Big2).Bytes())},
+		},
+	}, state
+}
+
+func Test_VerifyAccountProof(t *testing.T) {
+	root, proof, _ := proofFixture(t)
+
+	account, err := VerifyAccountProof(root.Hex(), proof)
+	require.NoError(t, err)
+	assert.True(t, account.Exists)
+	assert.Equal(t, uint64(1), account.Nonce)
+	assert.Equal(t, "1000", account.Balance.String())
+	assert.Equal(t, proof.CodeHash, account.CodeHash)
+	require.Len(t, account.Storage, 2)
+	assert.Equal(t, common.BigToHash(common.Big1).Hex(), account.Storage[0].Key)
+	assert.Equal(t, common.BigToHash(common.Big2).Hex(), account.Storage[1].Key)
+	assert.Equal(t, common.BigToHash(common.Big0).Hex(), account.Storage[1].Value)
+
+	// 잘못된 state root
+	_, err = VerifyAccountProof(common.HexToHash("0x01").Hex(), proof)
+	assert.ErrorIs(t, err, ErrInvalidProof)
+
+	// 응답의 잔액이 증명된 값과 다르다.
+	proof.Balance = "0x3e9"
+	_, err = VerifyAccountProof(root.Hex(), proof)
+	assert.ErrorIs(t, err, ErrInvalidProof)
+	proof.Balance = "0x3e8"
+
+	// 응답의 slot 값이 증명된 값과 다르다.
+	proof.StorageProof[0].Value = "0x2b"
+	_, err = VerifyAccountProof(root.Hex(), proof)
+	assert.ErrorIs(t, err, ErrInvalidProof)
+}
+
+func Test_VerifyAccountProof_absent(t *testing.T) {
+	root, _, state := proofFixture(t)
+	addr := common.HexToAddress("0x00000000000000000000000000000000000000ff")
+	proof := &evmctypes.AccountProof{
+		Address:      addr.Hex(),
+		AccountProof: proofNodes(t, state, addr.Bytes()),
+		Balance:      "0x0",
+		CodeHash:     common.Hash{}.Hex(),
+		Nonce:        "0x0",
+		StorageHash:  common.Hash{}.Hex(),
+		StorageProof: []*evmctypes.StorageProof{{Key: "0x0", Value: "0x0", Proof: []string{}}},
+	}
+
+	account, err := VerifyAccountProof(root.Hex(), proof)
+	require.NoError(t, err)
+	assert.False(t, account.Exists)
+	assert.True(t, account.Balance.IsZero())
+	assert.Equal(t, types.EmptyRootHash.Hex(), account.StorageHash)
+
+	// 존재하지 않는 계정에 잔액이 있다고 주장하는 응답
+	proof.Balance = "0x1"
+	_, err = VerifyAccountProof(root.Hex(), proof)
+	assert.ErrorIs(t, err, ErrInvalidProof)
+}
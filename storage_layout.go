@@ -0,0 +1,385 @@
+package evmc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Well-known proxy storage slots.
+const (
+	// ERC1967ImplementationSlot is bytes32(uint256(keccak256("eip1967.proxy.implementation")) - 1).
+	ERC1967ImplementationSlot = "0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc"
+	// ERC1967AdminSlot is bytes32(uint256(keccak256("eip1967.proxy.admin")) - 1).
+	ERC1967AdminSlot = "0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103"
+	// ERC1967BeaconSlot is bytes32(uint256(keccak256("eip1967.proxy.beacon")) - 1).
+	ERC1967BeaconSlot = "0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50"
+	// EIP1822ProxiableSlot is keccak256("PROXIABLE").
+	EIP1822ProxiableSlot = "0xc5f16f0fcc639fa48a6947836d9850f504798523bf8c9a3a87d5876cf622bcf7"
+
+	beaconImplementationSig = "0x5c60da1b" // implementation()
+)
+
+// maxStorageBytesLength bounds the length decoded from a long string/bytes
+// slot so that a garbage word does not trigger millions of reads.
+const maxStorageBytesLength = 1 << 20
+
+var slotModulus = new(big.Int).Lsh(common.Big1, 256)
+
+// StorageLocation is a value located at Offset bytes from the low-order end
+// of Slot, as laid out by the Solidity compiler for packed variables.
+type StorageLocation struct {
+	Slot   string
+	Offset int
+	Size   int
+}
+
+// Extract returns the Size bytes of the location from the 32-byte word read
+// at Slot.
+func (l StorageLocation) Extract(word string) ([]byte, error) {
+	if l.Offset < 0 || l.Size <= 0 || l.Offset+l.Size > 32 {
+		return nil, fmt.Errorf("Extract: invalid offset %d and size %d", l.Offset, l.Size)
+	}
+	b, err := decodeWord(word)
+	if err != nil {
+		return nil, fmt.Errorf("Extract: %w", err)
+	}
+	end := 32 - l.Offset
+	return b[end-l.Size : end], nil
+}
+
+// SlotAdd returns slot+n modulo 2^256, e.g. the slot of the n-th member of a
+// struct stored at slot.
+func SlotAdd(slot string, n uint64) string {
+	v := new(big.Int).SetBytes(common.HexToHash(slot).Bytes())
+	v.Add(v, new(big.Int).SetUint64(n)).Mod(v, slotModulus)
+	return common.BigToHash(v).Hex()
+}
+
+// MappingStringKey is a string or bytes key of a Solidity mapping, hashed
+// unpadded by [MappingSlot].
+type MappingStringKey string
+
+// MappingSlot returns the slot of mapping[keys[0]][keys[1]]... for a mapping
+// declared at slot. Value-type keys are the 32-byte encodings produced by
+// evmcsoltypes (Address, Uint256, Bool, FixedBytes); string and bytes keys
+// are passed as [MappingStringKey] or []byte and used unpadded, as Solidity
+// does. A plain Go string is rejected, since an address or number given as a
+// hex string would silently yield a wrong slot.
+func MappingSlot(slot string, keys ...any) (string, error) {
+	current := common.HexToHash(slot)
+	for i, key := range keys {
+		var k []byte
+		switch v := key.(type) {
+		case []byte:
+			k = v
+		case MappingStringKey:
+			k = []byte(v)
+		case string:
+			return "", fmt.Errorf("MappingSlot: key %d: ambiguous string %q, use MappingStringKey or an evmcsoltypes encoding", i, v)
+		default:
+			return "", fmt.Errorf("MappingSlot: key %d: unsupported type %T", i, key)
+		}
+		current = crypto.Keccak256Hash(k, current.Bytes())
+	}
+	return current.Hex(), nil
+}
+
+// DynamicArrayDataSlot returns the slot where the elements of a dynamic
+// array (or a long string/bytes) declared at slot start.
+func DynamicArrayDataSlot(slot string) string {
+	return crypto.Keccak256Hash(common.HexToHash(slot).Bytes()).Hex()
+}
+
+// ArrayElementLocation returns the location of element index of a dynamic
+// array declared at slot. elemSize is the element size in bytes and must be
+// positive; elements of up to 16 bytes are packed several per slot, and
+// elements of more than 32 bytes (structs, static arrays) are given as a
+// multiple of 32.
+func ArrayElementLocation(slot string, index uint64, elemSize int) (StorageLocation, error) {
+	if elemSize <= 0 {
+		return StorageLocation{}, fmt.Errorf("ArrayElementLocation: invalid element size %d", elemSize)
+	}
+	data := DynamicArrayDataSlot(slot)
+	if elemSize >= 32 {
+		slots := uint64((elemSize + 31) / 32)
+		return StorageLocation{Slot: SlotAdd(data, index*slots), Size: 32}, nil
+	}
+	perSlot := uint64(32 / elemSize)
+	return StorageLocation{
+		Slot:   SlotAdd(data, index/perSlot),
+		Offset: int(index%perSlot) * elemSize,
+		Size:   elemSize,
+	}, nil
+}
+
+// ProxyInfo is the proxy configuration read from the standard proxy slots.
+// Empty fields mean the corresponding slot is unset.
+type ProxyInfo struct {
+	Implementation string // ERC-1967 implementation, EIP-1822 logic or beacon implementation
+	Admin          string // ERC-1967 admin
+	Beacon         string // ERC-1967 beacon
+	Proxiable      bool   // Implementation was read from the EIP-1822 slot
+}
+
+// IsProxy reports whether any proxy slot is set.
+func (p *ProxyInfo) IsProxy() bool {
+	return p.Implementation != "" || p.Beacon != ""
+}
+
+type storageLayout struct {
+	c caller
+}
+
+// ReadSlots reads the given slots of address in one batch.
+func (s *storageLayout) ReadSlots(address string, slots []string, blockAndTag evmctypes.BlockAndTag) ([]string, error) {
+	return s.ReadSlotsWithContext(context.Background(), address, slots, blockAndTag)
+}
+
+// ReadSlotsWithContext is the context-aware variant of [storageLayout.ReadSlots].
+func (s *storageLayout) ReadSlotsWithContext(
+	ctx context.Context,
+	address string,
+	slots []string,
+	blockAndTag evmctypes.BlockAndTag,
+) ([]string, error) {
+	var (
+		words    = make([]string, len(slots))
+		elements = make([]rpc.BatchElem, len(slots))
+	)
+	for i, slot := range slots {
+		elements[i] = rpc.BatchElem{
+			Method: EthGetStorageAt.String(),
+			Args:   []any{address, common.HexToHash(slot).Hex(), blockAndTag},
+			Result: &words[i],
+		}
+	}
+	if err := s.c.BatchCallWithContext(ctx, elements, -1); err != nil {
+		return nil, fmt.Errorf("ReadSlots: %w", err)
+	}
+	for _, el := range elements {
+		if el.Error != nil {
+			return nil, fmt.Errorf("ReadSlots: %w", el.Error)
+		}
+	}
+	return words, nil
+}
+
+// ReadStruct reads the n consecutive slots of a struct (or static array)
+// stored at slot in one batch.
+func (s *storageLayout) ReadStruct(address, slot string, n uint64, blockAndTag evmctypes.BlockAndTag) ([]string, error) {
+	return s.ReadStructWithContext(context.Background(), address, slot, n, blockAndTag)
+}
+
+// ReadStructWithContext is the context-aware variant of [storageLayout.ReadStruct].
+func (s *storageLayout) ReadStructWithContext(
+	ctx context.Context,
+	address string,
+	slot string,
+	n uint64,
+	blockAndTag evmctypes.BlockAndTag,
+) ([]string, error) {
+	slots := make([]string, n)
+	for i := range slots {
+		slots[i] = SlotAdd(slot, uint64(i))
+	}
+	return s.ReadSlotsWithContext(ctx, address, slots, blockAndTag)
+}
+
+// ReadBytes reads a string or bytes state variable stored at slot, following
+// the long encoding into the data slots when the value exceeds 31 bytes.
+func (s *storageLayout) ReadBytes(address, slot string, blockAndTag evmctypes.BlockAndTag) ([]byte, error) {
+	return s.ReadBytesWithContext(context.Background(), address, slot, blockAndTag)
+}
+
+// ReadBytesWithContext is the context-aware variant of [storageLayout.ReadBytes].
+func (s *storageLayout) ReadBytesWithContext(
+	ctx context.Context,
+	address string,
+	slot string,
+	blockAndTag evmctypes.BlockAndTag,
+) ([]byte, error) {
+	words, err := s.ReadSlotsWithContext(ctx, address, []string{slot}, blockAndTag)
+	if err != nil {
+		return nil, err
+	}
+	head, err := decodeWord(words[0])
+	if err != nil {
+		return nil, fmt.Errorf("ReadBytes: %w", err)
+	}
+	// Short values (< 32 bytes) keep the data in the high-order bytes and
+	// length*2 in the lowest byte; long values store length*2+1.
+	if head[31]&1 == 0 {
+		length := int(head[31] / 2)
+		if length > 31 {
+			return nil, fmt.Errorf("ReadBytes: invalid short length %d", length)
+		}
+		return head[:length], nil
+	}
+	v := new(big.Int).SetBytes(head)
+	length := v.Sub(v, common.Big1).Rsh(v, 1)
+	if !length.IsUint64() || length.Uint64() > maxStorageBytesLength {
+		return nil, fmt.Errorf("ReadBytes: invalid long length %s", length)
+	}
+	n := length.Uint64()
+	data, err := s.ReadStructWithContext(ctx, address, DynamicArrayDataSlot(slot), (n+31)/32, blockAndTag)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]byte, 0, len(data)*32)
+	for _, word := range data {
+		b, err := decodeWord(word)
+		if err != nil {
+			return nil, fmt.Errorf("ReadBytes: %w", err)
+		}
+		result = append(result, b...)
+	}
+	return result[:n], nil
+}
+
+// ReadString is [storageLayout.ReadBytes] for string state variables.
+func (s *storageLayout) ReadString(address, slot string, blockAndTag evmctypes.BlockAndTag) (string, error) {
+	return s.ReadStringWithContext(context.Background(), address, slot, blockAndTag)
+}
+
+// ReadStringWithContext is the context-aware variant of [storageLayout.ReadString].
+func (s *storageLayout) ReadStringWithContext(
+	ctx context.Context,
+	address string,
+	slot string,
+	blockAndTag evmctypes.BlockAndTag,
+) (string, error) {
+	b, err := s.ReadBytesWithContext(ctx, address, slot, blockAndTag)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// ReadArray reads the length of the dynamic array declared at slot and then
+// all of its data slots in one batch. elemSize is as in
+// [ArrayElementLocation]; use the returned words with
+// [ArrayElementLocation] to extract packed elements.
+func (s *storageLayout) ReadArray(
+	address string,
+	slot string,
+	elemSize int,
+	blockAndTag evmctypes.BlockAndTag,
+) (uint64, []string, error) {
+	return s.ReadArrayWithContext(context.Background(), address, slot, elemSize, blockAndTag)
+}
+
+// ReadArrayWithContext is the context-aware variant of [storageLayout.ReadArray].
+func (s *storageLayout) ReadArrayWithContext(
+	ctx context.Context,
+	address string,
+	slot string,
+	elemSize int,
+	blockAndTag evmctypes.BlockAndTag,
+) (uint64, []string, error) {
+	if elemSize <= 0 {
+		return 0, nil, fmt.Errorf("ReadArray: invalid element size %d", elemSize)
+	}
+	words, err := s.ReadSlotsWithContext(ctx, address, []string{slot}, blockAndTag)
+	if err != nil {
+		return 0, nil, err
+	}
+	length, err := hexutil.DecodeBig(trimLeadingZeroHex(words[0]))
+	if err != nil || !length.IsUint64() || length.Uint64() > maxStorageBytesLength {
+		return 0, nil, fmt.Errorf("ReadArray: invalid length %s", words[0])
+	}
+	n := length.Uint64()
+	if n == 0 {
+		return 0, nil, nil
+	}
+	last, err := ArrayElementLocation(slot, n-1, elemSize)
+	if err != nil {
+		return 0, nil, fmt.Errorf("ReadArray: %w", err)
+	}
+	first := new(big.Int).SetBytes(common.HexToHash(DynamicArrayDataSlot(slot)).Bytes())
+	span := new(big.Int).SetBytes(common.HexToHash(last.Slot).Bytes())
+	span.Sub(span, first).Mod(span, slotModulus)
+	if elemSize > 32 {
+		span.Add(span, big.NewInt(int64((elemSize+31)/32-1)))
+	}
+	data, err := s.ReadStructWithContext(ctx, address, DynamicArrayDataSlot(slot), span.Uint64()+1, blockAndTag)
+	if err != nil {
+		return 0, nil, err
+	}
+	return n, data, nil
+}
+
+// GetProxyInfo reads the ERC-1967 implementation, admin and beacon slots and
+// the EIP-1822 slot of address in one batch. For beacon proxies the
+// implementation is then read from the beacon's implementation().
+func (s *storageLayout) GetProxyInfo(address string, blockAndTag evmctypes.BlockAndTag) (*ProxyInfo, error) {
+	return s.GetProxyInfoWithContext(context.Background(), address, blockAndTag)
+}
+
+// GetProxyInfoWithContext is the context-aware variant of [storageLayout.GetProxyInfo].
+func (s *storageLayout) GetProxyInfoWithContext(
+	ctx context.Context,
+	address string,
+	blockAndTag evmctypes.BlockAndTag,
+) (*ProxyInfo, error) {
+	words, err := s.ReadSlotsWithContext(ctx, address, []string{
+		ERC1967ImplementationSlot,
+		ERC1967AdminSlot,
+		ERC1967BeaconSlot,
+		EIP1822ProxiableSlot,
+	}, blockAndTag)
+	if err != nil {
+		return nil, err
+	}
+	info := &ProxyInfo{
+		Implementation: wordToAddress(words[0]),
+		Admin:          wordToAddress(words[1]),
+		Beacon:         wordToAddress(words[2]),
+	}
+	if info.Implementation == "" {
+		if info.Implementation = wordToAddress(words[3]); info.Implementation != "" {
+			info.Proxiable = true
+		}
+	}
+	if info.Implementation == "" && info.Beacon != "" {
+		result := new(string)
+		params := &evmctypes.QueryParams{To: info.Beacon, Data: beaconImplementationSig}
+		if err := s.c.call(ctx, result, EthCall, params, blockAndTag); err != nil {
+			return nil, fmt.Errorf("GetProxyInfo: beacon: %w", err)
+		}
+		info.Implementation = wordToAddress(*result)
+	}
+	return info, nil
+}
+
+// decodeWord decodes a storage word, left-padding short results to 32 bytes.
+func decodeWord(word string) ([]byte, error) {
+	b, err := hexutil.Decode(word)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > 32 {
+		return nil, fmt.Errorf("word longer than 32 bytes: %s", word)
+	}
+	return common.LeftPadBytes(b, 32), nil
+}
+
+// wordToAddress returns the address held in the low 20 bytes of word, or ""
+// when the word is zero.
+func wordToAddress(word string) string {
+	b, err := decodeWord(word)
+	if err != nil {
+		return ""
+	}
+	addr := common.BytesToAddress(b)
+	if addr == (common.Address{}) {
+		return ""
+	}
+	return addr.Hex()
+}
@@ -0,0 +1,175 @@
+package evmc
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/bbaktaeho/evmc/evmcsoltypes"
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// storageMock은 slot → word 맵을 eth_getStorageAt으로 제공한다.
+func storageMock(t *testing.T, storage map[common.Hash]common.Hash) *mockRPCServer {
+	t.Helper()
+	mock := newMockRPCServer(t)
+	mock.on("eth_getStorageAt", func(params json.RawMessage) any {
+		var args []string
+		require.NoError(t, json.Unmarshal(params, &args))
+		return storage[common.HexToHash(args[1])].Hex()
+	})
+	return mock
+}
+
+func Test_StorageSlots(t *testing.T) {
+	// 표준 proxy slot 상수 검증
+	minusOne := func(s string) string {
+		v := new(big.Int).SetBytes(crypto.Keccak256([]byte(s)))
+		return common.BigToHash(v.Sub(v, common.Big1)).Hex()
+	}
+	assert.Equal(t, minusOne("eip1967.proxy.implementation"), ERC1967ImplementationSlot)
+	assert.Equal(t, minusOne("eip1967.proxy.admin"), ERC1967AdminSlot)
+	assert.Equal(t, minusOne("eip1967.proxy.beacon"), ERC1967BeaconSlot)
+	assert.Equal(t, crypto.Keccak256Hash([]byte("PROXIABLE")).Hex(), EIP1822ProxiableSlot)
+
+	// mapping(address => mapping(uint256 => uint256)) at slot 3
+	owner := "0x00000000000000000000000000000000000000aa"
+	slot, err := MappingSlot("0x3", evmcsoltypes.Address(owner), evmcsoltypes.Uint256(decimal.NewFromInt(7)))
+	require.NoError(t, err)
+	inner := crypto.Keccak256(common.HexToHash(owner).Bytes(), common.HexToHash("0x3").Bytes())
+	assert.Equal(t, crypto.Keccak256Hash(common.HexToHash("0x7").Bytes(), inner).Hex(), slot)
+
+	// string key는 padding 없이 사용한다.
+	slot, err = MappingSlot("0x1", MappingStringKey("abc"))
+	require.NoError(t, err)
+	assert.Equal(t, crypto.Keccak256Hash([]byte("abc"), common.HexToHash("0x1").Bytes()).Hex(), slot)
+
+	// 주소를 Go string으로 넘기면 잘못된 slot이 되므로 거부한다.
+	_, err = MappingSlot("0x1", owner)
+	assert.Error(t, err)
+	_, err = MappingSlot("0x1", 1)
+	assert.Error(t, err)
+
+	// uint64[] at slot 2: 한 slot에 4개씩 packed
+	loc, err := ArrayElementLocation("0x2", 5, 8)
+	require.NoError(t, err)
+	assert.Equal(t, SlotAdd(DynamicArrayDataSlot("0x2"), 1), loc.Slot)
+	assert.Equal(t, 8, loc.Offset)
+
+	// struct {uint256; uint256; uint256}[] 요소는 3 slot을 차지한다.
+	loc, err = ArrayElementLocation("0x2", 2, 96)
+	require.NoError(t, err)
+	assert.Equal(t, SlotAdd(DynamicArrayDataSlot("0x2"), 6), loc.Slot)
+
+	_, err = ArrayElementLocation("0x2", 0, 0)
+	assert.Error(t, err)
+
+	// slot 덧셈은 2^256에서 wrap된다.
+	assert.Equal(t, common.BigToHash(common.Big1).Hex(), SlotAdd(common.MaxHash.Hex(), 2))
+
+	// packed 변수: uint128 a (offset 0) + address b (offset 16) 중 b 추출
+	b, err := StorageLocation{Offset: 16, Size: 16}.Extract("0x" + strings.Repeat("11", 16) + strings.Repeat("22", 16))
+	require.NoError(t, err)
+	assert.Equal(t, "0x"+strings.Repeat("11", 16), hexutil.Encode(b))
+	b, err = StorageLocation{Offset: 0, Size: 1}.Extract("0x2a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x2a}, b)
+}
+
+func Test_storageLayout_mock_ReadBytes(t *testing.T) {
+	long := strings.Repeat("evmc storage ", 5) // 65 bytes, 3 slots
+	data := common.HexToHash(DynamicArrayDataSlot("0x1"))
+	storage := map[common.Hash]common.Hash{
+		// short string "hello": 데이터 + length*2
+		common.HexToHash("0x0"): common.BytesToHash(append(common.RightPadBytes([]byte("hello"), 31), 10)),
+		common.HexToHash("0x1"): common.BigToHash(big.NewInt(int64(len(long)*2 + 1))),
+	}
+	for i := 0; i*32 < len(long); i++ {
+		chunk := []byte(long[i*32 : min((i+1)*32, len(long))])
+		storage[common.HexToHash(SlotAdd(data.Hex(), uint64(i)))] = common.BytesToHash(common.RightPadBytes(chunk, 32))
+	}
+	client := testEvmc(storageMock(t, storage).url())
+
+	s, err := client.Storage().ReadString("0xcontract", "0x0", evmctypes.Latest)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", s)
+
+	s, err = client.Storage().ReadString("0xcontract", "0x1", evmctypes.Latest)
+	require.NoError(t, err)
+	assert.Equal(t, long, s)
+}
+
+func Test_storageLayout_mock_ReadArrayAndStruct(t *testing.T) {
+	data := DynamicArrayDataSlot("0x5")
+	storage := map[common.Hash]common.Hash{
+		common.HexToHash("0x5"): common.BigToHash(big.NewInt(5)),
+		// uint64[] = [1,2,3,4,5]
+		common.HexToHash(data):             common.HexToHash("0x0000000000000004000000000000000300000000000000020000000000000001"),
+		common.HexToHash(SlotAdd(data, 1)): common.HexToHash("0x5"),
+		common.HexToHash("0xa"):            common.HexToHash("0x1"),
+		common.HexToHash("0xb"):            common.HexToHash("0x2"),
+	}
+	client := testEvmc(storageMock(t, storage).url())
+
+	n, words, err := client.Storage().ReadArray("0xcontract", "0x5", 8, evmctypes.Latest)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), n)
+	require.Len(t, words, 2)
+	loc, err := ArrayElementLocation("0x5", 3, 8)
+	require.NoError(t, err)
+	b, err := loc.Extract(words[3/4])
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), new(big.Int).SetBytes(b).Uint64())
+
+	fields, err := client.Storage().ReadStruct("0xcontract", "0xa", 3, evmctypes.Latest)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		common.HexToHash("0x1").Hex(), common.HexToHash("0x2").Hex(), common.Hash{}.Hex(),
+	}, fields)
+}
+
+func Test_storageLayout_mock_GetProxyInfo(t *testing.T) {
+	impl := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	admin := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	beacon := common.HexToAddress("0x00000000000000000000000000000000000000cc")
+
+	storage := map[common.Hash]common.Hash{
+		common.HexToHash(ERC1967ImplementationSlot): common.BytesToHash(impl.Bytes()),
+		common.HexToHash(ERC1967AdminSlot):          common.BytesToHash(admin.Bytes()),
+	}
+	mock := storageMock(t, storage)
+	mock.on("eth_call", func(params json.RawMessage) any { return common.BytesToHash(impl.Bytes()).Hex() })
+	client := testEvmc(mock.url())
+
+	info, err := client.Storage().GetProxyInfo("0xproxy", evmctypes.Latest)
+	require.NoError(t, err)
+	assert.Equal(t, &ProxyInfo{Implementation: impl.Hex(), Admin: admin.Hex()}, info)
+	assert.True(t, info.IsProxy())
+
+	// beacon proxy: beacon의 implementation()을 호출한다.
+	delete(storage, common.HexToHash(ERC1967ImplementationSlot))
+	delete(storage, common.HexToHash(ERC1967AdminSlot))
+	storage[common.HexToHash(ERC1967BeaconSlot)] = common.BytesToHash(beacon.Bytes())
+	info, err = client.Storage().GetProxyInfo("0xproxy", evmctypes.Latest)
+	require.NoError(t, err)
+	assert.Equal(t, &ProxyInfo{Implementation: impl.Hex(), Beacon: beacon.Hex()}, info)
+
+	// EIP-1822 (UUPS)
+	delete(storage, common.HexToHash(ERC1967BeaconSlot))
+	storage[common.HexToHash(EIP1822ProxiableSlot)] = common.BytesToHash(impl.Bytes())
+	info, err = client.Storage().GetProxyInfo("0xproxy", evmctypes.Latest)
+	require.NoError(t, err)
+	assert.Equal(t, &ProxyInfo{Implementation: impl.Hex(), Proxiable: true}, info)
+
+	delete(storage, common.HexToHash(EIP1822ProxiableSlot))
+	info, err = client.Storage().GetProxyInfo("0xproxy", evmctypes.Latest)
+	require.NoError(t, err)
+	assert.False(t, info.IsProxy())
+}
@@ -0,0 +1,369 @@
+package evmc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bbaktaeho/evmc/evmcsoltypes"
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/shopspring/decimal"
+)
+
+// Event topics (keccak256 of the event signature) of the standard token events.
+const (
+	// TransferTopic is Transfer(address,address,uint256), shared by ERC-20 and ERC-721.
+	TransferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	// ApprovalTopic is Approval(address,address,uint256), shared by ERC-20 and ERC-721.
+	ApprovalTopic = "0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"
+	// ApprovalForAllTopic is ApprovalForAll(address,address,bool), shared by ERC-721 and ERC-1155.
+	ApprovalForAllTopic = "0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31"
+	// TransferSingleTopic is TransferSingle(address,address,address,uint256,uint256).
+	TransferSingleTopic = "0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62"
+	// TransferBatchTopic is TransferBatch(address,address,address,uint256[],uint256[]).
+	TransferBatchTopic = "0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb"
+	// URITopic is URI(string,uint256).
+	URITopic = "0x6bb7ff708619ba0610cba295a58592e0451dee2622938c8755667688daf3529b"
+)
+
+// TokenEvent is implemented by every typed event returned by [DecodeTokenEvent].
+type TokenEvent interface {
+	EventName() string
+}
+
+// ERC20TransferEvent is Transfer(address indexed from, address indexed to, uint256 value).
+type ERC20TransferEvent struct {
+	Token string          `json:"token"`
+	From  string          `json:"from"`
+	To    string          `json:"to"`
+	Value decimal.Decimal `json:"value"`
+}
+
+// ERC721TransferEvent is Transfer(address indexed from, address indexed to, uint256 indexed tokenId).
+type ERC721TransferEvent struct {
+	Token   string          `json:"token"`
+	From    string          `json:"from"`
+	To      string          `json:"to"`
+	TokenID decimal.Decimal `json:"tokenId"`
+}
+
+// ERC20ApprovalEvent is Approval(address indexed owner, address indexed spender, uint256 value).
+type ERC20ApprovalEvent struct {
+	Token   string          `json:"token"`
+	Owner   string          `json:"owner"`
+	Spender string          `json:"spender"`
+	Value   decimal.Decimal `json:"value"`
+}
+
+// ERC721ApprovalEvent is Approval(address indexed owner, address indexed approved, uint256 indexed tokenId).
+type ERC721ApprovalEvent struct {
+	Token    string          `json:"token"`
+	Owner    string          `json:"owner"`
+	Approved string          `json:"approved"`
+	TokenID  decimal.Decimal `json:"tokenId"`
+}
+
+// ApprovalForAllEvent is ApprovalForAll(address indexed owner, address indexed operator, bool approved).
+type ApprovalForAllEvent struct {
+	Token    string `json:"token"`
+	Owner    string `json:"owner"`
+	Operator string `json:"operator"`
+	Approved bool   `json:"approved"`
+}
+
+// TransferSingleEvent is TransferSingle(address indexed operator, address indexed from,
+// address indexed to, uint256 id, uint256 value).
+type TransferSingleEvent struct {
+	Token    string          `json:"token"`
+	Operator string          `json:"operator"`
+	From     string          `json:"from"`
+	To       string          `json:"to"`
+	ID       decimal.Decimal `json:"id"`
+	Value    decimal.Decimal `json:"value"`
+}
+
+// TransferBatchEvent is TransferBatch(address indexed operator, address indexed from,
+// address indexed to, uint256[] ids, uint256[] values).
+type TransferBatchEvent struct {
+	Token    string            `json:"token"`
+	Operator string            `json:"operator"`
+	From     string            `json:"from"`
+	To       string            `json:"to"`
+	IDs      []decimal.Decimal `json:"ids"`
+	Values   []decimal.Decimal `json:"values"`
+}
+
+// URIEvent is URI(string value, uint256 indexed id).
+type URIEvent struct {
+	Token string          `json:"token"`
+	Value string          `json:"value"`
+	ID    decimal.Decimal `json:"id"`
+}
+
+func (*ERC20TransferEvent) EventName() string  { return "Transfer" }
+func (*ERC721TransferEvent) EventName() string { return "Transfer" }
+func (*ERC20ApprovalEvent) EventName() string  { return "Approval" }
+func (*ERC721ApprovalEvent) EventName() string { return "Approval" }
+func (*ApprovalForAllEvent) EventName() string { return "ApprovalForAll" }
+func (*TransferSingleEvent) EventName() string { return "TransferSingle" }
+func (*TransferBatchEvent) EventName() string  { return "TransferBatch" }
+func (*URIEvent) EventName() string            { return "URI" }
+
+// DecodeTokenEvent decodes a standard token event log. ERC-20 and ERC-721
+// Transfer/Approval share a topic and are told apart by topic count: three for
+// ERC-20 (value in data) and four for ERC-721 (indexed tokenId).
+// Logs of other events return [ErrUnexpectedEvent].
+func DecodeTokenEvent(log *evmctypes.Log) (TokenEvent, error) {
+	if len(log.Topics) == 0 {
+		return nil, fmt.Errorf("DecodeTokenEvent: %w", ErrUnexpectedEvent)
+	}
+	switch strings.ToLower(log.Topics[0]) {
+	case TransferTopic:
+		if len(log.Topics) == 4 {
+			return DecodeERC721Transfer(log)
+		}
+		return DecodeERC20Transfer(log)
+	case ApprovalTopic:
+		if len(log.Topics) == 4 {
+			return DecodeERC721Approval(log)
+		}
+		return DecodeERC20Approval(log)
+	case ApprovalForAllTopic:
+		return DecodeApprovalForAll(log)
+	case TransferSingleTopic:
+		return DecodeTransferSingle(log)
+	case TransferBatchTopic:
+		return DecodeTransferBatch(log)
+	case URITopic:
+		return DecodeURI(log)
+	}
+	return nil, fmt.Errorf("DecodeTokenEvent: %w", ErrUnexpectedEvent)
+}
+
+// DecodeTokenEventFromCallLog decodes a log emitted inside a call frame of
+// callTracer with withLog enabled. See [DecodeTokenEvent].
+func DecodeTokenEventFromCallLog(log *evmctypes.CallLog) (TokenEvent, error) {
+	return DecodeTokenEvent(&evmctypes.Log{Address: log.Address, Topics: log.Topics, Data: log.Data})
+}
+
+func DecodeERC20Transfer(log *evmctypes.Log) (*ERC20TransferEvent, error) {
+	if !isEvent(log, TransferTopic, 3) {
+		return nil, fmt.Errorf("DecodeERC20Transfer: %w", ErrUnexpectedEvent)
+	}
+	words, err := decodeUintWords(log.Data, 1)
+	if err != nil {
+		return nil, fmt.Errorf("DecodeERC20Transfer: %w", err)
+	}
+	return &ERC20TransferEvent{
+		Token: log.Address,
+		From:  topicToAddress(log.Topics[1]),
+		To:    topicToAddress(log.Topics[2]),
+		Value: words[0],
+	}, nil
+}
+
+func DecodeERC721Transfer(log *evmctypes.Log) (*ERC721TransferEvent, error) {
+	if !isEvent(log, TransferTopic, 4) {
+		return nil, fmt.Errorf("DecodeERC721Transfer: %w", ErrUnexpectedEvent)
+	}
+	tokenID, err := topicToDecimal(log.Topics[3])
+	if err != nil {
+		return nil, fmt.Errorf("DecodeERC721Transfer: %w", err)
+	}
+	return &ERC721TransferEvent{
+		Token:   log.Address,
+		From:    topicToAddress(log.Topics[1]),
+		To:      topicToAddress(log.Topics[2]),
+		TokenID: tokenID,
+	}, nil
+}
+
+func DecodeERC20Approval(log *evmctypes.Log) (*ERC20ApprovalEvent, error) {
+	if !isEvent(log, ApprovalTopic, 3) {
+		return nil, fmt.Errorf("DecodeERC20Approval: %w", ErrUnexpectedEvent)
+	}
+	words, err := decodeUintWords(log.Data, 1)
+	if err != nil {
+		return nil, fmt.Errorf("DecodeERC20Approval: %w", err)
+	}
+	return &ERC20ApprovalEvent{
+		Token:   log.Address,
+		Owner:   topicToAddress(log.Topics[1]),
+		Spender: topicToAddress(log.Topics[2]),
+		Value:   words[0],
+	}, nil
+}
+
+func DecodeERC721Approval(log *evmctypes.Log) (*ERC721ApprovalEvent, error) {
+	if !isEvent(log, ApprovalTopic, 4) {
+		return nil, fmt.Errorf("DecodeERC721Approval: %w", ErrUnexpectedEvent)
+	}
+	tokenID, err := topicToDecimal(log.Topics[3])
+	if err != nil {
+		return nil, fmt.Errorf("DecodeERC721Approval: %w", err)
+	}
+	return &ERC721ApprovalEvent{
+		Token:    log.Address,
+		Owner:    topicToAddress(log.Topics[1]),
+		Approved: topicToAddress(log.Topics[2]),
+		TokenID:  tokenID,
+	}, nil
+}
+
+func DecodeApprovalForAll(log *evmctypes.Log) (*ApprovalForAllEvent, error) {
+	if !isEvent(log, ApprovalForAllTopic, 3) {
+		return nil, fmt.Errorf("DecodeApprovalForAll: %w", ErrUnexpectedEvent)
+	}
+	approved, err := evmcsoltypes.ParseBool(log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("DecodeApprovalForAll: %w", err)
+	}
+	return &ApprovalForAllEvent{
+		Token:    log.Address,
+		Owner:    topicToAddress(log.Topics[1]),
+		Operator: topicToAddress(log.Topics[2]),
+		Approved: approved,
+	}, nil
+}
+
+func DecodeTransferSingle(log *evmctypes.Log) (*TransferSingleEvent, error) {
+	if !isEvent(log, TransferSingleTopic, 4) {
+		return nil, fmt.Errorf("DecodeTransferSingle: %w", ErrUnexpectedEvent)
+	}
+	words, err := decodeUintWords(log.Data, 2)
+	if err != nil {
+		return nil, fmt.Errorf("DecodeTransferSingle: %w", err)
+	}
+	return &TransferSingleEvent{
+		Token:    log.Address,
+		Operator: topicToAddress(log.Topics[1]),
+		From:     topicToAddress(log.Topics[2]),
+		To:       topicToAddress(log.Topics[3]),
+		ID:       words[0],
+		Value:    words[1],
+	}, nil
+}
+
+func DecodeTransferBatch(log *evmctypes.Log) (*TransferBatchEvent, error) {
+	if !isEvent(log, TransferBatchTopic, 4) {
+		return nil, fmt.Errorf("DecodeTransferBatch: %w", ErrUnexpectedEvent)
+	}
+	ids, values, err := evmcsoltypes.ParseSolUintArrayPair(log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("DecodeTransferBatch: %w", err)
+	}
+	if len(ids) != len(values) {
+		return nil, fmt.Errorf("DecodeTransferBatch: ids and values length mismatch (%d != %d)", len(ids), len(values))
+	}
+	return &TransferBatchEvent{
+		Token:    log.Address,
+		Operator: topicToAddress(log.Topics[1]),
+		From:     topicToAddress(log.Topics[2]),
+		To:       topicToAddress(log.Topics[3]),
+		IDs:      ids,
+		Values:   values,
+	}, nil
+}
+
+func DecodeURI(log *evmctypes.Log) (*URIEvent, error) {
+	if !isEvent(log, URITopic, 2) {
+		return nil, fmt.Errorf("DecodeURI: %w", ErrUnexpectedEvent)
+	}
+	value, err := evmcsoltypes.ParseSolStringToString(log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("DecodeURI: %w", err)
+	}
+	id, err := topicToDecimal(log.Topics[1])
+	if err != nil {
+		return nil, fmt.Errorf("DecodeURI: %w", err)
+	}
+	return &URIEvent{
+		Token: log.Address,
+		Value: value,
+		ID:    id,
+	}, nil
+}
+
+// TokenMovement is a single balance change of one token between two accounts.
+// Mints have From set to the zero address and burns have To set to it.
+type TokenMovement struct {
+	Standard TokenStandard   `json:"standard"`
+	Token    string          `json:"token"`
+	Operator string          `json:"operator,omitempty"` // ERC-1155 only
+	From     string          `json:"from"`
+	To       string          `json:"to"`
+	TokenID  decimal.Decimal `json:"tokenId"` // zero for ERC-20
+	Amount   decimal.Decimal `json:"amount"`  // always 1 for ERC-721
+
+	BlockNumber     uint64 `json:"blockNumber"`
+	TransactionHash string `json:"transactionHash"`
+	LogIndex        uint64 `json:"logIndex"`
+}
+
+// ExtractTokenMovements returns every ERC-20, ERC-721 and ERC-1155 transfer in
+// the given receipts, in log order. It accepts a single [evmctypes.Receipt] as
+// well as the result of GetBlockReceipts. Removed logs and logs that carry a
+// transfer topic but do not follow the standard layout are skipped.
+func ExtractTokenMovements(receipts ...*evmctypes.Receipt) []*TokenMovement {
+	var movements []*TokenMovement
+	for _, receipt := range receipts {
+		if receipt == nil {
+			continue
+		}
+		for _, log := range receipt.Logs {
+			if log == nil || log.Removed {
+				continue
+			}
+			movements = append(movements, TokenMovementsFromLog(log)...)
+		}
+	}
+	return movements
+}
+
+// TokenMovementsFromLog returns the movements described by a single log.
+// A TransferBatch log yields one movement per id.
+func TokenMovementsFromLog(log *evmctypes.Log) []*TokenMovement {
+	event, err := DecodeTokenEvent(log)
+	if err != nil {
+		return nil
+	}
+	newMovement := func(standard TokenStandard) *TokenMovement {
+		return &TokenMovement{
+			Standard:        standard,
+			Token:           log.Address,
+			BlockNumber:     log.BlockNumber,
+			TransactionHash: log.TransactionHash,
+			LogIndex:        log.LogIndex,
+		}
+	}
+	switch ev := event.(type) {
+	case *ERC20TransferEvent:
+		m := newMovement(TokenStandardERC20)
+		m.From, m.To, m.Amount = ev.From, ev.To, ev.Value
+		return []*TokenMovement{m}
+	case *ERC721TransferEvent:
+		m := newMovement(TokenStandardERC721)
+		m.From, m.To, m.TokenID, m.Amount = ev.From, ev.To, ev.TokenID, decimal.NewFromInt(1)
+		return []*TokenMovement{m}
+	case *TransferSingleEvent:
+		m := newMovement(TokenStandardERC1155)
+		m.Operator, m.From, m.To, m.TokenID, m.Amount = ev.Operator, ev.From, ev.To, ev.ID, ev.Value
+		return []*TokenMovement{m}
+	case *TransferBatchEvent:
+		movements := make([]*TokenMovement, len(ev.IDs))
+		for i := range ev.IDs {
+			m := newMovement(TokenStandardERC1155)
+			m.Operator, m.From, m.To, m.TokenID, m.Amount = ev.Operator, ev.From, ev.To, ev.IDs[i], ev.Values[i]
+			movements[i] = m
+		}
+		return movements
+	}
+	return nil
+}
+
+func isEvent(log *evmctypes.Log, topic string, topics int) bool {
+	return len(log.Topics) == topics && strings.EqualFold(log.Topics[0], topic)
+}
+
+func topicToDecimal(topic string) (decimal.Decimal, error) {
+	return evmcsoltypes.ParseSolUintToDecimal(topic)
+}
@@ -0,0 +1,161 @@
+package evmc
+
+import (
+	"testing"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	topicAlice = "0x000000000000000000000000000000000000000000000000000000000000a11c"
+	topicBob   = "0x0000000000000000000000000000000000000000000000000000000000000b0b"
+	topicZero  = "0x0000000000000000000000000000000000000000000000000000000000000000"
+	addrAlice  = "0x000000000000000000000000000000000000a11c"
+	addrBob    = "0x0000000000000000000000000000000000000B0b"
+	word1      = "0000000000000000000000000000000000000000000000000000000000000001"
+	word2      = "0000000000000000000000000000000000000000000000000000000000000002"
+	word7      = "0000000000000000000000000000000000000000000000000000000000000007"
+)
+
+func Test_DecodeTokenEvent(t *testing.T) {
+	t.Run("erc20 transfer", func(t *testing.T) {
+		ev, err := DecodeTokenEvent(&evmctypes.Log{
+			Address: "0xtoken",
+			Topics:  []string{TransferTopic, topicAlice, topicBob},
+			Data:    "0x" + word7,
+		})
+		require.NoError(t, err)
+		transfer, ok := ev.(*ERC20TransferEvent)
+		require.True(t, ok)
+		assert.Equal(t, addrAlice, transfer.From)
+		assert.Equal(t, addrBob, transfer.To)
+		assert.Equal(t, "7", transfer.Value.String())
+	})
+
+	t.Run("erc721 transfer", func(t *testing.T) {
+		ev, err := DecodeTokenEvent(&evmctypes.Log{
+			Address: "0xnft",
+			Topics:  []string{TransferTopic, topicAlice, topicBob, "0x" + word7},
+			Data:    "0x",
+		})
+		require.NoError(t, err)
+		transfer, ok := ev.(*ERC721TransferEvent)
+		require.True(t, ok)
+		assert.Equal(t, "7", transfer.TokenID.String())
+	})
+
+	t.Run("erc721 approval", func(t *testing.T) {
+		ev, err := DecodeTokenEvent(&evmctypes.Log{
+			Topics: []string{ApprovalTopic, topicAlice, topicBob, "0x" + word2},
+			Data:   "0x",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Approval", ev.EventName())
+		assert.Equal(t, addrBob, ev.(*ERC721ApprovalEvent).Approved)
+	})
+
+	t.Run("approval for all", func(t *testing.T) {
+		ev, err := DecodeTokenEvent(&evmctypes.Log{
+			Topics: []string{ApprovalForAllTopic, topicAlice, topicBob},
+			Data:   "0x" + word1,
+		})
+		require.NoError(t, err)
+		assert.True(t, ev.(*ApprovalForAllEvent).Approved)
+	})
+
+	t.Run("uri", func(t *testing.T) {
+		ev, err := DecodeTokenEventFromCallLog(&evmctypes.CallLog{
+			Topics: []string{URITopic, "0x" + word2},
+			Data: "0x0000000000000000000000000000000000000000000000000000000000000020" +
+				"0000000000000000000000000000000000000000000000000000000000000004" +
+				"6970667300000000000000000000000000000000000000000000000000000000",
+		})
+		require.NoError(t, err)
+		uri := ev.(*URIEvent)
+		assert.Equal(t, "ipfs", uri.Value)
+		assert.Equal(t, "2", uri.ID.String())
+	})
+
+	t.Run("unknown event", func(t *testing.T) {
+		_, err := DecodeTokenEvent(&evmctypes.Log{Topics: []string{ERC4626DepositTopic}})
+		assert.ErrorIs(t, err, ErrUnexpectedEvent)
+	})
+
+	t.Run("malformed data", func(t *testing.T) {
+		_, err := DecodeTokenEvent(&evmctypes.Log{
+			Topics: []string{TransferTopic, topicAlice, topicBob},
+			Data:   "0x",
+		})
+		assert.Error(t, err)
+	})
+}
+
+func Test_ExtractTokenMovements(t *testing.T) {
+	receipts := []*evmctypes.Receipt{
+		{
+			TransactionHash: "0xtx1",
+			Logs: []*evmctypes.Log{
+				{
+					Address: "0xtoken", TransactionHash: "0xtx1", LogIndex: 0,
+					Topics: []string{TransferTopic, topicAlice, topicBob},
+					Data:   "0x" + word7,
+				},
+				{
+					Address: "0xtoken", TransactionHash: "0xtx1", LogIndex: 1,
+					Topics: []string{ApprovalTopic, topicAlice, topicBob},
+					Data:   "0x" + word7,
+				},
+			},
+		},
+		{
+			TransactionHash: "0xtx2",
+			Logs: []*evmctypes.Log{
+				{
+					Address: "0xnft", TransactionHash: "0xtx2", LogIndex: 2,
+					Topics: []string{TransferTopic, topicZero, topicBob, "0x" + word1},
+				},
+				{
+					Address: "0xmulti", TransactionHash: "0xtx2", LogIndex: 3,
+					Topics: []string{TransferSingleTopic, topicAlice, topicAlice, topicBob},
+					Data:   "0x" + word2 + word7,
+				},
+				{
+					Address: "0xmulti", TransactionHash: "0xtx2", LogIndex: 4,
+					Topics: []string{TransferBatchTopic, topicAlice, topicAlice, topicBob},
+					Data: "0x" +
+						"0000000000000000000000000000000000000000000000000000000000000040" +
+						"00000000000000000000000000000000000000000000000000000000000000a0" +
+						word2 + word1 + word2 +
+						word2 + word7 + word1,
+				},
+				{
+					Address: "0xnft", Removed: true,
+					Topics: []string{TransferTopic, topicZero, topicBob, "0x" + word2},
+				},
+			},
+		},
+	}
+
+	movements := ExtractTokenMovements(receipts...)
+	require.Len(t, movements, 5)
+
+	assert.Equal(t, TokenStandardERC20, movements[0].Standard)
+	assert.Equal(t, "7", movements[0].Amount.String())
+	assert.Equal(t, "0xtx1", movements[0].TransactionHash)
+
+	assert.Equal(t, TokenStandardERC721, movements[1].Standard)
+	assert.Equal(t, "0x0000000000000000000000000000000000000000", movements[1].From)
+	assert.Equal(t, "1", movements[1].TokenID.String())
+	assert.Equal(t, "1", movements[1].Amount.String())
+
+	assert.Equal(t, TokenStandardERC1155, movements[2].Standard)
+	assert.Equal(t, addrAlice, movements[2].Operator)
+	assert.Equal(t, "2", movements[2].TokenID.String())
+
+	assert.Equal(t, "1", movements[3].TokenID.String())
+	assert.Equal(t, "7", movements[3].Amount.String())
+	assert.Equal(t, "2", movements[4].TokenID.String())
+	assert.Equal(t, uint64(4), movements[4].LogIndex)
+}
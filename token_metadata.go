@@ -0,0 +1,306 @@
+package evmc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	defaultIPFSGateway     = "https://ipfs.io/ipfs/"
+	defaultArweaveGateway  = "https://arweave.net/"
+	defaultMetadataTimeout = 30 * time.Second
+	defaultMetadataMaxSize = 5 * 1024 * 1024
+
+	erc1155IDPlaceholder = "{id}"
+)
+
+// TokenAttribute is an entry of the OpenSea-style "attributes" array.
+type TokenAttribute struct {
+	TraitType   string `json:"trait_type,omitempty"`
+	Value       any    `json:"value"`
+	DisplayType string `json:"display_type,omitempty"`
+	MaxValue    any    `json:"max_value,omitempty"`
+}
+
+// TokenMetadata is the metadata JSON referenced by ERC-721 tokenURI and
+// ERC-1155 uri. Fields outside the common schema are kept in Raw.
+type TokenMetadata struct {
+	Name            string            `json:"name,omitempty"`
+	Description     string            `json:"description,omitempty"`
+	Image           string            `json:"image,omitempty"`
+	ImageData       string            `json:"image_data,omitempty"`
+	ExternalURL     string            `json:"external_url,omitempty"`
+	AnimationURL    string            `json:"animation_url,omitempty"`
+	BackgroundColor string            `json:"background_color,omitempty"`
+	Attributes      []*TokenAttribute `json:"attributes,omitempty"`
+	Properties      map[string]any    `json:"properties,omitempty"` // ERC-1155
+
+	// URI is the resolved location the metadata was loaded from.
+	URI string          `json:"-"`
+	Raw json.RawMessage `json:"-"`
+}
+
+// MetadataFetcher loads the document at an http(s) URL. Implement it to add
+// caching, rate limiting or a local stub in tests.
+type MetadataFetcher interface {
+	Fetch(ctx context.Context, url string) ([]byte, error)
+}
+
+type httpMetadataFetcher struct {
+	client  *http.Client
+	maxSize int64
+}
+
+// NewHTTPMetadataFetcher returns the default [MetadataFetcher]. Responses
+// larger than 5 MiB are rejected.
+func NewHTTPMetadataFetcher(client *http.Client) MetadataFetcher {
+	if client == nil {
+		client = &http.Client{Timeout: defaultMetadataTimeout}
+	}
+	return &httpMetadataFetcher{client: client, maxSize: defaultMetadataMaxSize}
+}
+
+func (h *httpMetadataFetcher) Fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, h.maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > h.maxSize {
+		return nil, fmt.Errorf("metadata exceeds %d bytes", h.maxSize)
+	}
+	return body, nil
+}
+
+type metadataOptions struct {
+	ipfsGateway    string
+	arweaveGateway string
+	fetcher        MetadataFetcher
+}
+
+type metadataOptionFunc func(*metadataOptions)
+
+func (f metadataOptionFunc) apply(o *metadataOptions) {
+	f(o)
+}
+
+// MetadataOptions configures a [MetadataResolver].
+type MetadataOptions interface {
+	apply(*metadataOptions)
+}
+
+// WithIPFSGateway sets the gateway used for ipfs:// URIs.
+// Default: https://ipfs.io/ipfs/.
+func WithIPFSGateway(gateway string) MetadataOptions {
+	return metadataOptionFunc(func(o *metadataOptions) {
+		o.ipfsGateway = gateway
+	})
+}
+
+// WithArweaveGateway sets the gateway used for ar:// URIs.
+// Default: https://arweave.net/.
+func WithArweaveGateway(gateway string) MetadataOptions {
+	return metadataOptionFunc(func(o *metadataOptions) {
+		o.arweaveGateway = gateway
+	})
+}
+
+// WithMetadataFetcher sets the fetcher used for http(s) URIs, including the
+// gateway URLs of ipfs:// and ar://. Default: [NewHTTPMetadataFetcher](nil).
+func WithMetadataFetcher(fetcher MetadataFetcher) MetadataOptions {
+	return metadataOptionFunc(func(o *metadataOptions) {
+		o.fetcher = fetcher
+	})
+}
+
+// MetadataResolver turns ERC-721 tokenURI and ERC-1155 uri values into
+// [TokenMetadata].
+type MetadataResolver struct {
+	ipfsGateway    string
+	arweaveGateway string
+	fetcher        MetadataFetcher
+}
+
+func NewMetadataResolver(opts ...MetadataOptions) *MetadataResolver {
+	o := &metadataOptions{
+		ipfsGateway:    defaultIPFSGateway,
+		arweaveGateway: defaultArweaveGateway,
+	}
+	for _, opt := range opts {
+		opt.apply(o)
+	}
+	if o.fetcher == nil {
+		o.fetcher = NewHTTPMetadataFetcher(nil)
+	}
+	return &MetadataResolver{
+		ipfsGateway:    withTrailingSlash(o.ipfsGateway),
+		arweaveGateway: withTrailingSlash(o.arweaveGateway),
+		fetcher:        o.fetcher,
+	}
+}
+
+// SubstituteERC1155ID replaces the {id} placeholder of an ERC-1155 URI with the
+// lowercase, 64 character hex form of id as required by the standard.
+func SubstituteERC1155ID(uri string, id decimal.Decimal) string {
+	if !strings.Contains(uri, erc1155IDPlaceholder) {
+		return uri
+	}
+	return strings.ReplaceAll(uri, erc1155IDPlaceholder, fmt.Sprintf("%064x", id.BigInt()))
+}
+
+// GatewayURL rewrites ipfs:// and ar:// URIs to the configured gateways.
+// Other URIs are returned unchanged, so it is also useful for the Image field
+// of resolved metadata.
+func (m *MetadataResolver) GatewayURL(uri string) string {
+	switch {
+	case strings.HasPrefix(uri, "ipfs://"):
+		path := strings.TrimPrefix(uri, "ipfs://")
+		path = strings.TrimPrefix(path, "ipfs/")
+		return m.ipfsGateway + path
+	case strings.HasPrefix(uri, "ar://"):
+		return m.arweaveGateway + strings.TrimPrefix(uri, "ar://")
+	}
+	return uri
+}
+
+// Resolve loads and parses the metadata at uri. Supported URIs are data:
+// (base64 or percent-encoded), ipfs://, ar:// and http(s)://.
+func (m *MetadataResolver) Resolve(ctx context.Context, uri string) (*TokenMetadata, error) {
+	uri = strings.TrimSpace(uri)
+	var (
+		body []byte
+		err  error
+	)
+	if strings.HasPrefix(uri, "data:") {
+		body, err = decodeDataURI(uri)
+	} else {
+		target := m.GatewayURL(uri)
+		if !strings.HasPrefix(target, "http://") && !strings.HasPrefix(target, "https://") {
+			return nil, fmt.Errorf("Resolve: %w: %q", ErrUnsupportedURI, uri)
+		}
+		body, err = m.fetcher.Fetch(ctx, target)
+		uri = target
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Resolve: %w", err)
+	}
+	metadata := new(TokenMetadata)
+	if err := json.Unmarshal(body, metadata); err != nil {
+		return nil, fmt.Errorf("Resolve: %w", err)
+	}
+	metadata.URI = uri
+	metadata.Raw = body
+	return metadata, nil
+}
+
+// ResolveERC1155 substitutes {id} in uri and resolves it.
+func (m *MetadataResolver) ResolveERC1155(ctx context.Context, uri string, id decimal.Decimal) (*TokenMetadata, error) {
+	return m.Resolve(ctx, SubstituteERC1155ID(uri, id))
+}
+
+// decodeDataURI decodes an RFC 2397 data URI.
+func decodeDataURI(uri string) ([]byte, error) {
+	header, payload, ok := strings.Cut(strings.TrimPrefix(uri, "data:"), ",")
+	if !ok {
+		return nil, fmt.Errorf("%w: malformed data uri", ErrUnsupportedURI)
+	}
+	if strings.HasSuffix(header, ";base64") {
+		b, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			// some contracts emit unpadded base64.
+			return base64.RawStdEncoding.DecodeString(strings.TrimRight(payload, "="))
+		}
+		return b, nil
+	}
+	decoded, err := url.PathUnescape(payload)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(decoded), nil
+}
+
+func withTrailingSlash(s string) string {
+	if strings.HasSuffix(s, "/") {
+		return s
+	}
+	return s + "/"
+}
+
+// --- ERC-721 / ERC-1155 convenience ---
+
+// TokenMetadata reads tokenURI and resolves it with resolver.
+// A nil resolver uses [NewMetadataResolver] defaults.
+func (e *erc721Contract) TokenMetadata(
+	tokenAddress string,
+	tokenID decimal.Decimal,
+	blockAndTag evmctypes.BlockAndTag,
+	resolver *MetadataResolver,
+) (*TokenMetadata, error) {
+	return e.TokenMetadataWithContext(context.Background(), tokenAddress, tokenID, blockAndTag, resolver)
+}
+
+func (e *erc721Contract) TokenMetadataWithContext(
+	ctx context.Context,
+	tokenAddress string,
+	tokenID decimal.Decimal,
+	blockAndTag evmctypes.BlockAndTag,
+	resolver *MetadataResolver,
+) (*TokenMetadata, error) {
+	uri, err := e.tokenURI(ctx, tokenAddress, tokenID, blockAndTag)
+	if err != nil {
+		return nil, err
+	}
+	if resolver == nil {
+		resolver = NewMetadataResolver()
+	}
+	return resolver.Resolve(ctx, uri)
+}
+
+// Metadata reads uri(id), substitutes {id} and resolves it with resolver.
+// A nil resolver uses [NewMetadataResolver] defaults.
+func (e *erc1155Contract) Metadata(
+	tokenAddress string,
+	id decimal.Decimal,
+	blockAndTag evmctypes.BlockAndTag,
+	resolver *MetadataResolver,
+) (*TokenMetadata, error) {
+	return e.MetadataWithContext(context.Background(), tokenAddress, id, blockAndTag, resolver)
+}
+
+func (e *erc1155Contract) MetadataWithContext(
+	ctx context.Context,
+	tokenAddress string,
+	id decimal.Decimal,
+	blockAndTag evmctypes.BlockAndTag,
+	resolver *MetadataResolver,
+) (*TokenMetadata, error) {
+	uri, err := e.uri(ctx, tokenAddress, id, blockAndTag)
+	if err != nil {
+		return nil, err
+	}
+	if resolver == nil {
+		resolver = NewMetadataResolver()
+	}
+	return resolver.ResolveERC1155(ctx, uri, id)
+}
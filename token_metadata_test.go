@@ -0,0 +1,101 @@
+package evmc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubFetcher는 URL별로 고정된 응답을 돌려주는 MetadataFetcher.
+type stubFetcher map[string]string
+
+func (s stubFetcher) Fetch(_ context.Context, url string) ([]byte, error) {
+	body, ok := s[url]
+	if !ok {
+		return nil, errors.New("not found: " + url)
+	}
+	return []byte(body), nil
+}
+
+const testMetadataJSON = `{"name":"Token #1","description":"desc","image":"ipfs://QmImage","attributes":[{"trait_type":"Color","value":"Red"},{"trait_type":"Level","value":5,"display_type":"number"}]}`
+
+func Test_MetadataResolver_Resolve(t *testing.T) {
+	fetcher := stubFetcher{
+		"https://gateway.test/ipfs/QmMeta/1.json": testMetadataJSON,
+		"https://arweave.test/txid":               testMetadataJSON,
+		"https://api.test/token/1":                testMetadataJSON,
+	}
+	resolver := NewMetadataResolver(
+		WithIPFSGateway("https://gateway.test/ipfs"),
+		WithArweaveGateway("https://arweave.test/"),
+		WithMetadataFetcher(fetcher),
+	)
+
+	tests := []struct {
+		name    string
+		uri     string
+		wantURI string
+	}{
+		{name: "ipfs", uri: "ipfs://QmMeta/1.json", wantURI: "https://gateway.test/ipfs/QmMeta/1.json"},
+		{name: "ipfs legacy", uri: "ipfs://ipfs/QmMeta/1.json", wantURI: "https://gateway.test/ipfs/QmMeta/1.json"},
+		{name: "arweave", uri: "ar://txid", wantURI: "https://arweave.test/txid"},
+		{name: "https", uri: "https://api.test/token/1", wantURI: "https://api.test/token/1"},
+		{
+			name:    "data base64",
+			uri:     "data:application/json;base64," + base64.StdEncoding.EncodeToString([]byte(testMetadataJSON)),
+			wantURI: "data:application/json;base64," + base64.StdEncoding.EncodeToString([]byte(testMetadataJSON)),
+		},
+		{
+			name:    "data percent-encoded",
+			uri:     `data:application/json,{"name":"Token%20#1","image":"ipfs://QmImage","attributes":[{"trait_type":"Color","value":"Red"},{"trait_type":"Level","value":5}]}`,
+			wantURI: `data:application/json,{"name":"Token%20#1","image":"ipfs://QmImage","attributes":[{"trait_type":"Color","value":"Red"},{"trait_type":"Level","value":5}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md, err := resolver.Resolve(context.Background(), tt.uri)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantURI, md.URI)
+			assert.Equal(t, "Token #1", md.Name)
+			assert.Equal(t, "https://gateway.test/ipfs/QmImage", resolver.GatewayURL(md.Image))
+			require.Len(t, md.Attributes, 2)
+			assert.Equal(t, "Color", md.Attributes[0].TraitType)
+			assert.Equal(t, float64(5), md.Attributes[1].Value)
+		})
+	}
+
+	_, err := resolver.Resolve(context.Background(), "ftp://nope")
+	assert.ErrorIs(t, err, ErrUnsupportedURI)
+}
+
+func Test_SubstituteERC1155ID(t *testing.T) {
+	got := SubstituteERC1155ID("https://token-cdn-domain/{id}.json", decimal.NewFromInt(314592))
+	assert.Equal(t, "https://token-cdn-domain/000000000000000000000000000000000000000000000000000000000004cce0.json", got)
+	assert.Equal(t, "ipfs://static", SubstituteERC1155ID("ipfs://static", decimal.NewFromInt(1)))
+}
+
+func Test_erc1155_mock_Metadata(t *testing.T) {
+	mock := newMockRPCServer(t)
+	mock.on("eth_call", func(params json.RawMessage) any {
+		// uri(uint256) -> "https://api.test/{id}.json"
+		return "0x0000000000000000000000000000000000000000000000000000000000000020" +
+			"000000000000000000000000000000000000000000000000000000000000001a" +
+			"68747470733a2f2f6170692e746573742f7b69647d2e6a736f6e000000000000"
+	})
+	fetcher := stubFetcher{
+		"https://api.test/0000000000000000000000000000000000000000000000000000000000000001.json": testMetadataJSON,
+	}
+
+	client := testEvmc(mock.url())
+	md, err := client.ERC1155().Metadata("0xmulti", decimal.NewFromInt(1), evmctypes.Latest,
+		NewMetadataResolver(WithMetadataFetcher(fetcher)))
+	require.NoError(t, err)
+	assert.Equal(t, "desc", md.Description)
+}
@@ -0,0 +1,290 @@
+package evmc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/bbaktaeho/evmc/evmcsoltypes"
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/bbaktaeho/evmc/evmcutils"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type TokenStandard string
+
+const (
+	TokenStandardERC20   TokenStandard = "ERC20"
+	TokenStandardERC721  TokenStandard = "ERC721"
+	TokenStandardERC1155 TokenStandard = "ERC1155"
+	TokenStandardERC4626 TokenStandard = "ERC4626"
+)
+
+func (t TokenStandard) String() string {
+	return string(t)
+}
+
+type TokenExtension string
+
+const (
+	// TokenExtensionMetadata is name/symbol/decimals for ERC-20 and
+	// name/symbol/tokenURI for ERC-721.
+	TokenExtensionMetadata TokenExtension = "Metadata"
+	// TokenExtensionEnumerable is the ERC-721 enumeration extension.
+	TokenExtensionEnumerable TokenExtension = "Enumerable"
+	// TokenExtensionRoyalty is the ERC-2981 royalty extension.
+	TokenExtensionRoyalty TokenExtension = "Royalty"
+	// TokenExtensionMetadataURI is the ERC-1155 uri(uint256) extension.
+	TokenExtensionMetadataURI TokenExtension = "MetadataURI"
+)
+
+func (t TokenExtension) String() string {
+	return string(t)
+}
+
+// TokenClassification is the result of [erc165Contract.DetectTokenStandard].
+type TokenClassification struct {
+	Address        string           `json:"address"`
+	IsContract     bool             `json:"isContract"`
+	SupportsERC165 bool             `json:"supportsERC165"`
+	Standards      []TokenStandard  `json:"standards"`
+	Extensions     []TokenExtension `json:"extensions"`
+}
+
+// Is reports whether the contract implements the given standard.
+func (t *TokenClassification) Is(standard TokenStandard) bool {
+	return slices.Contains(t.Standards, standard)
+}
+
+// Has reports whether the contract implements the given extension.
+func (t *TokenClassification) Has(extension TokenExtension) bool {
+	return slices.Contains(t.Extensions, extension)
+}
+
+// function selectors looked up in runtime bytecode.
+const (
+	selTransfer              = "0xa9059cbb"
+	selTransferFrom          = "0x23b872dd"
+	selApprove               = "0x095ea7b3"
+	selAllowance             = "0xdd62ed3e"
+	selBalanceOf             = "0x70a08231"
+	selTotalSupply           = "0x18160ddd"
+	selDecimals              = "0x313ce567"
+	selName                  = "0x06fdde03"
+	selSymbol                = "0x95d89b41"
+	selOwnerOf               = "0x6352211e"
+	selSafeTransferFrom      = "0x42842e0e"
+	selSafeTransferFromData  = "0xb88d4fde"
+	selSetApprovalForAll     = "0xa22cb465"
+	selTokenURI              = "0xc87b56dd"
+	selTokenByIndex          = "0x4f6ccce7"
+	selTokenOfOwnerByIndex   = "0x2f745c59"
+	selBalanceOfBatch        = "0x4e1273f4"
+	selSafeBatchTransferFrom = "0x2eb2c2d6"
+	selURI                   = "0x0e89341c"
+	selRoyaltyInfo           = "0x2a55205a"
+	selAsset                 = "0x38d52e0f"
+	selTotalAssets           = "0x01e1d114"
+	selConvertToShares       = "0xc6e6f592"
+	selConvertToAssets       = "0x07a2d13a"
+)
+
+// probe indices of the single batch sent by detectTokenStandard.
+const (
+	probeERC165 = iota
+	probeInvalid
+	probeERC721
+	probeERC721Metadata
+	probeERC721Enumerable
+	probeERC1155
+	probeERC1155MetadataURI
+	probeERC2981
+	probeDecimals
+	probeTotalSupply
+	probeName
+	probeSymbol
+	probeAsset
+	probeTotalAssets
+)
+
+// DetectTokenStandard classifies the contract at address as ERC-20, ERC-721,
+// ERC-1155 and/or ERC-4626 together with the extensions it implements.
+//
+// Three sources of evidence are combined:
+//   - ERC-165 supportsInterface probes, trusted only when the contract answers
+//     true for 0x01ffc9a7 and false for 0xffffffff;
+//   - function selectors found in the runtime bytecode returned by eth_getCode;
+//   - heuristic view calls (decimals, totalSupply, asset, totalAssets), which
+//     also cover proxies whose bytecode holds no selectors of the implementation.
+//
+// An address without code is returned with IsContract false and no standards.
+func (e *erc165Contract) DetectTokenStandard(
+	address string,
+	blockAndTag evmctypes.BlockAndTag,
+) (*TokenClassification, error) {
+	return e.detectTokenStandard(context.Background(), address, blockAndTag)
+}
+
+func (e *erc165Contract) DetectTokenStandardWithContext(
+	ctx context.Context,
+	address string,
+	blockAndTag evmctypes.BlockAndTag,
+) (*TokenClassification, error) {
+	return e.detectTokenStandard(ctx, address, blockAndTag)
+}
+
+func (e *erc165Contract) detectTokenStandard(
+	ctx context.Context,
+	address string,
+	blockAndTag evmctypes.BlockAndTag,
+) (*TokenClassification, error) {
+	tc := &TokenClassification{Address: address}
+
+	code := new(string)
+	if err := e.c.call(ctx, code, EthGetCode, address, evmctypes.ToBlockAndTag(blockAndTag)); err != nil {
+		return nil, fmt.Errorf("DetectTokenStandard: %w", err)
+	}
+	if *code == "" || *code == "0x" {
+		return tc, nil
+	}
+	tc.IsContract = true
+
+	selectors, err := evmcutils.ExtractSelectors(*code)
+	if err != nil {
+		return nil, fmt.Errorf("DetectTokenStandard: %w", err)
+	}
+	has := func(sels ...string) bool {
+		for _, sel := range sels {
+			if !slices.Contains(selectors, sel) {
+				return false
+			}
+		}
+		return true
+	}
+
+	results, err := e.probe(ctx, address, blockAndTag, []string{
+		probeERC165:             GenerateERC165SupportsInterface(InterfaceIDERC165),
+		probeInvalid:            GenerateERC165SupportsInterface(interfaceIDInvalid),
+		probeERC721:             GenerateERC165SupportsInterface(InterfaceIDERC721),
+		probeERC721Metadata:     GenerateERC165SupportsInterface(InterfaceIDERC721Metadata),
+		probeERC721Enumerable:   GenerateERC165SupportsInterface(InterfaceIDERC721Enumerable),
+		probeERC1155:            GenerateERC165SupportsInterface(InterfaceIDERC1155),
+		probeERC1155MetadataURI: GenerateERC165SupportsInterface(InterfaceIDERC1155MetadataURI),
+		probeERC2981:            GenerateERC165SupportsInterface(InterfaceIDERC2981),
+		probeDecimals:           selDecimals,
+		probeTotalSupply:        selTotalSupply,
+		probeName:               selName,
+		probeSymbol:             selSymbol,
+		probeAsset:              selAsset,
+		probeTotalAssets:        selTotalAssets,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("DetectTokenStandard: %w", err)
+	}
+
+	isTrue := func(i int) bool {
+		ok, err := evmcsoltypes.ParseBool(results[i])
+		return err == nil && ok
+	}
+	isUint := func(i int) bool {
+		_, err := evmcsoltypes.ParseSolUintToDecimal(results[i])
+		return err == nil
+	}
+	isString := func(i int) bool {
+		if _, err := evmcsoltypes.ParseSolStringToString(results[i]); err == nil {
+			return true
+		}
+		// some early tokens return bytes32 instead of string.
+		return len(results[i]) == 66
+	}
+
+	tc.SupportsERC165 = isTrue(probeERC165) && !isTrue(probeInvalid) && results[probeInvalid] != ""
+	supports := func(i int) bool {
+		return tc.SupportsERC165 && isTrue(i)
+	}
+
+	is721 := supports(probeERC721) ||
+		has(selOwnerOf, selSetApprovalForAll) && (has(selSafeTransferFrom) || has(selSafeTransferFromData))
+	is1155 := supports(probeERC1155) || has(selSafeBatchTransferFrom, selBalanceOfBatch)
+	if is721 {
+		tc.Standards = append(tc.Standards, TokenStandardERC721)
+		if supports(probeERC721Metadata) || has(selName, selSymbol, selTokenURI) {
+			tc.Extensions = append(tc.Extensions, TokenExtensionMetadata)
+		}
+		if supports(probeERC721Enumerable) || has(selTotalSupply, selTokenByIndex, selTokenOfOwnerByIndex) {
+			tc.Extensions = append(tc.Extensions, TokenExtensionEnumerable)
+		}
+	}
+	if is1155 {
+		tc.Standards = append(tc.Standards, TokenStandardERC1155)
+		if supports(probeERC1155MetadataURI) || has(selURI) {
+			tc.Extensions = append(tc.Extensions, TokenExtensionMetadataURI)
+		}
+	}
+	if !is721 && !is1155 {
+		is20 := has(selTransfer, selTransferFrom, selApprove, selAllowance, selBalanceOf, selTotalSupply) ||
+			isUint(probeDecimals) && isUint(probeTotalSupply)
+		if is20 {
+			tc.Standards = append(tc.Standards, TokenStandardERC20)
+			if isString(probeName) && isString(probeSymbol) && isUint(probeDecimals) {
+				tc.Extensions = append(tc.Extensions, TokenExtensionMetadata)
+			}
+			is4626 := has(selAsset, selTotalAssets, selConvertToShares, selConvertToAssets) ||
+				isUint(probeTotalAssets) && isAddress(results[probeAsset])
+			if is4626 {
+				tc.Standards = append(tc.Standards, TokenStandardERC4626)
+			}
+		}
+	}
+	if (is721 || is1155) && (supports(probeERC2981) || has(selRoyaltyInfo)) {
+		tc.Extensions = append(tc.Extensions, TokenExtensionRoyalty)
+	}
+	return tc, nil
+}
+
+// probe sends every calldata as an eth_call in one batch. Calls that revert
+// leave an empty string at their index.
+func (e *erc165Contract) probe(
+	ctx context.Context,
+	address string,
+	blockAndTag evmctypes.BlockAndTag,
+	data []string,
+) ([]string, error) {
+	var (
+		elements = make([]rpc.BatchElem, len(data))
+		results  = make([]string, len(data))
+	)
+	for i, d := range data {
+		elements[i] = rpc.BatchElem{
+			Method: EthCall.String(),
+			Args: []any{
+				&evmctypes.QueryParams{To: address, Data: d},
+				evmctypes.ToBlockAndTag(blockAndTag),
+			},
+			Result: &results[i],
+		}
+	}
+	if err := e.c.BatchCallWithContext(ctx, elements, -1); err != nil {
+		return nil, err
+	}
+	for i, el := range elements {
+		if el.Error != nil {
+			results[i] = ""
+		}
+	}
+	return results, nil
+}
+
+// isAddress reports whether an ABI-encoded return value is a non-zero address,
+// i.e. a 32-byte word with the upper 12 bytes cleared.
+func isAddress(solReturn string) bool {
+	if len(solReturn) != 66 {
+		return false
+	}
+	addr, err := evmcsoltypes.ParseSolAddress(solReturn)
+	if err != nil {
+		return false
+	}
+	return solReturn[2:26] == "000000000000000000000000" &&
+		addr != "0x0000000000000000000000000000000000000000"
+}
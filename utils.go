@@ -1,6 +1,14 @@
 package evmc
 
-import "unicode/utf8"
+import (
+	"fmt"
+	"math/big"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/shopspring/decimal"
+)
 
 func removeInvalidUTF8Bytes(data []byte) []byte {
 	if utf8.Valid(data) {
@@ -16,3 +24,24 @@ func removeInvalidUTF8Bytes(data []byte) []byte {
 	}
 	return valid
 }
+
+// topicToAddress converts an indexed address topic to a checksummed address.
+func topicToAddress(topic string) string {
+	return common.HexToAddress(topic).Hex()
+}
+
+// decodeUintWords decodes the first n 32-byte words of log data as uint256.
+func decodeUintWords(data string, n int) ([]decimal.Decimal, error) {
+	b, err := hexutil.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < n*32 {
+		return nil, fmt.Errorf("data too short: want %d words, got %d bytes", n, len(b))
+	}
+	words := make([]decimal.Decimal, n)
+	for i := range words {
+		words[i] = decimal.NewFromBigInt(new(big.Int).SetBytes(b[i*32:(i+1)*32]), 0)
+	}
+	return words, nil
+}
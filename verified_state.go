@@ -0,0 +1,182 @@
+package evmc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/shopspring/decimal"
+)
+
+// TrustAnchor is a block whose state root the caller trusts. Verified reads
+// are served at the block with Hash, or at Number when Hash is empty, and
+// checked against StateRoot.
+type TrustAnchor struct {
+	Number    uint64
+	Hash      string
+	StateRoot string
+}
+
+// block returns the block parameter of the reads. Pinning them to the anchor
+// hash keeps a reorg at Number from serving the state of another block.
+func (a *TrustAnchor) block() evmctypes.BlockAndTag {
+	if a.Hash != "" {
+		return evmctypes.FormatHash(a.Hash, true)
+	}
+	return evmctypes.FormatNumber(a.Number)
+}
+
+// NewTrustAnchor returns an anchor for a header obtained from a trusted
+// source (e.g. a consensus client or a second provider).
+func NewTrustAnchor(header *evmctypes.Header) *TrustAnchor {
+	return &TrustAnchor{Number: header.Number, Hash: header.Hash, StateRoot: header.StateRoot}
+}
+
+// PinTrustAnchor fetches the header of a pinned block hash and checks that
+// the header fields hash to it, so that the returned state root cannot be
+// forged by the provider.
+func (e *ethNamespace) PinTrustAnchor(blockHash string) (*TrustAnchor, error) {
+	return e.PinTrustAnchorWithContext(context.Background(), blockHash)
+}
+
+// PinTrustAnchorWithContext is the context-aware variant of [ethNamespace.PinTrustAnchor].
+func (e *ethNamespace) PinTrustAnchorWithContext(ctx context.Context, blockHash string) (*TrustAnchor, error) {
+	header := new(evmctypes.Header)
+	if err := e.getBlockByHash(ctx, header, blockHash, false); err != nil {
+		return nil, err
+	}
+	if header.Hash == "" {
+		return nil, fmt.Errorf("PinTrustAnchor: block %s not found", blockHash)
+	}
+	rebuilt, err := rebuildHeader(header)
+	if err != nil {
+		return nil, fmt.Errorf("PinTrustAnchor: %w", err)
+	}
+	if computed := rebuilt.Hash(); computed != common.HexToHash(blockHash) {
+		return nil, fmt.Errorf("PinTrustAnchor: %w: hash %s, computed %s", ErrBlockIntegrity, blockHash, computed)
+	}
+	return NewTrustAnchor(header), nil
+}
+
+// GetVerifiedBalance fetches the balance of address at the anchor block
+// together with its account proof, and fails with [ErrInvalidProof] or
+// [ErrStateMismatch] unless both agree with the anchor's state root.
+func (e *ethNamespace) GetVerifiedBalance(address string, anchor *TrustAnchor) (decimal.Decimal, error) {
+	return e.GetVerifiedBalanceWithContext(context.Background(), address, anchor)
+}
+
+// GetVerifiedBalanceWithContext is the context-aware variant of [ethNamespace.GetVerifiedBalance].
+func (e *ethNamespace) GetVerifiedBalanceWithContext(
+	ctx context.Context,
+	address string,
+	anchor *TrustAnchor,
+) (decimal.Decimal, error) {
+	var balance hexutil.Big
+	account, err := e.getVerifiedState(ctx, anchor, address, nil, rpc.BatchElem{
+		Method: EthGetBalance.String(),
+		Args:   []any{address, anchor.block()},
+		Result: &balance,
+	})
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("GetVerifiedBalance: %w", err)
+	}
+	if got := decimal.NewFromBigInt(balance.ToInt(), 0); !got.Equal(account.Balance) {
+		return decimal.Zero, fmt.Errorf("GetVerifiedBalance: %w: balance %s, proven %s", ErrStateMismatch, got, account.Balance)
+	}
+	return account.Balance, nil
+}
+
+// GetVerifiedStorageAt fetches a storage slot at the anchor block together
+// with its storage proof and returns the proven 32-byte word.
+func (e *ethNamespace) GetVerifiedStorageAt(address, position string, anchor *TrustAnchor) (string, error) {
+	return e.GetVerifiedStorageAtWithContext(context.Background(), address, position, anchor)
+}
+
+// GetVerifiedStorageAtWithContext is the context-aware variant of [ethNamespace.GetVerifiedStorageAt].
+func (e *ethNamespace) GetVerifiedStorageAtWithContext(
+	ctx context.Context,
+	address string,
+	position string,
+	anchor *TrustAnchor,
+) (string, error) {
+	var value string
+	account, err := e.getVerifiedState(ctx, anchor, address, []string{position}, rpc.BatchElem{
+		Method: EthGetStorageAt.String(),
+		Args:   []any{address, position, anchor.block()},
+		Result: &value,
+	})
+	if err != nil {
+		return "", fmt.Errorf("GetVerifiedStorageAt: %w", err)
+	}
+	if len(account.Storage) != 1 {
+		return "", fmt.Errorf("GetVerifiedStorageAt: %w: %d storage proofs", ErrInvalidProof, len(account.Storage))
+	}
+	proven := account.Storage[0].Value
+	if common.HexToHash(value).Hex() != proven {
+		return "", fmt.Errorf("GetVerifiedStorageAt: %w: value %s, proven %s", ErrStateMismatch, value, proven)
+	}
+	return proven, nil
+}
+
+// GetVerifiedCode fetches the code of address at the anchor block and checks
+// its hash against the proven codeHash.
+func (e *ethNamespace) GetVerifiedCode(address string, anchor *TrustAnchor) (string, error) {
+	return e.GetVerifiedCodeWithContext(context.Background(), address, anchor)
+}
+
+// GetVerifiedCodeWithContext is the context-aware variant of [ethNamespace.GetVerifiedCode].
+func (e *ethNamespace) GetVerifiedCodeWithContext(ctx context.Context, address string, anchor *TrustAnchor) (string, error) {
+	var code hexutil.Bytes
+	account, err := e.getVerifiedState(ctx, anchor, address, nil, rpc.BatchElem{
+		Method: EthGetCode.String(),
+		Args:   []any{address, anchor.block()},
+		Result: &code,
+	})
+	if err != nil {
+		return "", fmt.Errorf("GetVerifiedCode: %w", err)
+	}
+	if computed := crypto.Keccak256Hash(code).Hex(); !strings.EqualFold(computed, account.CodeHash) {
+		return "", fmt.Errorf("GetVerifiedCode: %w: code hash %s, proven %s", ErrStateMismatch, computed, account.CodeHash)
+	}
+	return code.String(), nil
+}
+
+// getVerifiedState sends read together with eth_getProof for the anchor
+// block in one batch and verifies the proof against the anchor's state root.
+func (e *ethNamespace) getVerifiedState(
+	ctx context.Context,
+	anchor *TrustAnchor,
+	address string,
+	storageKeys []string,
+	read rpc.BatchElem,
+) (*ProvenAccount, error) {
+	if storageKeys == nil {
+		storageKeys = []string{}
+	}
+	proof := new(evmctypes.AccountProof)
+	elements := []rpc.BatchElem{
+		read,
+		{
+			Method: EthGetProof.String(),
+			Args:   []any{address, storageKeys, anchor.block()},
+			Result: proof,
+		},
+	}
+	if err := e.c.BatchCallWithContext(ctx, elements, -1); err != nil {
+		return nil, err
+	}
+	for _, el := range elements {
+		if el.Error != nil {
+			return nil, el.Error
+		}
+	}
+	if !strings.EqualFold(proof.Address, address) {
+		return nil, fmt.Errorf("%w: proof for %s, requested %s", ErrInvalidProof, proof.Address, address)
+	}
+	return VerifyAccountProof(anchor.StateRoot, proof)
+}
@@ -0,0 +1,97 @@
+package evmc
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/bbaktaeho/evmc/evmctypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// verifiedStateMock은 proofFixture의 state를 제공하는 mock 노드를 만든다.
+func verifiedStateMock(t *testing.T) (*mockRPCServer, *evmctypes.AccountProof, *TrustAnchor) {
+	t.Helper()
+	root, proof, _ := proofFixture(t)
+	header := &types.Header{Number: big.NewInt(100), Root: root, Difficulty: big.NewInt(0)}
+	fields, err := headerFields(header)
+	require.NoError(t, err)
+
+	// 조회는 번호가 아니라 anchor 블록 해시(EIP-1898)에 고정되어야 한다.
+	pinned := `{"blockHash":"` + header.Hash().Hex() + `","requireCanonical":true}`
+	assertPinned := func(params json.RawMessage) {
+		var args []json.RawMessage
+		require.NoError(t, json.Unmarshal(params, &args))
+		assert.JSONEq(t, pinned, string(args[len(args)-1]))
+	}
+
+	mock := newMockRPCServer(t)
+	mock.on("eth_getBlockByHash", func(params json.RawMessage) any { return fields })
+	mock.on("eth_getProof", func(params json.RawMessage) any {
+		assertPinned(params)
+		return proof
+	})
+	mock.on("eth_getBalance", func(params json.RawMessage) any {
+		assertPinned(params)
+		return "0x3e8"
+	})
+	mock.on("eth_getStorageAt", func(params json.RawMessage) any {
+		return "0x000000000000000000000000000000000000000000000000000000000000002a"
+	})
+	mock.on("eth_getCode", func(params json.RawMessage) any { return "0x6000" })
+
+	return mock, proof, &TrustAnchor{Number: 100, Hash: header.Hash().Hex(), StateRoot: root.Hex()}
+}
+
+func Test_ethNamespace_mock_PinTrustAnchor(t *testing.T) {
+	mock, _, anchor := verifiedStateMock(t)
+	client := testEvmc(mock.url())
+
+	pinned, err := client.Eth().PinTrustAnchor(anchor.Hash)
+	require.NoError(t, err)
+	assert.Equal(t, anchor, pinned)
+
+	// 다른 해시를 고정했는데 노드가 엉뚱한 헤더를 돌려준 경우
+	_, err = client.Eth().PinTrustAnchor(common.HexToHash("0x01").Hex())
+	assert.ErrorIs(t, err, ErrBlockIntegrity)
+}
+
+func Test_ethNamespace_mock_GetVerifiedState(t *testing.T) {
+	mock, proof, anchor := verifiedStateMock(t)
+	client := testEvmc(mock.url())
+
+	balance, err := client.Eth().GetVerifiedBalance(proof.Address, anchor)
+	require.NoError(t, err)
+	assert.Equal(t, "1000", balance.String())
+
+	// eth_getProof는 slot 1과 2에 대한 증명을 반환하므로 slot 1 하나만 남긴다.
+	proof.StorageProof = proof.StorageProof[:1]
+	value, err := client.Eth().GetVerifiedStorageAt(proof.Address, "0x1", anchor)
+	require.NoError(t, err)
+	assert.Equal(t, common.BigToHash(big.NewInt(42)).Hex(), value)
+
+	code, err := client.Eth().GetVerifiedCode(proof.Address, anchor)
+	require.NoError(t, err)
+	assert.Equal(t, "0x6000", code)
+}
+
+func Test_ethNamespace_mock_GetVerifiedState_lyingProvider(t *testing.T) {
+	mock, proof, anchor := verifiedStateMock(t)
+	client := testEvmc(mock.url())
+
+	mock.on("eth_getBalance", func(params json.RawMessage) any { return "0x3e9" })
+	_, err := client.Eth().GetVerifiedBalance(proof.Address, anchor)
+	assert.ErrorIs(t, err, ErrStateMismatch)
+
+	mock.on("eth_getCode", func(params json.RawMessage) any { return "0x6001" })
+	_, err = client.Eth().GetVerifiedCode(proof.Address, anchor)
+	assert.ErrorIs(t, err, ErrStateMismatch)
+
+	// 신뢰하는 state root와 다른 state에 대한 증명
+	anchor.StateRoot = common.HexToHash("0x01").Hex()
+	_, err = client.Eth().GetVerifiedCode(proof.Address, anchor)
+	assert.ErrorIs(t, err, ErrInvalidProof)
+}
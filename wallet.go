@@ -50,3 +50,46 @@ func (w *Wallet) SignTx(sendingTx *SendingTx, chainID uint64) (hash, rawTx strin
 func (w *Wallet) Address() string {
 	return w.address
 }
+
+// SignKaiaTx signs tx as the sender and appends the signature to
+// tx.Signatures. It returns the transaction hash and the raw transaction;
+// for fee-delegated types, hand the raw transaction to the fee payer.
+func (w *Wallet) SignKaiaTx(tx *KaiaTx) (hash, rawTx string, err error) {
+	sigRLP, err := tx.SigRLP()
+	if err != nil {
+		return "", "", err
+	}
+	sig, err := signKaia(w, sigRLP, tx.ChainID)
+	if err != nil {
+		return "", "", err
+	}
+	tx.Signatures = append(tx.Signatures, sig)
+	return encodeKaiaTx(tx)
+}
+
+// SignKaiaTxAsFeePayer signs a fee-delegated tx as the fee payer and appends
+// the signature to tx.FeePayerSignatures. tx.FeePayer defaults to the
+// wallet address.
+func (w *Wallet) SignKaiaTxAsFeePayer(tx *KaiaTx) (hash, rawTx string, err error) {
+	if tx.FeePayer == "" {
+		tx.FeePayer = w.address
+	}
+	sigRLP, err := tx.FeePayerSigRLP()
+	if err != nil {
+		return "", "", err
+	}
+	sig, err := signKaia(w, sigRLP, tx.ChainID)
+	if err != nil {
+		return "", "", err
+	}
+	tx.FeePayerSignatures = append(tx.FeePayerSignatures, sig)
+	return encodeKaiaTx(tx)
+}
+
+func encodeKaiaTx(tx *KaiaTx) (hash, rawTx string, err error) {
+	raw, err := tx.RawTx()
+	if err != nil {
+		return "", "", err
+	}
+	return crypto.Keccak256Hash(raw).Hex(), hexutil.Encode(raw), nil
+}